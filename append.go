@@ -0,0 +1,73 @@
+package bitstream
+
+import "unsafe"
+
+// Append appends the bits written to other to the end of w, so sections encoded
+// separately (for example in parallel) can be stitched together.
+// other is not modified, and the cursor of w is not moved.
+// When w ends on an element boundary and both writers share the same padding,
+// whole elements are copied; otherwise the bits are copied 64 at a time.
+// w.Append(w) duplicates the contents of w.
+func (w *BitWriter[T]) Append(other *BitWriter[T]) {
+	if other == w {
+		w.lock()
+		defer w.unlock()
+		w.appendWriter(w)
+		return
+	}
+	// Lock in a fixed order so that concurrent a.Append(b) and b.Append(a) cannot deadlock.
+	first, second := w, other
+	if uintptr(unsafe.Pointer(first.mu)) > uintptr(unsafe.Pointer(second.mu)) {
+		first, second = second, first
+	}
+	first.lock()
+	defer first.unlock()
+	second.lock()
+	defer second.unlock()
+	w.appendWriter(other)
+}
+
+// Append appends the bits written to other to the end of w, like w.Append, for writers with
+// different element types, so sections encoded with one element type can be stitched onto a
+// stream of another. The bits are copied 64 at a time; writers of the same type take the
+// whole-element path of w.Append.
+func Append[T, U Unsigned](w *BitWriter[T], other *BitWriter[U]) {
+	if o, ok := any(other).(*BitWriter[T]); ok {
+		w.Append(o)
+		return
+	}
+	// Lock in the same order as w.Append, by mutex address.
+	if uintptr(unsafe.Pointer(w.mu)) < uintptr(unsafe.Pointer(other.mu)) {
+		w.lock()
+		defer w.unlock()
+		other.lock()
+		defer other.unlock()
+	} else {
+		other.lock()
+		defer other.unlock()
+		w.lock()
+		defer w.unlock()
+	}
+	if !w.reserve(w.bits + other.bits) {
+		return
+	}
+	r := other.reader()
+	appendBits64(w, &r, 0, other.bits)
+}
+
+// appendWriter appends the bits of other. The caller must hold both locks.
+func (w *BitWriter[T]) appendWriter(other *BitWriter[T]) {
+	nbits := other.bits
+	if !w.reserve(w.bits + nbits) {
+		return
+	}
+	if w.bits%w.s == 0 && w.lp == other.lp && w.rp == other.rp {
+		n := (nbits + other.s - 1) / other.s
+		w.data = append(w.data[:w.bits/w.s], other.data[:n]...)
+		w.bits += nbits
+		w.traceAppend(w.bits-nbits, nbits)
+		return
+	}
+	r := other.reader()
+	appendBits64(w, &r, 0, nbits)
+}
@@ -0,0 +1,104 @@
+package bitstream
+
+import (
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestAppend(t *testing.T) {
+	t.Run("Aligned", func(t *testing.T) {
+		a := NewBitWriter[uint16](2, 2)
+		a.WriteBits(0xABC, 12)
+		b := NewBitWriter[uint16](2, 2)
+		b.WriteBits(0xDEF1, 16)
+		a.Append(b)
+		if a.Bits() != 28 {
+			t.Errorf("Bits() = %d; want 28", a.Bits())
+		}
+		if got, want := a.ToHex(), "abcdef1"; got != want {
+			t.Errorf("ToHex() = %q; want %q", got, want)
+		}
+		if b.Bits() != 16 {
+			t.Errorf("other Bits() = %d; want 16", b.Bits())
+		}
+	})
+
+	t.Run("Unaligned", func(t *testing.T) {
+		a := NewBitWriter[uint8](0, 0)
+		a.WriteBits(0b101, 3)
+		b := NewBitWriter[uint8](1, 0)
+		for range 20 {
+			b.WriteBits(0b1100110, 7)
+		}
+		a.Append(b)
+		if a.Bits() != 143 {
+			t.Fatalf("Bits() = %d; want 143", a.Bits())
+		}
+		r := NewBitReader(a.Data(), 0, 0)
+		if v, _ := r.ReadBits(3); v != 0b101 {
+			t.Errorf("ReadBits(3) = %b; want 101", v)
+		}
+		for i := range 20 {
+			if v, _ := r.ReadBits(7); v != 0b1100110 {
+				t.Errorf("ReadBits(7) #%d = %b; want 1100110", i, v)
+			}
+		}
+	})
+
+	t.Run("OtherType", func(t *testing.T) {
+		a := NewBitWriter[uint32](1, 3)
+		a.WriteBits(0b101, 3)
+		b := NewBitWriter[uint8](0, 1)
+		for range 10 {
+			b.WriteBits(0b1100110, 7)
+		}
+		Append(a, b)
+		if a.Bits() != 73 || b.Bits() != 70 {
+			t.Fatalf("Bits() = %d, other Bits() = %d; want 73, 70", a.Bits(), b.Bits())
+		}
+		r := NewBitReader(a.Data(), 1, 3)
+		if v, _ := r.ReadBits(3); v != 0b101 {
+			t.Errorf("ReadBits(3) = %b; want 101", v)
+		}
+		for i := range 10 {
+			if v, _ := r.ReadBits(7); v != 0b1100110 {
+				t.Errorf("ReadBits(7) #%d = %b; want 1100110", i, v)
+			}
+		}
+		// writers of the same type are joined by w.Append
+		c := NewBitWriter[uint32](1, 3)
+		c.WriteBits(0xAB, 8)
+		Append(c, c)
+		if got := c.ToHex(); got != "abab" {
+			t.Errorf("Append(c, c): ToHex() = %s; want abab", got)
+		}
+	})
+
+	t.Run("Self", func(t *testing.T) {
+		for _, bits := range []int{5, 8} {
+			w := NewBitWriter[uint8](0, 0)
+			w.WriteBits(0b10110011, bits)
+			w.Append(w)
+			want := NewBitWriter[uint8](0, 0)
+			want.WriteBits(0b10110011, bits)
+			want.WriteBits(0b10110011, bits)
+			if !slices.Equal(w.Data(), want.Data()) || w.Bits() != 2*bits {
+				t.Errorf("%d bits: Data() = %08b; want %08b", bits, w.Data(), want.Data())
+			}
+		}
+	})
+
+	t.Run("Concurrent", func(t *testing.T) {
+		a, b := NewBitWriter[uint32](0, 0), NewBitWriter[uint32](0, 0)
+		a.WriteBits(1, 1)
+		b.WriteBits(1, 1)
+		var wg sync.WaitGroup
+		for range 10 {
+			wg.Add(2)
+			go func() { defer wg.Done(); a.Append(b) }()
+			go func() { defer wg.Done(); b.Append(a) }()
+		}
+		wg.Wait()
+	})
+}
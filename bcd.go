@@ -0,0 +1,134 @@
+package bitstream
+
+// maxBCDDigits is the largest number of decimal digits that always fits in a uint64.
+const maxBCDDigits = 19
+
+// ReadBCD reads a binary-coded-decimal field of digits 4-bit digits, most significant first,
+// at any bit alignment, and advances the cursor.
+// Returns ErrInvalidFormat if a nibble is greater than 9, and otherwise errors as for ReadBits.
+// The cursor is not moved on error. Panics unless digits is 1..19.
+func (r *BitReader[T]) ReadBCD(digits int) (uint64, error) {
+	if digits < 1 || digits > maxBCDDigits {
+		panic("bitstream: invalid BCD digit count")
+	}
+	start := r.pos
+	v, err := r.readDigits(digits)
+	if err != nil {
+		r.pos = start
+	}
+	return v, err
+}
+
+// ReadPackedDecimal reads a packed-decimal (COBOL COMP-3) field: digits BCD digits followed
+// by a sign nibble, where 0xD and 0xB mean negative and 0xA, 0xC, 0xE and 0xF mean positive.
+// Errors are reported as for ReadBCD, with ErrInvalidFormat also for a sign nibble below 0xA.
+// Panics unless digits is 1..18.
+func (r *BitReader[T]) ReadPackedDecimal(digits int) (int64, error) {
+	if digits < 1 || digits > maxBCDDigits-1 {
+		panic("bitstream: invalid BCD digit count")
+	}
+	start := r.pos
+	v, err := r.readDigits(digits)
+	if err != nil {
+		r.pos = start
+		return 0, err
+	}
+	sign, err := r.ReadBits(4)
+	if err != nil || sign < 0xA {
+		r.pos = start
+		if err == nil {
+			err = ErrInvalidFormat
+		} else {
+			err = eofAsUnexpected(err)
+		}
+		return 0, err
+	}
+	if sign == 0xB || sign == 0xD {
+		return -int64(v), nil
+	}
+	return int64(v), nil
+}
+
+// readDigits reads digits BCD nibbles, leaving the cursor wherever it stops.
+func (r *BitReader[T]) readDigits(digits int) (uint64, error) {
+	var v uint64
+	for i := range digits {
+		d, err := r.ReadBits(4)
+		if i > 0 {
+			err = eofAsUnexpected(err)
+		}
+		if err != nil {
+			return 0, err
+		}
+		if d > 9 {
+			return 0, ErrInvalidFormat
+		}
+		v = v*10 + d
+	}
+	return v, nil
+}
+
+// WriteBCD writes v as a binary-coded-decimal field of digits 4-bit digits,
+// most significant first and zero-filled on the left.
+// Returns ErrOverflow, writing nothing, if v has more than digits digits, and
+// ErrBudgetExceeded if the field would exceed the limit set by NewBitWriterLimit.
+// Panics unless digits is 1..19.
+func (w *BitWriter[T]) WriteBCD(v uint64, digits int) error {
+	if digits < 1 || digits > maxBCDDigits {
+		panic("bitstream: invalid BCD digit count")
+	}
+	if !fitsDigits(v, digits) {
+		return ErrOverflow
+	}
+	w.lock()
+	defer w.unlock()
+	if !w.reserveBits(4 * digits) {
+		return w.err
+	}
+	w.writeDigits(v, digits)
+	return nil
+}
+
+// WritePackedDecimal writes v as a packed-decimal (COBOL COMP-3) field: digits BCD digits
+// followed by a sign nibble, 0xC for positive or zero and 0xD for negative.
+// Errors are reported as for WriteBCD.
+// Panics unless digits is 1..18.
+func (w *BitWriter[T]) WritePackedDecimal(v int64, digits int) error {
+	if digits < 1 || digits > maxBCDDigits-1 {
+		panic("bitstream: invalid BCD digit count")
+	}
+	u, sign := uint64(v), uint64(0xC)
+	if v < 0 {
+		u, sign = -u, 0xD
+	}
+	if !fitsDigits(u, digits) {
+		return ErrOverflow
+	}
+	w.lock()
+	defer w.unlock()
+	if !w.reserveBits(4 * (digits + 1)) {
+		return w.err
+	}
+	w.writeDigits(u, digits)
+	w.writeBits(sign, 4)
+	return nil
+}
+
+func (w *BitWriter[T]) writeDigits(v uint64, digits int) {
+	var nibbles [maxBCDDigits]uint8
+	for i := digits - 1; i >= 0; i-- {
+		nibbles[i] = uint8(v % 10)
+		v /= 10
+	}
+	for _, d := range nibbles[:digits] {
+		w.writeBits(uint64(d), 4)
+	}
+}
+
+// fitsDigits reports whether v has at most digits decimal digits.
+func fitsDigits(v uint64, digits int) bool {
+	for range digits {
+		v /= 10
+	}
+	return v == 0
+}
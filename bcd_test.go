@@ -0,0 +1,77 @@
+package bitstream
+
+import (
+	"io"
+	"testing"
+)
+
+func TestBCD(t *testing.T) {
+	t.Run("ReadBCD", func(t *testing.T) {
+		reader := NewBitReader([]uint8{0b1010_0010, 0b0101_1001, 0b1000_0000}, 0, 0)
+		reader.Skip(4)
+		if v, err := reader.ReadBCD(3); v != 259 || err != nil {
+			t.Errorf("ReadBCD(3) = %d, %v; want 259, nil", v, err)
+		}
+		if v, err := reader.ReadBCD(1); v != 8 || err != nil {
+			t.Errorf("ReadBCD(1) = %d, %v; want 8, nil", v, err)
+		}
+		if _, err := reader.ReadBCD(3); err != io.ErrUnexpectedEOF || reader.Pos() != 20 {
+			t.Errorf("ReadBCD(3) = %v at %d; want io.ErrUnexpectedEOF at 20", err, reader.Pos())
+		}
+		reader.Seek(0)
+		if _, err := reader.ReadBCD(2); err != ErrInvalidFormat || reader.Pos() != 0 {
+			t.Errorf("ReadBCD(2) = %v at %d; want ErrInvalidFormat at 0", err, reader.Pos())
+		}
+	})
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		writer := NewBitWriter[uint16](0, 3)
+		writer.WriteBool(true)
+		for _, v := range []uint64{0, 7, 123456, 9999999999999999999} {
+			if err := writer.WriteBCD(v, 19); err != nil {
+				t.Fatalf("WriteBCD(%d, 19) error = %v; want nil", v, err)
+			}
+		}
+		reader := NewBitReader(writer.Data(), 0, 3)
+		reader.Skip(1)
+		for _, want := range []uint64{0, 7, 123456, 9999999999999999999} {
+			if v, err := reader.ReadBCD(19); v != want || err != nil {
+				t.Errorf("ReadBCD(19) = %d, %v; want %d, nil", v, err, want)
+			}
+		}
+	})
+
+	t.Run("WriteBCD", func(t *testing.T) {
+		writer := NewBitWriter[uint8](0, 0)
+		writer.WriteBCD(1234, 6)
+		if got := writer.ToHex(); got != "001234" {
+			t.Errorf("ToHex() = %q; want \"001234\"", got)
+		}
+		if err := writer.WriteBCD(1000, 3); err != ErrOverflow || writer.Bits() != 24 {
+			t.Errorf("WriteBCD(1000, 3) = %v with %d bits; want ErrOverflow with 24", err, writer.Bits())
+		}
+	})
+
+	t.Run("PackedDecimal", func(t *testing.T) {
+		writer := NewBitWriter[uint8](0, 0)
+		writer.WritePackedDecimal(-1234, 5)
+		writer.WritePackedDecimal(42, 3)
+		if got := writer.ToHex(); got != "01234d042c" {
+			t.Errorf("ToHex() = %q; want \"01234d042c\"", got)
+		}
+		if err := writer.WritePackedDecimal(-100, 2); err != ErrOverflow {
+			t.Errorf("WritePackedDecimal(-100, 2) error = %v; want ErrOverflow", err)
+		}
+		reader := NewBitReader(writer.Data(), 0, 0)
+		if v, err := reader.ReadPackedDecimal(5); v != -1234 || err != nil {
+			t.Errorf("ReadPackedDecimal(5) = %d, %v; want -1234, nil", v, err)
+		}
+		if v, err := reader.ReadPackedDecimal(3); v != 42 || err != nil {
+			t.Errorf("ReadPackedDecimal(3) = %d, %v; want 42, nil", v, err)
+		}
+		reader = NewBitReader([]uint8{0x12, 0x39}, 0, 0)
+		if _, err := reader.ReadPackedDecimal(3); err != ErrInvalidFormat || reader.Pos() != 0 {
+			t.Errorf("ReadPackedDecimal(3) = %v at %d; want ErrInvalidFormat at 0", err, reader.Pos())
+		}
+	})
+}
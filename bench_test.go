@@ -0,0 +1,200 @@
+package bitstream
+
+import (
+	"fmt"
+	"testing"
+)
+
+// benchWidths are the field widths exercised by the sequential benchmarks.
+var benchWidths = []int{1, 3, 8, 13, 32, 57, 64}
+
+// benchBits is the size of the streams read and written by the benchmarks.
+const benchBits = 1 << 16
+
+// benchPaddings lists the unpadded and padded layouts benchmarked for each element type.
+var benchPaddings = []struct {
+	name   string
+	lp, rp int
+}{
+	{"unpadded", 0, 0},
+	{"padded", 1, 1},
+}
+
+// benchData returns a writer holding benchBits pseudo-random bits.
+func benchData[T Unsigned](lp, rp int) *BitWriter[T] {
+	w := NewUnsyncBitWriter[T](lp, rp)
+	x := uint64(0x9E3779B97F4A7C15)
+	for range benchBits / 64 {
+		x ^= x << 13
+		x ^= x >> 7
+		x ^= x << 17
+		w.WriteBits(x, 64)
+	}
+	return w
+}
+
+func benchReadBits[T Unsigned](b *testing.B, name string) {
+	for _, p := range benchPaddings {
+		data := benchData[T](p.lp, p.rp).Data()
+		for _, width := range benchWidths {
+			b.Run(fmt.Sprintf("%s/%s/%d", name, p.name, width), func(b *testing.B) {
+				reader := NewBitReader(data, p.lp, p.rp)
+				b.SetBytes(benchBits / 8)
+				for b.Loop() {
+					reader.Seek(0)
+					for range benchBits / width {
+						reader.ReadBits(width)
+					}
+				}
+			})
+		}
+	}
+}
+
+func benchWriteBits[T Unsigned](b *testing.B, name string) {
+	for _, p := range benchPaddings {
+		for _, width := range benchWidths {
+			b.Run(fmt.Sprintf("%s/%s/%d", name, p.name, width), func(b *testing.B) {
+				writer := NewUnsyncBitWriter[T](p.lp, p.rp)
+				b.SetBytes(benchBits / 8)
+				for b.Loop() {
+					writer.Reset()
+					for i := range benchBits / width {
+						writer.WriteBits(uint64(i)*0x9E3779B97F4A7C15, width)
+					}
+				}
+			})
+		}
+	}
+}
+
+func benchRandomAccess[T Unsigned](b *testing.B, name string) {
+	for _, p := range benchPaddings {
+		data := benchData[T](p.lp, p.rp).Data()
+		b.Run(fmt.Sprintf("%s/%s", name, p.name), func(b *testing.B) {
+			reader := NewBitReader(data, p.lp, p.rp)
+			pos := 0
+			for b.Loop() {
+				for range 1024 {
+					// stride by a prime so positions hit every alignment
+					pos = (pos + 7919) % (reader.Bits() - 64)
+					reader.ReadBitsAt(pos, 37)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkReadBits reads a stream sequentially with ReadBits at widths 1 to 64,
+// for every element type, unpadded and padded.
+func BenchmarkReadBits(b *testing.B) {
+	benchReadBits[uint8](b, "uint8")
+	benchReadBits[uint16](b, "uint16")
+	benchReadBits[uint32](b, "uint32")
+	benchReadBits[uint64](b, "uint64")
+}
+
+// BenchmarkWriteBits writes a stream sequentially with WriteBits at widths 1 to 64,
+// for every element type, unpadded and padded.
+func BenchmarkWriteBits(b *testing.B) {
+	benchWriteBits[uint8](b, "uint8")
+	benchWriteBits[uint16](b, "uint16")
+	benchWriteBits[uint32](b, "uint32")
+	benchWriteBits[uint64](b, "uint64")
+}
+
+// BenchmarkRandomAccess reads 37-bit fields at scattered positions with ReadBitsAt.
+func BenchmarkRandomAccess(b *testing.B) {
+	benchRandomAccess[uint8](b, "uint8")
+	benchRandomAccess[uint16](b, "uint16")
+	benchRandomAccess[uint32](b, "uint32")
+	benchRandomAccess[uint64](b, "uint64")
+}
+
+// BenchmarkBulk compares copying a stream with Read and Write, which move 64 bits at a
+// time, and with Append, which copies whole elements when the layouts match.
+func BenchmarkBulk(b *testing.B) {
+	src := benchData[uint64](0, 0)
+	buf := make([]byte, benchBits/8)
+	b.Run("Read", func(b *testing.B) {
+		reader := NewBitReader(src.Data(), 0, 0)
+		b.SetBytes(benchBits / 8)
+		for b.Loop() {
+			reader.Seek(0)
+			reader.Read(buf)
+		}
+	})
+	b.Run("Write", func(b *testing.B) {
+		writer := NewUnsyncBitWriter[uint64](0, 0)
+		b.SetBytes(benchBits / 8)
+		for b.Loop() {
+			writer.Reset()
+			writer.Write(buf)
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		writer := NewUnsyncBitWriter[uint64](0, 0)
+		b.SetBytes(benchBits / 8)
+		for b.Loop() {
+			writer.Reset()
+			writer.Append(src)
+		}
+	})
+	b.Run("Count", func(b *testing.B) {
+		reader := NewBitReader(src.Data(), 0, 0)
+		b.SetBytes(benchBits / 8)
+		for b.Loop() {
+			reader.Count(0, reader.Bits())
+		}
+	})
+}
+
+// BenchmarkUnpack compares reading 11-bit fields one at a time with UnpackAll.
+func BenchmarkUnpack(b *testing.B) {
+	writer := NewBitWriter[uint64](0, 0)
+	for i := range 1 << 14 {
+		writer.WriteBits(uint64(i), 11)
+	}
+	b.Run("ReadBits", func(b *testing.B) {
+		reader := NewBitReader(writer.Data(), 0, 0)
+		reader.SetBits(writer.Bits())
+		for b.Loop() {
+			reader.Seek(0)
+			for range 1 << 14 {
+				reader.ReadBits(11)
+			}
+		}
+	})
+	b.Run("UnpackAll", func(b *testing.B) {
+		reader := NewBitReader(writer.Data(), 0, 0)
+		reader.SetBits(writer.Bits())
+		for b.Loop() {
+			reader.Seek(0)
+			reader.UnpackAll(11)
+		}
+	})
+}
+
+// BenchmarkPack compares writing 11-bit fields one at a time with PackAll.
+func BenchmarkPack(b *testing.B) {
+	values := make([]uint64, 1<<14)
+	for i := range values {
+		values[i] = uint64(i) & 0x7FF
+	}
+	b.Run("WriteBits", func(b *testing.B) {
+		writer := NewUnsyncBitWriter[uint64](0, 0)
+		for b.Loop() {
+			writer.Reset()
+			for _, v := range values {
+				writer.WriteBits(v, 11)
+			}
+		}
+	})
+	b.Run("PackAll", func(b *testing.B) {
+		writer := NewUnsyncBitWriter[uint64](0, 0)
+		for b.Loop() {
+			writer.Reset()
+			writer.PackAll(values, 11)
+		}
+	})
+}
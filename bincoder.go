@@ -0,0 +1,190 @@
+package bitstream
+
+import "math"
+
+// BinRule defines how a BinContext estimates and adapts the probability of a binary
+// decision (a bin). The state is opaque to the coder: P0 maps it to a probability and
+// Next advances it after each coded bin, so both table-driven state machines (as in
+// H.264/H.265 CABAC) and counter-based estimators (as in LZMA and AV1) can be expressed.
+type BinRule interface {
+	// Init returns the state for a fresh context.
+	Init() uint16
+	// P0 returns the probability that the bin is 0 in units of 1/MaxModelTotal,
+	// between 1 and MaxModelTotal-1.
+	P0(state uint16) uint32
+	// Next returns the state after coding bit in state.
+	Next(state uint16, bit bool) uint16
+}
+
+// BinContext is the adaptive probability of one kind of binary decision.
+// A coder typically keeps an array of contexts indexed by syntax element and neighbourhood.
+type BinContext struct {
+	rule  BinRule
+	state uint16
+}
+
+// NewBinContext returns a context in the initial state of rule.
+func NewBinContext(rule BinRule) BinContext {
+	return BinContext{rule: rule, state: rule.Init()}
+}
+
+// NewBinContexts returns n contexts in the initial state of rule.
+func NewBinContexts(rule BinRule, n int) []BinContext {
+	ctx := make([]BinContext, n)
+	for i := range ctx {
+		ctx[i] = NewBinContext(rule)
+	}
+	return ctx
+}
+
+// State returns the rule-specific state of the context.
+func (c *BinContext) State() uint16 {
+	return c.state
+}
+
+// SetState sets the rule-specific state, for example from a codec's initialization table.
+func (c *BinContext) SetState(state uint16) {
+	c.state = state
+}
+
+// P0 returns the current probability that the next bin is 0.
+func (c *BinContext) P0() float64 {
+	return float64(c.rule.P0(c.state)) / MaxModelTotal
+}
+
+func (c *BinContext) p0() uint64 {
+	p := c.rule.P0(c.state)
+	if p < 1 || p >= MaxModelTotal {
+		panic("bitstream: invalid bin probability")
+	}
+	return uint64(p)
+}
+
+// EncodeBit codes one bin with the probability of c, then adapts c.
+// Bins and symbols from Models can be interleaved freely.
+//
+// Panics if the rule of c reports a probability outside [1, MaxModelTotal-1].
+func (e *RangeEncoder[T]) EncodeBit(c *BinContext, bit bool) {
+	p := c.p0()
+	if bit {
+		e.narrow(p, MaxModelTotal, MaxModelTotal)
+	} else {
+		e.narrow(0, p, MaxModelTotal)
+	}
+	c.state = c.rule.Next(c.state, bit)
+}
+
+// EncodeBypass codes one bin with probability 1/2 and no context, as CABAC does for
+// bins that are close to uniformly distributed, such as sign bits and suffixes.
+func (e *RangeEncoder[T]) EncodeBypass(bit bool) {
+	if bit {
+		e.narrow(1, 2, 2)
+	} else {
+		e.narrow(0, 1, 2)
+	}
+}
+
+// DecodeBit decodes one bin coded by EncodeBit with the same context, then adapts c.
+//
+// Panics if the rule of c reports a probability outside [1, MaxModelTotal-1].
+func (d *RangeDecoder[T]) DecodeBit(c *BinContext) bool {
+	p := c.p0()
+	bit := d.target(MaxModelTotal) >= p
+	if bit {
+		d.narrow(p, MaxModelTotal, MaxModelTotal)
+	} else {
+		d.narrow(0, p, MaxModelTotal)
+	}
+	c.state = c.rule.Next(c.state, bit)
+	return bit
+}
+
+// DecodeBypass decodes one bin coded by EncodeBypass.
+func (d *RangeDecoder[T]) DecodeBypass() bool {
+	bit := d.target(2) != 0
+	if bit {
+		d.narrow(1, 2, 2)
+	} else {
+		d.narrow(0, 1, 2)
+	}
+	return bit
+}
+
+// ShiftRule returns a BinRule that keeps the probability as a 16-bit counter and moves it
+// 1/2^rate of the way towards each coded bin, the estimator used by LZMA (rate 5) and AV1.
+// Smaller rates adapt faster; larger rates settle on a more precise estimate.
+//
+// Panics if rate is not between 1 and 15.
+func ShiftRule(rate int) BinRule {
+	if rate < 1 || rate > 15 {
+		panic("bitstream: shift rate must be between 1 and 15")
+	}
+	return shiftRule(rate)
+}
+
+type shiftRule int
+
+func (shiftRule) Init() uint16 {
+	return MaxModelTotal / 2
+}
+
+func (shiftRule) P0(state uint16) uint32 {
+	return max(uint32(state), 1)
+}
+
+func (r shiftRule) Next(state uint16, bit bool) uint16 {
+	if bit {
+		return state - state>>r
+	}
+	return state + uint16((MaxModelTotal-uint32(state))>>r)
+}
+
+// CABAC is the BinRule of H.264 and H.265 CABAC: 64 probability states for the less
+// probable symbol (LPS), from 0.5 down to about 0.01875, and the value of the more probable
+// symbol (MPS). The state is pStateIdx<<1 | valMPS, so codec initialization tables can be
+// loaded with SetState. Probabilities come from the standard's geometric model rather than
+// its quantized range table, so streams are not bit-exact with a real CABAC engine.
+var CABAC BinRule = cabacRule{}
+
+type cabacRule struct{}
+
+// cabacLPS is the LPS probability of each CABAC state in units of 1/MaxModelTotal.
+var cabacLPS = func() (p [64]uint32) {
+	alpha := math.Pow(0.01875/0.5, 1.0/63)
+	for i := range p {
+		p[i] = uint32(math.Round(0.5 * math.Pow(alpha, float64(i)) * MaxModelTotal))
+	}
+	return p
+}()
+
+// cabacNextLPS is transIdxLPS from H.264 Table 9-45.
+var cabacNextLPS = [64]uint8{
+	0, 0, 1, 2, 2, 4, 4, 5, 6, 7, 8, 9, 9, 11, 11, 12,
+	13, 13, 15, 15, 16, 16, 18, 18, 19, 19, 21, 21, 22, 22, 23, 24,
+	24, 25, 26, 26, 27, 27, 28, 29, 29, 30, 30, 30, 31, 32, 32, 33,
+	33, 33, 34, 34, 35, 35, 35, 36, 36, 36, 37, 37, 37, 38, 38, 63,
+}
+
+func (cabacRule) Init() uint16 {
+	return 0
+}
+
+func (cabacRule) P0(state uint16) uint32 {
+	lps := cabacLPS[state>>1&63]
+	if state&1 == 0 {
+		// MPS is 0
+		return MaxModelTotal - lps
+	}
+	return lps
+}
+
+func (cabacRule) Next(state uint16, bit bool) uint16 {
+	idx, mps := state>>1&63, state&1
+	if bit == (mps == 1) {
+		return min(idx+1, 62)<<1 | mps
+	}
+	if idx == 0 {
+		mps ^= 1
+	}
+	return uint16(cabacNextLPS[idx])<<1 | mps
+}
@@ -0,0 +1,82 @@
+package bitstream
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+func TestBinCoder(t *testing.T) {
+	for name, rule := range map[string]BinRule{"CABAC": CABAC, "Shift": ShiftRule(5)} {
+		t.Run(name, func(t *testing.T) {
+			rnd := rand.New(rand.NewPCG(3, 4))
+			bins := make([]bool, 4000)
+			for i := range bins {
+				// context i%2 is mostly 1, context 0 is mostly 0
+				bins[i] = rnd.Float64() < 0.05 != (i%2 == 1)
+			}
+			writer := NewBitWriter[uint8](0, 0)
+			enc := NewRangeEncoder(writer)
+			ctx := NewBinContexts(rule, 2)
+			m := NewFrequencyModel(10)
+			for i, b := range bins {
+				enc.EncodeBit(&ctx[i%2], b)
+				if i%100 == 0 {
+					enc.EncodeBypass(i%200 == 0)
+					enc.Encode(m, i/100%10)
+				}
+			}
+			enc.Flush()
+			if writer.Bits() > len(bins)/2 {
+				t.Errorf("encoded %d bins in %d bits; want at most %d", len(bins), writer.Bits(), len(bins)/2)
+			}
+			if p := ctx[1].P0(); p > 0.3 {
+				t.Errorf("P0() of mostly-1 context = %v; want below 0.3", p)
+			}
+
+			reader := NewBitReader(writer.Data(), 0, 0)
+			reader.SetBits(writer.Bits())
+			dec := NewRangeDecoder(reader)
+			ctx = NewBinContexts(rule, 2)
+			m = NewFrequencyModel(10)
+			for i, want := range bins {
+				if got := dec.DecodeBit(&ctx[i%2]); got != want {
+					t.Fatalf("DecodeBit() #%d = %v; want %v", i, got, want)
+				}
+				if i%100 == 0 {
+					if got := dec.DecodeBypass(); got != (i%200 == 0) {
+						t.Fatalf("DecodeBypass() #%d = %v; want %v", i, got, i%200 == 0)
+					}
+					if got := dec.Decode(m); got != i/100%10 {
+						t.Fatalf("Decode() #%d = %d; want %d", i, got, i/100%10)
+					}
+				}
+			}
+		})
+	}
+
+	t.Run("CABACStates", func(t *testing.T) {
+		c := NewBinContext(CABAC)
+		if c.P0() != 0.5 {
+			t.Errorf("P0() = %v; want 0.5", c.P0())
+		}
+		tests := []struct {
+			state uint16
+			bit   bool
+			want  uint16
+		}{
+			{0<<1 | 0, true, 0<<1 | 1},   // an LPS in state 0 flips the MPS
+			{0<<1 | 0, false, 1<<1 | 0},  // an MPS moves to a less likely LPS
+			{62<<1 | 1, true, 62<<1 | 1}, // state 62 is the most skewed adaptive state
+			{62<<1 | 1, false, 38<<1 | 1},
+		}
+		for _, tt := range tests {
+			if got := CABAC.Next(tt.state, tt.bit); got != tt.want {
+				t.Errorf("Next(%d, %v) = %d; want %d", tt.state, tt.bit, got, tt.want)
+			}
+		}
+		c.SetState(62<<1 | 1)
+		if p := c.P0(); p < 0.018 || p > 0.02 {
+			t.Errorf("P0() in state 62 = %v; want about 0.01875", p)
+		}
+	})
+}
@@ -0,0 +1,175 @@
+package bitstream
+
+import "unsafe"
+
+// BitSet is a set of non-negative integers stored as bits in an integer slice,
+// using the same padding convention as BitReader and BitWriter:
+// bit i lives at position i of the valid bit stream.
+// The set grows automatically when bits beyond its length are set.
+//
+// BitSet is not safe for concurrent use.
+type BitSet[T Unsigned] struct {
+	data []T // Storage
+	bits int // Length of the set in bits
+	s    int // Number of valid bits per element (element size - left padding - right padding)
+	msb  T   // MSB mask for the valid bit range
+	lp   int // Left padding bits
+	rp   int // Right padding bits
+}
+
+// NewBitSet creates an empty BitSet.
+// leftPadd and rightPadd specify how many upper and lower bits of each element are padding,
+// as for NewBitWriter.
+//
+// Panics if leftPadd + rightPadd >= element bit size, as this would leave no valid bits.
+func NewBitSet[T Unsigned](leftPadd, rightPadd int) *BitSet[T] {
+	var zero T
+	size := int(unsafe.Sizeof(zero)) * 8
+	if leftPadd+rightPadd >= size {
+		panic("bitstream: padding sum must be less than element bit size")
+	}
+	return &BitSet[T]{
+		s:   size - leftPadd - rightPadd,
+		msb: T(1) << (size - leftPadd - 1),
+		lp:  leftPadd,
+		rp:  rightPadd,
+	}
+}
+
+// Len returns the length of the set in bits: one more than the highest bit ever set or flipped.
+func (b *BitSet[T]) Len() int {
+	return b.bits
+}
+
+// Data returns the underlying storage.
+// Use Len() to get the number of valid bits.
+func (b *BitSet[T]) Data() []T {
+	return b.data
+}
+
+// Set sets bit i, growing the set if necessary.
+//
+// Panics if i is negative.
+func (b *BitSet[T]) Set(i int) {
+	if i < 0 {
+		panic("bitstream: negative position")
+	}
+	b.grow(i + 1)
+	b.data[i/b.s] |= b.msb >> (i % b.s)
+}
+
+// Clear clears bit i. Clearing a bit beyond Len() does nothing.
+//
+// Panics if i is negative.
+func (b *BitSet[T]) Clear(i int) {
+	if i < 0 {
+		panic("bitstream: negative position")
+	}
+	if i < b.bits {
+		b.data[i/b.s] &^= b.msb >> (i % b.s)
+	}
+}
+
+// Flip toggles bit i, growing the set if necessary.
+//
+// Panics if i is negative.
+func (b *BitSet[T]) Flip(i int) {
+	if i < 0 {
+		panic("bitstream: negative position")
+	}
+	b.grow(i + 1)
+	b.data[i/b.s] ^= b.msb >> (i % b.s)
+}
+
+// Test reports whether bit i is set. Bits beyond Len() are never set.
+func (b *BitSet[T]) Test(i int) bool {
+	if i < 0 || i >= b.bits {
+		return false
+	}
+	return b.data[i/b.s]&(b.msb>>(i%b.s)) != 0
+}
+
+// Count returns the number of set bits.
+func (b *BitSet[T]) Count() int {
+	return popcount(b.data)
+}
+
+// CountRange returns the number of set bits in the range [from, to).
+// The range is clipped to [0, Len()).
+func (b *BitSet[T]) CountRange(from, to int) int {
+	return countOnes(b.data, b.s, b.rp, max(from, 0), min(to, b.bits))
+}
+
+// NextSet returns the position of the first set bit at or after from, or -1 if there is none.
+func (b *BitSet[T]) NextSet(from int) int {
+	return nextBit(b.data, b.s, b.rp, max(from, 0), b.bits, true)
+}
+
+// NextClear returns the position of the first clear bit at or after from within Len(),
+// or -1 if there is none.
+func (b *BitSet[T]) NextClear(from int) int {
+	return nextBit(b.data, b.s, b.rp, max(from, 0), b.bits, false)
+}
+
+// And sets b to the intersection of b and other.
+//
+// Panics if other has a different padding configuration.
+func (b *BitSet[T]) And(other *BitSet[T]) {
+	b.checkLayout(other)
+	for i := range b.data {
+		if i < len(other.data) {
+			b.data[i] &= other.data[i]
+		} else {
+			b.data[i] = 0
+		}
+	}
+}
+
+// Or sets b to the union of b and other.
+//
+// Panics if other has a different padding configuration.
+func (b *BitSet[T]) Or(other *BitSet[T]) {
+	b.checkLayout(other)
+	b.grow(other.bits)
+	for i := range other.data {
+		b.data[i] |= other.data[i]
+	}
+}
+
+// Xor sets b to the symmetric difference of b and other.
+//
+// Panics if other has a different padding configuration.
+func (b *BitSet[T]) Xor(other *BitSet[T]) {
+	b.checkLayout(other)
+	b.grow(other.bits)
+	for i := range other.data {
+		b.data[i] ^= other.data[i]
+	}
+}
+
+// AndNot removes the bits of other from b.
+//
+// Panics if other has a different padding configuration.
+func (b *BitSet[T]) AndNot(other *BitSet[T]) {
+	b.checkLayout(other)
+	for i := range min(len(b.data), len(other.data)) {
+		b.data[i] &^= other.data[i]
+	}
+}
+
+func (b *BitSet[T]) checkLayout(other *BitSet[T]) {
+	if b.lp != other.lp || b.rp != other.rp {
+		panic("bitstream: BitSet padding mismatch")
+	}
+}
+
+// grow extends the set to at least n bits.
+func (b *BitSet[T]) grow(n int) {
+	if n <= b.bits {
+		return
+	}
+	b.bits = n
+	if e := (n + b.s - 1) / b.s; e > len(b.data) {
+		b.data = append(b.data, make([]T, e-len(b.data))...)
+	}
+}
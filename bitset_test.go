@@ -0,0 +1,131 @@
+package bitstream
+
+import "testing"
+
+func TestBitSet(t *testing.T) {
+	t.Run("SetClearFlipTest", func(t *testing.T) {
+		set := NewBitSet[uint8](1, 1)
+		set.Set(0)
+		set.Set(7)
+		set.Set(20)
+		if set.Len() != 21 {
+			t.Errorf("Len() = %d; want 21", set.Len())
+		}
+		// 6 valid bits per element: bit 7 is the second bit of element 1
+		if data := set.Data(); len(data) != 4 || data[0] != 0b01000000 || data[1] != 0b00100000 {
+			t.Errorf("Data() = %08b; want [01000000 00100000 ...] with 4 elements", data)
+		}
+		for i := range 25 {
+			want := i == 0 || i == 7 || i == 20
+			if set.Test(i) != want {
+				t.Errorf("Test(%d) = %v; want %v", i, set.Test(i), want)
+			}
+		}
+		set.Clear(7)
+		set.Clear(100)
+		set.Flip(0)
+		set.Flip(3)
+		if set.Test(7) || set.Test(0) || !set.Test(3) {
+			t.Errorf("after Clear/Flip: Test(7) = %v, Test(0) = %v, Test(3) = %v; want false, false, true", set.Test(7), set.Test(0), set.Test(3))
+		}
+		if set.Count() != 2 {
+			t.Errorf("Count() = %d; want 2", set.Count())
+		}
+		if set.Test(-1) {
+			t.Error("Test(-1) = true; want false")
+		}
+	})
+
+	t.Run("CountRange", func(t *testing.T) {
+		set := NewBitSet[uint8](2, 1)
+		for _, i := range []int{0, 4, 5, 6, 11, 30} {
+			set.Set(i)
+		}
+		tests := []struct{ from, to, want int }{
+			{0, 31, 6}, {1, 31, 5}, {4, 7, 3}, {5, 11, 2}, {5, 12, 3},
+			{-5, 100, 6}, {12, 30, 0}, {10, 5, 0},
+		}
+		for _, tt := range tests {
+			if got := set.CountRange(tt.from, tt.to); got != tt.want {
+				t.Errorf("CountRange(%d, %d) = %d; want %d", tt.from, tt.to, got, tt.want)
+			}
+		}
+	})
+
+	t.Run("NextSet_NextClear", func(t *testing.T) {
+		set := NewBitSet[uint16](3, 0)
+		for _, i := range []int{2, 13, 14, 40} {
+			set.Set(i)
+		}
+		var got []int
+		for i := set.NextSet(0); i >= 0; i = set.NextSet(i + 1) {
+			got = append(got, i)
+		}
+		if len(got) != 4 || got[0] != 2 || got[1] != 13 || got[2] != 14 || got[3] != 40 {
+			t.Errorf("NextSet iteration = %v; want [2 13 14 40]", got)
+		}
+		if n := set.NextClear(13); n != 15 {
+			t.Errorf("NextClear(13) = %d; want 15", n)
+		}
+		if n := set.NextClear(40); n != -1 {
+			t.Errorf("NextClear(40) = %d; want -1", n)
+		}
+		if n := set.NextSet(41); n != -1 {
+			t.Errorf("NextSet(41) = %d; want -1", n)
+		}
+	})
+
+	t.Run("SetOperations", func(t *testing.T) {
+		build := func(bits ...int) *BitSet[uint32] {
+			set := NewBitSet[uint32](0, 4)
+			for _, i := range bits {
+				set.Set(i)
+			}
+			return set
+		}
+		members := func(set *BitSet[uint32]) []int {
+			var m []int
+			for i := set.NextSet(0); i >= 0; i = set.NextSet(i + 1) {
+				m = append(m, i)
+			}
+			return m
+		}
+		equal := func(a, b []int) bool {
+			if len(a) != len(b) {
+				return false
+			}
+			for i := range a {
+				if a[i] != b[i] {
+					return false
+				}
+			}
+			return true
+		}
+		tests := []struct {
+			name string
+			op   func(a, b *BitSet[uint32])
+			want []int
+		}{
+			{"And", (*BitSet[uint32]).And, []int{5, 30}},
+			{"Or", (*BitSet[uint32]).Or, []int{1, 5, 30, 31, 70}},
+			{"Xor", (*BitSet[uint32]).Xor, []int{1, 31, 70}},
+			{"AndNot", (*BitSet[uint32]).AndNot, []int{1}},
+		}
+		for _, tt := range tests {
+			a := build(1, 5, 30)
+			tt.op(a, build(5, 30, 31, 70))
+			if got := members(a); !equal(got, tt.want) {
+				t.Errorf("%s = %v; want %v", tt.name, got, tt.want)
+			}
+		}
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("expected panic for padding mismatch")
+			}
+		}()
+		NewBitSet[uint8](0, 0).Or(NewBitSet[uint8](1, 0))
+	})
+}
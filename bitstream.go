@@ -1,8 +1,12 @@
 package bitstream
 
 import (
+	"encoding/binary"
 	"errors"
 	"io"
+	"math"
+	"math/bits"
+	"slices"
 	"sync"
 	"unsafe"
 )
@@ -10,12 +14,44 @@ import (
 var (
 	// ErrNegativePosition is returned when a negative position is provided to Seek or WriteBitAt/ReadBitAt.
 	ErrNegativePosition = errors.New("bitstream: negative position")
+	// ErrInvalidWhence is returned when SeekBit is called with an unknown whence value.
+	ErrInvalidWhence = errors.New("bitstream: invalid whence")
+	// ErrOverflow is returned when a value does not fit where it must be stored, such as a
+	// variable-length code longer than 64 bits or a position beyond the largest int.
+	ErrOverflow = errors.New("bitstream: value overflows its range")
+	// ErrInvalidFormat is returned when UnmarshalBinary is given data it cannot decode.
+	ErrInvalidFormat = errors.New("bitstream: invalid binary format")
+	// ErrBudgetExceeded is returned when a section of a MultiWriter holds more bits than its budget,
+	// or when a write would exceed the limit of a writer created by NewBitWriterLimit.
+	ErrBudgetExceeded = errors.New("bitstream: bit budget exceeded")
+	// ErrLengthMismatch is returned when combining streams with different numbers of valid bits.
+	ErrLengthMismatch = errors.New("bitstream: stream lengths differ")
+	// ErrNotAligned is returned when a byte-oriented read starts off a byte boundary.
+	ErrNotAligned = errors.New("bitstream: position is not byte-aligned")
+	// ErrChecksum is returned when a frame's CRC does not match its contents.
+	ErrChecksum = errors.New("bitstream: checksum mismatch")
+	// ErrNoData is returned by a non-blocking reader when the bits requested have not arrived yet.
+	ErrNoData = errors.New("bitstream: no data available")
+	// ErrInvalidArgument is returned by Try when a function of this package was called with an
+	// argument it would otherwise panic on, such as an impossible padding or field width.
+	ErrInvalidArgument = errors.New("bitstream: invalid argument")
 )
 
 type Unsigned interface {
 	~uint64 | ~uint32 | ~uint16 | ~uint8 | ~uint
 }
 
+// Writer is the bit-appending method set shared by every *BitWriter[T].
+// It lets code such as the methods emitted by cmd/bitstreamgen accept a writer of any element type.
+type Writer interface {
+	WriteBits(data uint64, bits int)
+}
+
+// Reader is the cursor-reading method set shared by every *BitReader[T].
+type Reader interface {
+	ReadBits(bits int) (uint64, error)
+}
+
 // BitReader provides bit-level reading operations on integer slice data.
 // It treats the data as a continuous bit stream, allowing precise bit extraction.
 //
@@ -27,7 +63,14 @@ type BitReader[T Unsigned] struct {
 	bits int // Total number of valid bits in the data
 	s    int // Number of valid bits per element (element size - left padding - right padding)
 	msb  T   // MSB mask for the valid bit range
+	lp   int // Left padding bits
+	rp   int // Right padding bits
 	pos  int // Current read position (cursor)
+	off  int // Offset of bit 0 into data, nonzero for readers returned by Slice
+
+	unaligned bool   // Allow byte-order reads at any bit position, see AllowUnaligned
+	detailed  bool   // Return *RangeError for reads past the end, see SetDetailedErrors
+	tracer    Tracer // Notified of every field read, see SetTracer
 }
 
 // NewBitReader creates a new BitReader for manipulating bits from integer slice data.
@@ -37,19 +80,37 @@ type BitReader[T Unsigned] struct {
 // set leftPadd=2 and rightPadd=1.
 // The reader will only access bits from position leftPadd to (element size - rightPadd).
 //
-// Panics if leftPadd + rightPadd >= element bit size, as this would leave no valid bits to read.
+// Panics if leftPadd + rightPadd >= element bit size, as this would leave no valid bits to read,
+// or if data has more valid bits than an int can count.
 func NewBitReader[T Unsigned](data []T, leftPadd, rightPadd int) *BitReader[T] {
+	r := &BitReader[T]{}
+	r.Reset(data, leftPadd, rightPadd)
+	return r
+}
+
+// Reset makes the BitReader read from data with the given padding, as if it had been
+// created by NewBitReader, so a single reader can be reused across many buffers.
+// The cursor is moved back to 0 and any limit set by SetBits is discarded.
+//
+// Panics if leftPadd + rightPadd >= element bit size, as this would leave no valid bits to read,
+// or if data has more valid bits than an int can count.
+func (r *BitReader[T]) Reset(data []T, leftPadd, rightPadd int) {
 	var zero T
 	size := int(unsafe.Sizeof(zero)) * 8
 	if leftPadd+rightPadd >= size {
 		panic("bitstream: padding sum must be less than element bit size")
 	}
 	s := size - leftPadd - rightPadd
-	return &BitReader[T]{
+	if len(data) > math.MaxInt/s {
+		panic("bitstream: data too large for int bit positions")
+	}
+	*r = BitReader[T]{
 		data: data,
 		bits: len(data) * s,
 		s:    s,
 		msb:  T(1) << (size - leftPadd - 1),
+		lp:   leftPadd,
+		rp:   rightPadd,
 		pos:  0,
 	}
 }
@@ -61,7 +122,7 @@ func NewBitReader[T Unsigned](data []T, leftPadd, rightPadd int) *BitReader[T] {
 // regardless of the actual padding configuration.
 // This is useful for limiting the readable range within the data.
 func (r *BitReader[T]) SetBits(bits int) {
-	if max := len(r.data) * r.s; bits > max {
+	if max := len(r.data)*r.s - r.off; bits > max {
 		bits = max
 		return
 	}
@@ -127,6 +188,8 @@ func (r *BitReader[T]) Bits() int {
 
 // Data returns the source data slice.
 // Use Bits() to get the total number of valid bits.
+// For a reader returned by Slice, it is the run of elements covering the window,
+// whose first valid bit need not be bit 0 of the window.
 func (r *BitReader[T]) Data() []T {
 	return r.data
 }
@@ -142,13 +205,53 @@ func (r *BitReader[T]) AnyData() any {
 // Returns false and io.EOF if the position is beyond the valid bits.
 func (r *BitReader[T]) ReadBit() (bool, error) {
 	if r.pos >= r.bits {
-		return false, io.EOF
+		return false, r.eof(r.pos, 1)
 	}
 	bit := r.readBitAt(r.pos)
+	if r.tracer != nil {
+		r.tracer.OnRead(r.pos, 1, b2u(bit))
+	}
 	r.pos++
 	return bit, nil
 }
 
+// ReadBits reads the specified number of bits at the current position and advances the cursor.
+// Returns the bits as a uint64 value, right-aligned (LSB-aligned).
+// Returns 0 and io.EOF if no valid bits remain, or 0 and io.ErrUnexpectedEOF
+// if fewer than bits valid bits remain. The cursor is not moved on error.
+//
+// Panics if bits > 64.
+func (r *BitReader[T]) ReadBits(bits int) (uint64, error) {
+	v, err := r.PeekBits(bits)
+	if err != nil {
+		return 0, err
+	}
+	if r.tracer != nil && bits > 0 {
+		r.tracer.OnRead(r.pos, bits, v)
+	}
+	r.pos += bits
+	return v, nil
+}
+
+// PeekBits reads the specified number of bits at the current position without moving the cursor.
+// Returns the bits as a uint64 value, right-aligned (LSB-aligned).
+// Returns 0 and io.EOF if no valid bits remain, or 0 and io.ErrUnexpectedEOF
+// if fewer than bits valid bits remain.
+//
+// Panics if bits > 64.
+func (r *BitReader[T]) PeekBits(bits int) (uint64, error) {
+	if bits > 64 {
+		panic(&WidthError{Op: "read", Width: bits, Max: 64})
+	}
+	if bits <= 0 {
+		return 0, nil
+	}
+	if bits > r.bits-r.pos {
+		return 0, r.eof(r.pos, bits)
+	}
+	return r.bitsAt(r.pos, bits), nil
+}
+
 // ReadBitAt reads one bit at the specified position without moving the cursor.
 // Returns false and io.EOF if the position is beyond the valid bits.
 // Returns false and ErrNegativePosition for negative positions.
@@ -157,11 +260,33 @@ func (r *BitReader[T]) ReadBitAt(pos int) (bool, error) {
 		return false, ErrNegativePosition
 	}
 	if pos >= r.bits {
-		return false, io.EOF
+		return false, r.eof(pos, 1)
 	}
 	return r.readBitAt(pos), nil
 }
 
+// ReadBitsAt reads the specified number of bits starting at pos without moving the cursor.
+// Returns the bits as a uint64 value, right-aligned (LSB-aligned).
+// Returns 0 and ErrNegativePosition for negative positions, 0 and io.EOF if pos is at or
+// beyond the valid bits, or 0 and io.ErrUnexpectedEOF if fewer than bits valid bits remain after pos.
+//
+// Panics if bits > 64.
+func (r *BitReader[T]) ReadBitsAt(pos, bits int) (uint64, error) {
+	if bits > 64 {
+		panic(&WidthError{Op: "read", Width: bits, Max: 64})
+	}
+	if pos < 0 {
+		return 0, ErrNegativePosition
+	}
+	if bits <= 0 {
+		return 0, nil
+	}
+	if bits > r.bits-pos {
+		return 0, r.eof(pos, bits)
+	}
+	return r.bitsAt(pos, bits), nil
+}
+
 // Pos returns the current read position (cursor).
 func (r *BitReader[T]) Pos() int {
 	return r.pos
@@ -179,7 +304,149 @@ func (r *BitReader[T]) Seek(pos int) error {
 	return nil
 }
 
+// Read implements io.Reader. It reads the remaining bits from the current position,
+// packed MSB-first into bytes, and advances the cursor.
+// If the number of remaining bits is not a multiple of 8, the last byte is padded
+// with zero bits on the right.
+// Returns 0 and io.EOF if the position is at or beyond the valid bits.
+func (r *BitReader[T]) Read(p []byte) (n int, err error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	if r.pos >= r.bits {
+		return 0, io.EOF
+	}
+	for len(p)-n >= 8 && r.bits-r.pos >= 64 {
+		v := r.bitsAt(r.pos, 64)
+		if r.tracer != nil {
+			r.tracer.OnRead(r.pos, 64, v)
+		}
+		binary.BigEndian.PutUint64(p[n:], v)
+		r.pos += 64
+		n += 8
+	}
+	for n < len(p) && r.pos < r.bits {
+		k := min(8, r.bits-r.pos)
+		v := r.bitsAt(r.pos, k)
+		if r.tracer != nil {
+			r.tracer.OnRead(r.pos, k, v)
+		}
+		p[n] = byte(v << (8 - k))
+		r.pos += k
+		n++
+	}
+	return n, nil
+}
+
+// SeekBit sets the read position (cursor) like io.Seeker, but in bits.
+// offset is interpreted according to whence: io.SeekStart means relative to the start,
+// io.SeekCurrent means relative to the current position, and io.SeekEnd means relative
+// to the end of the valid bits.
+// Returns the new position.
+// Returns ErrNegativePosition if the resulting position is negative, ErrOverflow if it
+// does not fit in an int, and ErrInvalidWhence for an unknown whence value.
+func (r *BitReader[T]) SeekBit(offset int64, whence int) (int64, error) {
+	pos, err := seekPos(offset, whence, r.pos, r.bits)
+	if err != nil {
+		return int64(r.pos), err
+	}
+	r.pos = pos
+	return int64(pos), nil
+}
+
+// Count returns the number of set bits in the range [from, to).
+// The range is clipped to the valid bits; it does not move the cursor.
+func (r *BitReader[T]) Count(from, to int) int {
+	from, to = max(from, 0), min(to, r.bits)
+	if from >= to {
+		return 0
+	}
+	return countOnes(r.data, r.s, r.rp, from+r.off, to+r.off)
+}
+
+// LeadingZeros returns the number of consecutive zero bits starting at the current position,
+// stopping at the first one bit or at the end of the valid bits. It does not move the cursor.
+func (r *BitReader[T]) LeadingZeros() int {
+	return r.run(false)
+}
+
+// LeadingOnes returns the number of consecutive one bits starting at the current position,
+// stopping at the first zero bit or at the end of the valid bits. It does not move the cursor.
+func (r *BitReader[T]) LeadingOnes() int {
+	return r.run(true)
+}
+
+// FindNextSet returns the position of the first set bit at or after from, or -1 if there is none.
+// It does not move the cursor.
+func (r *BitReader[T]) FindNextSet(from int) int {
+	return r.next(max(from, 0), true)
+}
+
+// FindNextClear returns the position of the first clear bit at or after from within the valid bits,
+// or -1 if there is none. It does not move the cursor.
+func (r *BitReader[T]) FindNextClear(from int) int {
+	return r.next(max(from, 0), false)
+}
+
+// next returns the position of the first bit equal to want at or after from, or -1.
+func (r *BitReader[T]) next(from int, want bool) int {
+	pos := nextBit(r.data, r.s, r.rp, from+r.off, r.bits+r.off, want)
+	if pos < 0 {
+		return -1
+	}
+	return pos - r.off
+}
+
+func (r *BitReader[T]) run(bit bool) int {
+	if r.pos >= r.bits {
+		return 0
+	}
+	end := r.next(r.pos, !bit)
+	if end < 0 {
+		end = r.bits
+	}
+	return end - r.pos
+}
+
+// Skip advances the read position (cursor) by n bits.
+// If fewer than n valid bits remain, the cursor is moved to the end of the valid bits
+// and io.EOF is returned.
+// Returns ErrNegativePosition for negative n.
+func (r *BitReader[T]) Skip(n int) error {
+	if n < 0 {
+		return ErrNegativePosition
+	}
+	if n > r.bits-r.pos {
+		r.pos = max(r.pos, r.bits)
+		return io.EOF
+	}
+	r.pos += n
+	return nil
+}
+
+// AlignToByte advances the read position (cursor) to the next multiple of 8 bits.
+// The cursor is not moved if it is already byte-aligned.
+// Alignment is relative to the start of the bit stream, not to element boundaries.
+func (r *BitReader[T]) AlignToByte() {
+	r.AlignTo(8)
+}
+
+// AlignTo advances the read position (cursor) to the next multiple of k bits.
+// The cursor is not moved if it is already aligned.
+// Like Seek, the cursor may end up beyond the valid bits.
+//
+// Panics if k <= 0.
+func (r *BitReader[T]) AlignTo(k int) {
+	if k <= 0 {
+		panic("bitstream: alignment must be positive")
+	}
+	if rem := r.pos % k; rem != 0 {
+		r.pos += k - rem
+	}
+}
+
 func (r *BitReader[T]) readBitAt(pos int) bool {
+	pos += r.off
 	mask := r.msb >> (pos % r.s)
 	return r.data[pos/r.s]&mask != 0
 }
@@ -187,15 +454,30 @@ func (r *BitReader[T]) readBitAt(pos int) bool {
 func (r *BitReader[T]) right(bits, n int) (b uint64) {
 	s := min(n*bits, r.bits)
 	e := min(s+bits, r.bits)
-	for i := s; i < e; i++ {
-		b <<= 1
-		mask := r.msb >> (i % r.s)
-		if r.data[i/r.s]&mask != 0 {
-			b |= 1
+	return r.bitsAt(s, e-s) << (bits - (e - s))
+}
+
+// bitsAt returns the bits in [pos, pos+bits) right-aligned.
+// The caller must ensure the range lies within the data.
+func (r *BitReader[T]) bitsAt(pos, bits int) (b uint64) {
+	if r.lp != 0 || r.rp != 0 {
+		for i := pos; i < pos+bits; i++ {
+			b <<= 1
+			if r.readBitAt(i) {
+				b |= 1
+			}
 		}
+		return
 	}
-	for range bits - (e - s) {
-		b <<= 1
+	// Without padding, every element is fully valid, so whole elements
+	// can be shifted into place instead of reading bit by bit.
+	pos += r.off
+	for bits > 0 {
+		idx, off := pos/r.s, pos%r.s
+		k := min(bits, r.s-off)
+		b = b<<k | uint64(r.data[idx]>>(r.s-off-k))&(uint64(1)<<k-1)
+		pos += k
+		bits -= k
 	}
 	return
 }
@@ -205,15 +487,23 @@ func (r *BitReader[T]) right(bits, n int) (b uint64) {
 //
 // BitWriter is safe for concurrent use. All methods are protected by an internal mutex,
 // allowing multiple goroutines to safely write to the same BitWriter instance.
+// Writers created by NewUnsyncBitWriter skip the mutex and are not safe for concurrent use.
 type BitWriter[T Unsigned] struct {
-	mu   *sync.Mutex
-	data []T // Destination data to write bits into
-	bits int // Total number of bits written so far
-	s    int // Number of valid bits per element (element size - left padding - right padding)
-	msb  T   // MSB mask for the valid bit range
-	lp   int // Left padding bits
-	rp   int // Right padding bits
-	pos  int // Current write position (cursor)
+	mu   *sync.Mutex // nil for writers created by NewUnsyncBitWriter
+	data []T         // Destination data to write bits into
+	bits int         // Total number of bits written so far
+	s    int         // Number of valid bits per element (element size - left padding - right padding)
+	msb  T           // MSB mask for the valid bit range
+	lp   int         // Left padding bits
+	rp   int         // Right padding bits
+	pos  int         // Current write position (cursor)
+	fill Fill        // Bits used for padding and alignment, see SetFill
+
+	limit    int       // Maximum number of bits, or 0 for no limit, see NewBitWriterLimit
+	err      error     // Sticky ErrBudgetExceeded once a write exceeded the limit
+	exceeded func(int) // Called when a write exceeds the limit, see SetBudgetFunc
+	widths   *[65]int  // Histogram of field widths, or nil, see TrackWidths
+	tracer   Tracer    // Notified of every field written, see SetTracer
 }
 
 // NewBitWriter creates a new BitWriter for writing bits to integer slice data.
@@ -241,6 +531,17 @@ func NewBitWriter[T Unsigned](leftPadd, rightPadd int) *BitWriter[T] {
 	}
 }
 
+// NewUnsyncBitWriter creates a BitWriter like NewBitWriter, but without the internal mutex.
+// It avoids the locking cost on every call for writers used by a single goroutine,
+// and must not be shared between goroutines without external synchronization.
+//
+// Panics if leftPadd + rightPadd >= element bit size.
+func NewUnsyncBitWriter[T Unsigned](leftPadd, rightPadd int) *BitWriter[T] {
+	w := NewBitWriter[T](leftPadd, rightPadd)
+	w.mu = nil
+	return w
+}
+
 // Write8 writes the specified bits from a uint8 value to the stream.
 // leftPadd specifies how many upper bits to skip in the source data.
 // bits specifies how many bits to write after skipping leftPadd bits.
@@ -250,11 +551,9 @@ func (w *BitWriter[T]) Write8(leftPadd, bits int, data uint8) {
 	if leftPadd+bits > 8 {
 		panic("bitstream: padding and bits exceed uint8 size")
 	}
-	w.mu.Lock()
-	defer w.mu.Unlock()
-	for i := leftPadd; i < leftPadd+bits; i++ {
-		w.write(data&(1<<(7-i)) != 0)
-	}
+	w.lock()
+	defer w.unlock()
+	w.writeBits(uint64(data)>>(8-leftPadd-bits), bits)
 }
 
 // Write16 writes the specified bits from a uint16 value to the stream.
@@ -266,11 +565,9 @@ func (w *BitWriter[T]) Write16(leftPadd, bits int, data uint16) {
 	if leftPadd+bits > 16 {
 		panic("bitstream: padding and bits exceed uint16 size")
 	}
-	w.mu.Lock()
-	defer w.mu.Unlock()
-	for i := leftPadd; i < leftPadd+bits; i++ {
-		w.write(data&(1<<(15-i)) != 0)
-	}
+	w.lock()
+	defer w.unlock()
+	w.writeBits(uint64(data)>>(16-leftPadd-bits), bits)
 }
 
 // Write32 writes the specified bits from a uint32 value to the stream.
@@ -282,11 +579,9 @@ func (w *BitWriter[T]) Write32(leftPadd, bits int, data uint32) {
 	if leftPadd+bits > 32 {
 		panic("bitstream: padding and bits exceed uint32 size")
 	}
-	w.mu.Lock()
-	defer w.mu.Unlock()
-	for i := leftPadd; i < leftPadd+bits; i++ {
-		w.write(data&(1<<(31-i)) != 0)
-	}
+	w.lock()
+	defer w.unlock()
+	w.writeBits(uint64(data)>>(32-leftPadd-bits), bits)
 }
 
 // Write64 writes the specified bits from a uint64 value to the stream.
@@ -298,25 +593,127 @@ func (w *BitWriter[T]) Write64(leftPadd, bits int, data uint64) {
 	if leftPadd+bits > 64 {
 		panic("bitstream: padding and bits exceed uint64 size")
 	}
-	w.mu.Lock()
-	defer w.mu.Unlock()
-	for i := leftPadd; i < leftPadd+bits; i++ {
-		w.write(data&(1<<(63-i)) != 0)
+	w.lock()
+	defer w.unlock()
+	w.writeBits(data>>(64-leftPadd-bits), bits)
+}
+
+// WriteBits writes the low bits of a uint64 value to the stream.
+// Unlike Write64, the value is right-aligned (LSB-aligned), so
+// WriteBits(0b101, 3) writes the bits 1, 0, 1.
+//
+// Panics if bits > 64.
+func (w *BitWriter[T]) WriteBits(data uint64, bits int) {
+	if bits > 64 {
+		panic(&WidthError{Op: "write", Width: bits, Max: 64})
+	}
+	w.lock()
+	defer w.unlock()
+	w.writeBits(data, bits)
+}
+
+// Write implements io.Writer. It appends every byte of p to the stream, 8 bits each,
+// MSB first. It always returns len(p) and a nil error, unless the writer has a bit limit:
+// then only the bytes that fit are written and ErrBudgetExceeded is returned for the rest.
+func (w *BitWriter[T]) Write(p []byte) (int, error) {
+	w.lock()
+	defer w.unlock()
+	if w.err != nil {
+		return 0, w.err
+	}
+	n := len(p)
+	if w.limit > 0 {
+		n = min(n, max(w.limit-w.bits, 0)/8)
+	}
+	i := 0
+	for ; n-i >= 8; i += 8 {
+		w.writeBits(binary.BigEndian.Uint64(p[i:]), 64)
 	}
+	for ; i < n; i++ {
+		w.writeBits(uint64(p[i]), 8)
+	}
+	if n < len(p) {
+		w.reserve(w.bits + 8*(len(p)-n))
+		return n, w.err
+	}
+	return n, nil
 }
 
 // WriteBool writes a single boolean value as one bit to the stream.
 func (w *BitWriter[T]) WriteBool(data bool) {
-	w.mu.Lock()
-	defer w.mu.Unlock()
-	w.write(data)
+	w.lock()
+	defer w.unlock()
+	var b uint64
+	if data {
+		b = 1
+	}
+	w.writeBits(b, 1)
+}
+
+// AlignToByte pads the stream with zero bits, or the fill set by SetFill,
+// until Bits() is a multiple of 8.
+// Nothing is written if the stream is already byte-aligned.
+func (w *BitWriter[T]) AlignToByte() {
+	w.AlignTo(8)
+}
+
+// AlignTo pads the stream with zero bits, or the fill set by SetFill, until Bits() is a
+// multiple of k.
+// Nothing is written if the stream is already aligned.
+//
+// Panics if k <= 0.
+func (w *BitWriter[T]) AlignTo(k int) {
+	if k <= 0 {
+		panic("bitstream: alignment must be positive")
+	}
+	w.lock()
+	defer w.unlock()
+	w.alignTo(k)
+}
+
+// Reset discards all written bits and moves the cursor back to 0,
+// keeping the underlying storage for reuse.
+// Slices previously returned by Data share that storage and are overwritten by later writes.
+func (w *BitWriter[T]) Reset() {
+	w.lock()
+	defer w.unlock()
+	w.data = w.data[:0]
+	w.bits = 0
+	w.pos = 0
+	w.err = nil
+}
+
+// ResetWithCapacity is like Reset, but also makes sure the storage can hold
+// at least nbits bits without reallocating.
+func (w *BitWriter[T]) ResetWithCapacity(nbits int) {
+	w.lock()
+	defer w.unlock()
+	w.data = w.data[:0]
+	w.bits = 0
+	w.pos = 0
+	w.err = nil
+	w.grow(nbits)
+}
+
+// Grow makes sure the storage can hold another nbits bits after Bits()
+// without reallocating. Use it before writing a large amount of data
+// whose size is known in advance.
+//
+// Panics if nbits is negative.
+func (w *BitWriter[T]) Grow(nbits int) {
+	if nbits < 0 {
+		panic("bitstream: negative Grow count")
+	}
+	w.lock()
+	defer w.unlock()
+	w.grow(nbits)
 }
 
 // Data returns the accumulated data slice.
 // Use Bits() to get the total number of valid bits written.
 func (w *BitWriter[T]) Data() []T {
-	w.mu.Lock()
-	defer w.mu.Unlock()
+	w.lock()
+	defer w.unlock()
 	return w.data
 }
 
@@ -324,23 +721,28 @@ func (w *BitWriter[T]) Data() []T {
 // This is useful when the exact type of the underlying data slice is not known at compile time.
 // Use Bits() to get the total number of valid bits written.
 func (w *BitWriter[T]) AnyData() any {
-	w.mu.Lock()
-	defer w.mu.Unlock()
+	w.lock()
+	defer w.unlock()
 	return w.data
 }
 
 // Bits returns the total number of valid bits in the BitWriter.
 func (w *BitWriter[T]) Bits() int {
-	w.mu.Lock()
-	defer w.mu.Unlock()
+	w.lock()
+	defer w.unlock()
 	return w.bits
 }
 
 // WriteBit writes one bit at the current position and advances the cursor.
 // Automatically extends the data slice if writing beyond current length.
+// Returns ErrBudgetExceeded if the bit would exceed the limit set by NewBitWriterLimit.
 func (w *BitWriter[T]) WriteBit(bit bool) error {
-	w.mu.Lock()
-	defer w.mu.Unlock()
+	w.lock()
+	defer w.unlock()
+	if !w.reserve(w.pos + 1) {
+		return w.err
+	}
+	w.traceWrite(w.pos, 1, b2u(bit))
 	w.writeBitAt(w.pos, bit)
 	w.pos++
 	if w.pos > w.bits {
@@ -351,13 +753,18 @@ func (w *BitWriter[T]) WriteBit(bit bool) error {
 
 // WriteBitAt writes one bit at the specified position without moving the cursor.
 // Automatically extends the data slice if writing beyond current length.
-// Returns ErrNegativePosition for negative positions.
+// Returns ErrNegativePosition for negative positions, and ErrBudgetExceeded if the bit
+// would exceed the limit set by NewBitWriterLimit.
 func (w *BitWriter[T]) WriteBitAt(pos int, bit bool) error {
 	if pos < 0 {
 		return ErrNegativePosition
 	}
-	w.mu.Lock()
-	defer w.mu.Unlock()
+	w.lock()
+	defer w.unlock()
+	if !w.reserve(pos + 1) {
+		return w.err
+	}
+	w.traceWrite(pos, 1, b2u(bit))
 	w.writeBitAt(pos, bit)
 	if pos >= w.bits {
 		w.bits = pos + 1
@@ -365,10 +772,43 @@ func (w *BitWriter[T]) WriteBitAt(pos int, bit bool) error {
 	return nil
 }
 
+// WriteBitsAt writes the low bits of a uint64 value at the specified position
+// without moving the cursor, overwriting any bits already there.
+// This allows fields such as length prefixes to be patched after the data they describe
+// has been written.
+// Automatically extends the data slice if writing beyond current length.
+// Returns ErrNegativePosition for negative positions, ErrOverflow if the field
+// would end beyond the largest int position, and ErrBudgetExceeded if it would exceed
+// the limit set by NewBitWriterLimit.
+//
+// Panics if bits > 64.
+func (w *BitWriter[T]) WriteBitsAt(pos, bits int, data uint64) error {
+	if bits > 64 {
+		panic(&WidthError{Op: "write", Width: bits, Max: 64})
+	}
+	if pos < 0 {
+		return ErrNegativePosition
+	}
+	if pos > math.MaxInt-max(bits, 0) {
+		return ErrOverflow
+	}
+	w.lock()
+	defer w.unlock()
+	if !w.reserve(pos + bits) {
+		return w.err
+	}
+	w.traceWrite(pos, bits, data)
+	w.writeBitsAt(pos, bits, data)
+	if pos+bits > w.bits {
+		w.bits = pos + bits
+	}
+	return nil
+}
+
 // Pos returns the current write position (cursor).
 func (w *BitWriter[T]) Pos() int {
-	w.mu.Lock()
-	defer w.mu.Unlock()
+	w.lock()
+	defer w.unlock()
 	return w.pos
 }
 
@@ -379,18 +819,33 @@ func (w *BitWriter[T]) Seek(pos int) error {
 	if pos < 0 {
 		return ErrNegativePosition
 	}
-	w.mu.Lock()
-	defer w.mu.Unlock()
+	w.lock()
+	defer w.unlock()
 	w.pos = pos
 	return nil
 }
 
+// SeekBit sets the write position (cursor) like io.Seeker, but in bits.
+// offset is interpreted according to whence: io.SeekStart means relative to the start,
+// io.SeekCurrent means relative to the current position, and io.SeekEnd means relative
+// to the total number of bits written.
+// Returns the new position.
+// Returns ErrNegativePosition if the resulting position is negative, ErrOverflow if it
+// does not fit in an int, and ErrInvalidWhence for an unknown whence value.
+func (w *BitWriter[T]) SeekBit(offset int64, whence int) (int64, error) {
+	w.lock()
+	defer w.unlock()
+	pos, err := seekPos(offset, whence, w.pos, w.bits)
+	if err != nil {
+		return int64(w.pos), err
+	}
+	w.pos = pos
+	return int64(pos), nil
+}
+
 func (w *BitWriter[T]) writeBitAt(pos int, bit bool) {
 	idx := pos / w.s
-	// Extend data slice if necessary
-	for idx >= len(w.data) {
-		w.data = append(w.data, 0)
-	}
+	w.extend(idx + 1)
 	if bit {
 		w.data[idx] |= w.msb >> (pos % w.s)
 	} else {
@@ -399,13 +854,158 @@ func (w *BitWriter[T]) writeBitAt(pos int, bit bool) {
 	}
 }
 
-func (w *BitWriter[T]) write(bit bool) {
-	idx := w.bits / w.s
-	if idx >= len(w.data) {
-		w.data = append(w.data, 0)
+// lock acquires the mutex of w, if it has one.
+func (w *BitWriter[T]) lock() {
+	if w.mu != nil {
+		w.mu.Lock()
 	}
-	if bit {
-		w.data[idx] |= w.msb >> (w.bits % w.s)
+}
+
+// unlock releases the mutex of w, if it has one.
+func (w *BitWriter[T]) unlock() {
+	if w.mu != nil {
+		w.mu.Unlock()
+	}
+}
+
+// writeBits appends the low bits of data at the end of the stream.
+func (w *BitWriter[T]) writeBits(data uint64, bits int) {
+	if bits <= 0 {
+		return
+	}
+	if w.bits > math.MaxInt-bits {
+		panic("bitstream: stream too long for int bit positions")
+	}
+	if !w.reserve(w.bits + bits) {
+		return
+	}
+	if w.widths != nil {
+		w.widths[bits]++
+	}
+	w.traceWrite(w.bits, bits, data)
+	w.writeBitsAt(w.bits, bits, data)
+	w.bits += bits
+}
+
+// writeBitsAt writes the low bits of data starting at pos.
+// Instead of looping bit by bit, it copies as many bits as fit into
+// each element at once using shifts and masks, so a 64-bit write touches
+// at most a handful of elements.
+func (w *BitWriter[T]) writeBitsAt(pos, bits int, data uint64) {
+	if bits <= 0 {
+		return
+	}
+	w.extend((pos+bits-1)/w.s + 1)
+	for bits > 0 {
+		idx, off := pos/w.s, pos%w.s
+		k := min(bits, w.s-off)
+		shift := w.rp + w.s - off - k
+		mask := T(uint64(1)<<k-1) << shift
+		chunk := T(data>>(bits-k)) << shift
+		w.data[idx] = w.data[idx]&^mask | chunk&mask
+		pos += k
+		bits -= k
+	}
+}
+
+func (w *BitWriter[T]) alignTo(k int) {
+	if rem := w.bits % k; rem != 0 {
+		for n := k - rem; n > 0; n -= 64 {
+			w.writeBits(w.fillBits(w.bits, min(n, 64)), min(n, 64))
+		}
+	}
+}
+
+// grow ensures the capacity for nbits more bits after w.bits.
+func (w *BitWriter[T]) grow(nbits int) {
+	if nbits > math.MaxInt-w.s-w.bits {
+		panic("bitstream: stream too long for int bit positions")
+	}
+	n := (w.bits + nbits + w.s - 1) / w.s
+	w.data = slices.Grow(w.data, n-len(w.data))
+}
+
+// extend grows the data slice to at least n elements, filled as set by SetFill.
+func (w *BitWriter[T]) extend(n int) {
+	if n > len(w.data) {
+		m := len(w.data)
+		w.data = append(w.data, make([]T, n-m)...)
+		if e := fillElem[T](w.fill); e != 0 {
+			for i := m; i < n; i++ {
+				w.data[i] = e
+			}
+		}
+	}
+}
+
+// seekPos resolves an io.Seeker style offset against the current position and total bits.
+func seekPos(offset int64, whence, pos, bits int) (int, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		if offset > math.MaxInt64-int64(pos) {
+			return 0, ErrOverflow
+		}
+		offset += int64(pos)
+	case io.SeekEnd:
+		if offset > math.MaxInt64-int64(bits) {
+			return 0, ErrOverflow
+		}
+		offset += int64(bits)
+	default:
+		return 0, ErrInvalidWhence
+	}
+	if offset < 0 {
+		return 0, ErrNegativePosition
+	}
+	if offset > math.MaxInt {
+		return 0, ErrOverflow
+	}
+	return int(offset), nil
+}
+
+// nextBit returns the first position in [from, to) of data whose bit equals want, or -1.
+// Each element is scanned as a whole using the element layout given by s and rp.
+func nextBit[T Unsigned](data []T, s, rp, from, to int, want bool) int {
+	for pos := from; pos < to; {
+		idx, off := pos/s, pos%s
+		k := min(s-off, to-pos)
+		mask := uint64(1)<<k - 1
+		chunk := uint64(data[idx]>>(rp+s-off-k)) & mask
+		if !want {
+			chunk ^= mask
+		}
+		if chunk != 0 {
+			return pos + bits.LeadingZeros64(chunk) - (64 - k)
+		}
+		pos += k
+	}
+	return -1
+}
+
+// countOnes returns the number of set bits of data in [from, to), given the element layout
+// s and rp. Partial elements at the ends are masked; whole elements in between are counted
+// in bulk with popcount, or popcountMasked when the elements are padded.
+func countOnes[T Unsigned](data []T, s, rp, from, to int) int {
+	if from >= to {
+		return 0
+	}
+	n := 0
+	if off := from % s; off != 0 {
+		k := min(s-off, to-from)
+		n += bits.OnesCount64(uint64(data[from/s]>>(rp+s-off-k)) & (uint64(1)<<k - 1))
+		from += k
+	}
+	// whole elements, counted in bulk
+	if first, last := from/s, to/s; first < last {
+		if mask := ^T(0) >> (int(unsafe.Sizeof(T(0)))*8 - s) << rp; mask == ^T(0) {
+			n += popcount(data[first:last])
+		} else {
+			n += popcountMasked(data[first:last], mask)
+		}
+	}
+	if rem := to % s; rem > 0 && to-rem >= from {
+		n += bits.OnesCount64(uint64(data[to/s]>>(rp+s-rem)) & (uint64(1)<<rem - 1))
 	}
-	w.bits += 1
+	return n
 }
@@ -1,7 +1,10 @@
 package bitstream
 
 import (
+	"bytes"
 	"io"
+	"math"
+	"slices"
 	"testing"
 )
 
@@ -145,6 +148,24 @@ func TestBitReader(t *testing.T) {
 			}
 		}
 	})
+	t.Run("right_and_ReadBitAt_consistency", func(t *testing.T) {
+		reader := NewBitReader([]uint64{0x0123456789ABCDEF, 0xFEDCBA9876543210, 0xA5A5A5A5A5A5A5A5}, 0, 0)
+		for _, bits := range []int{1, 7, 13, 31, 64} {
+			for n := 0; (n+1)*bits <= reader.Bits(); n++ {
+				var want uint64
+				for i := n * bits; i < (n+1)*bits; i++ {
+					bit, _ := reader.ReadBitAt(i)
+					want <<= 1
+					if bit {
+						want |= 1
+					}
+				}
+				if got := reader.Read64R(bits, n); got != want {
+					t.Errorf("Read64R(%d, %d) = %064b; want %064b", bits, n, got, want)
+				}
+			}
+		}
+	})
 	t.Run("Read16R_panic", func(t *testing.T) {
 		defer func() {
 			if r := recover(); r == nil {
@@ -352,6 +373,46 @@ func TestBitReader(t *testing.T) {
 		}
 	})
 
+	t.Run("ReadBitsAt", func(t *testing.T) {
+		reader := NewBitReader([]uint8{
+			0b10101100,
+			0b11100011,
+		}, 1, 1)
+		reader.Seek(3)
+
+		// valid bits: 010110 110001
+		tests := []struct {
+			pos, bits int
+			want      uint64
+			err       error
+		}{
+			{0, 6, 0b010110, nil},
+			{4, 5, 0b10110, nil},
+			{0, 12, 0b010110110001, nil},
+			{11, 1, 0b1, nil},
+			{5, 0, 0, nil},
+			{12, 1, 0, io.EOF},
+			{8, 5, 0, io.ErrUnexpectedEOF},
+			{-1, 1, 0, ErrNegativePosition},
+		}
+		for _, tt := range tests {
+			v, err := reader.ReadBitsAt(tt.pos, tt.bits)
+			if v != tt.want || err != tt.err {
+				t.Errorf("ReadBitsAt(%d, %d) = %b, %v; want %b, %v", tt.pos, tt.bits, v, err, tt.want, tt.err)
+			}
+		}
+		if reader.Pos() != 3 {
+			t.Errorf("Pos() after ReadBitsAt = %d; want 3", reader.Pos())
+		}
+
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("expected panic for 65 bits")
+			}
+		}()
+		reader.ReadBitsAt(0, 65)
+	})
+
 	t.Run("Seek", func(t *testing.T) {
 		reader := NewBitReader([]uint8{0b10101100}, 0, 0)
 
@@ -420,6 +481,34 @@ func TestBitReader(t *testing.T) {
 		}
 	})
 
+	t.Run("SeekBit", func(t *testing.T) {
+		reader := NewBitReader([]uint8{0b10101100, 0b11100011}, 0, 0)
+		tests := []struct {
+			offset int64
+			whence int
+			want   int64
+			err    error
+		}{
+			{4, io.SeekStart, 4, nil},
+			{3, io.SeekCurrent, 7, nil},
+			{-2, io.SeekCurrent, 5, nil},
+			{-1, io.SeekEnd, 15, nil},
+			{4, io.SeekEnd, 20, nil},
+			{-17, io.SeekEnd, 20, ErrNegativePosition},
+			{-1, io.SeekStart, 20, ErrNegativePosition},
+			{0, 3, 20, ErrInvalidWhence},
+		}
+		for _, tt := range tests {
+			got, err := reader.SeekBit(tt.offset, tt.whence)
+			if err != tt.err {
+				t.Errorf("SeekBit(%d, %d) error = %v; want %v", tt.offset, tt.whence, err, tt.err)
+			}
+			if got != tt.want || int64(reader.Pos()) != tt.want {
+				t.Errorf("SeekBit(%d, %d) = %d, Pos() = %d; want %d", tt.offset, tt.whence, got, reader.Pos(), tt.want)
+			}
+		}
+	})
+
 	t.Run("ReadBit_and_ReadBitAt_consistency", func(t *testing.T) {
 		reader := NewBitReader([]uint8{
 			0b10101100,
@@ -466,6 +555,269 @@ func TestBitReader(t *testing.T) {
 			t.Errorf("ReadBit() at pos 5 = %v; want false", bit)
 		}
 	})
+
+	t.Run("Skip", func(t *testing.T) {
+		reader := NewBitReader([]uint8{0b10101100, 0b11100011}, 0, 0)
+
+		if err := reader.Skip(3); err != nil {
+			t.Errorf("Skip(3) returned error: %v", err)
+		}
+		if reader.Pos() != 3 {
+			t.Errorf("Pos() after Skip(3) = %d; want 3", reader.Pos())
+		}
+		bit, _ := reader.ReadBit()
+		if bit != false {
+			t.Errorf("ReadBit() after Skip(3) = %v; want false", bit)
+		}
+
+		if err := reader.Skip(0); err != nil {
+			t.Errorf("Skip(0) returned error: %v", err)
+		}
+		if err := reader.Skip(-1); err != ErrNegativePosition {
+			t.Errorf("Skip(-1) should return ErrNegativePosition, got %v", err)
+		}
+		if reader.Pos() != 4 {
+			t.Errorf("Pos() after failed Skip(-1) = %d; want 4", reader.Pos())
+		}
+
+		// Skip beyond the end stops at the end
+		if err := reader.Skip(100); err != io.EOF {
+			t.Errorf("Skip(100) should return io.EOF, got %v", err)
+		}
+		if reader.Pos() != 16 {
+			t.Errorf("Pos() after Skip(100) = %d; want 16", reader.Pos())
+		}
+	})
+
+	t.Run("Position_overflow", func(t *testing.T) {
+		reader := NewBitReader([]uint8{0b10101100, 0b11100011}, 0, 0)
+		reader.Skip(1)
+		if err := reader.Skip(math.MaxInt); err != io.EOF || reader.Pos() != 16 {
+			t.Errorf("Skip(MaxInt) = %v, Pos() = %d; want io.EOF, 16", err, reader.Pos())
+		}
+		reader.Seek(math.MaxInt)
+		if _, err := reader.SeekBit(1, io.SeekCurrent); err != ErrOverflow || reader.Pos() != math.MaxInt {
+			t.Errorf("SeekBit(1, SeekCurrent) at MaxInt error = %v; want ErrOverflow", err)
+		}
+		if _, err := reader.SeekBit(math.MaxInt64, io.SeekEnd); err != ErrOverflow {
+			t.Errorf("SeekBit(MaxInt64, SeekEnd) error = %v; want ErrOverflow", err)
+		}
+		if _, err := reader.ReadBitsAt(math.MaxInt-1, 8); err != io.EOF {
+			t.Errorf("ReadBitsAt(MaxInt-1, 8) error = %v; want io.EOF", err)
+		}
+
+		writer := NewBitWriter[uint8](0, 0)
+		if err := writer.WriteBitsAt(math.MaxInt-3, 8, 0xFF); err != ErrOverflow || writer.Bits() != 0 {
+			t.Errorf("WriteBitsAt(MaxInt-3, 8) = %v with %d bits; want ErrOverflow with 0", err, writer.Bits())
+		}
+		defer func() {
+			if recover() == nil {
+				t.Error("Grow(MaxInt) did not panic")
+			}
+		}()
+		writer.Grow(math.MaxInt)
+	})
+
+	t.Run("AlignTo", func(t *testing.T) {
+		reader := NewBitReader([]uint8{0b10101100, 0b11100011}, 1, 1)
+
+		reader.AlignToByte()
+		if reader.Pos() != 0 {
+			t.Errorf("Pos() after AlignToByte() at 0 = %d; want 0", reader.Pos())
+		}
+		reader.Seek(1)
+		reader.AlignToByte()
+		if reader.Pos() != 8 {
+			t.Errorf("Pos() after AlignToByte() at 1 = %d; want 8", reader.Pos())
+		}
+		reader.AlignTo(3)
+		if reader.Pos() != 9 {
+			t.Errorf("Pos() after AlignTo(3) at 8 = %d; want 9", reader.Pos())
+		}
+		reader.AlignTo(1)
+		if reader.Pos() != 9 {
+			t.Errorf("Pos() after AlignTo(1) at 9 = %d; want 9", reader.Pos())
+		}
+		reader.AlignToByte()
+		if reader.Pos() != 16 {
+			t.Errorf("Pos() after AlignToByte() at 9 = %d; want 16", reader.Pos())
+		}
+
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("expected panic when k <= 0")
+			}
+		}()
+		reader.AlignTo(0)
+	})
+
+	t.Run("ReadBits", func(t *testing.T) {
+		reader := NewBitReader([]uint8{0b10101100, 0b11100011}, 1, 1)
+		// valid bits: 010110 110001
+		got, err := reader.PeekBits(5)
+		if err != nil || got != 0b01011 {
+			t.Errorf("PeekBits(5) = %05b, %v; want %05b, nil", got, err, 0b01011)
+		}
+		if reader.Pos() != 0 {
+			t.Errorf("Pos() after PeekBits(5) = %d; want 0", reader.Pos())
+		}
+		got, err = reader.ReadBits(5)
+		if err != nil || got != 0b01011 {
+			t.Errorf("ReadBits(5) = %05b, %v; want %05b, nil", got, err, 0b01011)
+		}
+		got, err = reader.ReadBits(4)
+		if err != nil || got != 0b0110 {
+			t.Errorf("ReadBits(4) = %04b, %v; want %04b, nil", got, err, 0b0110)
+		}
+		if _, err = reader.ReadBits(4); err != io.ErrUnexpectedEOF {
+			t.Errorf("ReadBits(4) with 3 bits left should return io.ErrUnexpectedEOF, got %v", err)
+		}
+		if reader.Pos() != 9 {
+			t.Errorf("Pos() after failed ReadBits(4) = %d; want 9", reader.Pos())
+		}
+		got, err = reader.ReadBits(3)
+		if err != nil || got != 0b001 {
+			t.Errorf("ReadBits(3) = %03b, %v; want %03b, nil", got, err, 0b001)
+		}
+		if _, err = reader.ReadBits(1); err != io.EOF {
+			t.Errorf("ReadBits(1) at end should return io.EOF, got %v", err)
+		}
+		if got, err = reader.ReadBits(0); got != 0 || err != nil {
+			t.Errorf("ReadBits(0) = %d, %v; want 0, nil", got, err)
+		}
+	})
+
+	t.Run("Reset", func(t *testing.T) {
+		reader := NewBitReader([]uint8{0xFF, 0xFF}, 0, 0)
+		reader.SetBits(5)
+		reader.Seek(3)
+		reader.Reset([]uint8{0b10101100, 0b11100011}, 1, 1)
+		if reader.Pos() != 0 {
+			t.Errorf("Pos() after Reset = %d; want 0", reader.Pos())
+		}
+		if reader.Bits() != 12 {
+			t.Errorf("Bits() after Reset = %d; want 12", reader.Bits())
+		}
+		if got, _ := reader.ReadBits(12); got != 0b010110110001 {
+			t.Errorf("ReadBits(12) after Reset = %012b; want %012b", got, 0b010110110001)
+		}
+		packet := []uint8{0xAB}
+		allocs := testing.AllocsPerRun(10, func() {
+			reader.Reset(packet, 0, 0)
+			reader.ReadBits(8)
+		})
+		if allocs != 0 {
+			t.Errorf("Reset allocated %v times; want 0", allocs)
+		}
+
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("expected panic for invalid padding")
+			}
+		}()
+		reader.Reset(nil, 4, 4)
+	})
+
+	t.Run("Count", func(t *testing.T) {
+		data := []uint64{0x0123456789ABCDEF, 0xFEDCBA9876543210}
+		for _, pad := range [][2]int{{0, 0}, {3, 5}} {
+			reader := NewBitReader(data, pad[0], pad[1])
+			for _, rng := range [][2]int{{0, reader.Bits()}, {3, 61}, {10, 100}, {60, 70}, {5, 5}} {
+				want := 0
+				for i := rng[0]; i < rng[1]; i++ {
+					if bit, _ := reader.ReadBitAt(i); bit {
+						want++
+					}
+				}
+				if got := reader.Count(rng[0], rng[1]); got != want {
+					t.Errorf("padding %v: Count(%d, %d) = %d; want %d", pad, rng[0], rng[1], got, want)
+				}
+			}
+		}
+		reader := NewBitReader([]uint8{0xFF, 0xFF}, 0, 0)
+		reader.SetBits(10)
+		if got := reader.Count(-3, 16); got != 10 {
+			t.Errorf("Count(-3, 16) with SetBits(10) = %d; want 10", got)
+		}
+	})
+
+	t.Run("LeadingZeros_LeadingOnes", func(t *testing.T) {
+		reader := NewBitReader([]uint8{0b00011100, 0b00000000, 0b01111111}, 1, 0)
+		// valid bits: 0011100 0000000 1111111
+		tests := []struct {
+			pos         int
+			zeros, ones int
+		}{
+			{0, 2, 0}, {2, 0, 3}, {5, 9, 0}, {14, 0, 7}, {20, 0, 1}, {21, 0, 0},
+		}
+		for _, tt := range tests {
+			reader.Seek(tt.pos)
+			if got := reader.LeadingZeros(); got != tt.zeros {
+				t.Errorf("LeadingZeros() at %d = %d; want %d", tt.pos, got, tt.zeros)
+			}
+			if got := reader.LeadingOnes(); got != tt.ones {
+				t.Errorf("LeadingOnes() at %d = %d; want %d", tt.pos, got, tt.ones)
+			}
+			if reader.Pos() != tt.pos {
+				t.Errorf("Pos() after LeadingZeros/LeadingOnes = %d; want %d", reader.Pos(), tt.pos)
+			}
+		}
+	})
+
+	t.Run("FindNextSet_FindNextClear", func(t *testing.T) {
+		reader := NewBitReader([]uint16{0x0000, 0x0010, 0xFFFF, 0xFFFF}, 0, 0)
+		reader.SetBits(60)
+		tests := []struct {
+			from       int
+			set, clear int
+		}{
+			{0, 27, 0}, {27, 27, 28}, {28, 32, 28}, {32, 32, -1}, {59, 59, -1}, {60, -1, -1}, {-4, 27, 0},
+		}
+		for _, tt := range tests {
+			if got := reader.FindNextSet(tt.from); got != tt.set {
+				t.Errorf("FindNextSet(%d) = %d; want %d", tt.from, got, tt.set)
+			}
+			if got := reader.FindNextClear(tt.from); got != tt.clear {
+				t.Errorf("FindNextClear(%d) = %d; want %d", tt.from, got, tt.clear)
+			}
+		}
+	})
+
+	t.Run("Read", func(t *testing.T) {
+		src := []byte{0xDE, 0xAD, 0xBE, 0xEF, 0x01, 0x23, 0x45, 0x67, 0x89, 0xAB, 0xCD}
+		reader := NewBitReader(src, 0, 0)
+		got, err := io.ReadAll(reader)
+		if err != nil {
+			t.Fatalf("io.ReadAll returned error: %v", err)
+		}
+		if string(got) != string(src) {
+			t.Errorf("io.ReadAll = %x; want %x", got, src)
+		}
+		n, err := reader.Read(make([]byte, 1))
+		if n != 0 || err != io.EOF {
+			t.Errorf("Read() at end = %d, %v; want 0, io.EOF", n, err)
+		}
+	})
+
+	t.Run("Read_withPadding", func(t *testing.T) {
+		// With lp=1, rp=1: 010110 110001 -> 01011011 0001(0000)
+		reader := NewBitReader([]uint8{0b10101100, 0b11100011}, 1, 1)
+		reader.Seek(1)
+		p := make([]byte, 4)
+		n, err := reader.Read(p)
+		if err != nil {
+			t.Fatalf("Read() returned error: %v", err)
+		}
+		if n != 2 {
+			t.Errorf("Read() n = %d; want 2", n)
+		}
+		if p[0] != 0b10110110 || p[1] != 0b00100000 {
+			t.Errorf("Read() = %08b %08b; want %08b %08b", p[0], p[1], 0b10110110, 0b00100000)
+		}
+		if reader.Pos() != 12 {
+			t.Errorf("Pos() after Read() = %d; want 12", reader.Pos())
+		}
+	})
 }
 
 func TestBitWriter(t *testing.T) {
@@ -590,6 +942,122 @@ func TestBitWriter(t *testing.T) {
 			t.Errorf("expected 3 elements in data, got %d", len(writer.data))
 		}
 	})
+	t.Run("WriteBits", func(t *testing.T) {
+		writer := NewBitWriter[uint8](0, 0)
+		writer.WriteBits(0b101, 3)
+		writer.WriteBits(0, 0)
+		writer.WriteBits(0xFF01, 9)
+		if writer.Bits() != 12 {
+			t.Errorf("expected bits to be 12, got %d", writer.Bits())
+		}
+		data := writer.Data()
+		if data[0] != 0b10110000 {
+			t.Errorf("expected data[0] to be %08b, got %08b", 0b10110000, data[0])
+		}
+		if data[1] != 0b00010000 {
+			t.Errorf("expected data[1] to be %08b, got %08b", 0b00010000, data[1])
+		}
+
+		writer16 := NewBitWriter[uint16](2, 1)
+		writer16.WriteBits(0xFFFFFFFFFFFFFFFF, 64)
+		if writer16.Bits() != 64 {
+			t.Errorf("expected bits to be 64, got %d", writer16.Bits())
+		}
+		data16 := writer16.Data()
+		if len(data16) != 5 {
+			t.Errorf("expected data length to be 5, got %d", len(data16))
+		}
+		if data16[0] != 0b0011111111111110 {
+			t.Errorf("expected data[0] to be %016b, got %016b", 0b0011111111111110, data16[0])
+		}
+		if data16[4] != 0b0011111111111100 {
+			t.Errorf("expected data[4] to be %016b, got %016b", 0b0011111111111100, data16[4])
+		}
+	})
+	t.Run("WriteBits_panic", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("expected panic when bits > 64")
+			}
+		}()
+		writer := NewBitWriter[uint8](0, 0)
+		writer.WriteBits(0, 65)
+	})
+	t.Run("WriteBits_and_WriteBitAt_consistency", func(t *testing.T) {
+		values := []struct {
+			data uint64
+			bits int
+		}{
+			{0b1, 1}, {0b10110, 5}, {0xDEADBEEF, 32}, {0x0123456789ABCDEF, 64},
+			{0b0, 3}, {0x7FF, 11}, {0xFFFFFFFFFFFFFFFF, 64}, {0b1010, 4},
+		}
+		for _, pad := range [][2]int{{0, 0}, {1, 0}, {0, 3}, {2, 5}} {
+			writer1 := NewBitWriter[uint8](pad[0], pad[1])
+			writer2 := NewBitWriter[uint8](pad[0], pad[1])
+			pos := 0
+			for _, v := range values {
+				writer1.WriteBits(v.data, v.bits)
+				for i := v.bits - 1; i >= 0; i-- {
+					writer2.WriteBitAt(pos, v.data&(1<<i) != 0)
+					pos++
+				}
+			}
+			if writer1.Bits() != writer2.Bits() {
+				t.Errorf("padding %v: Bits() mismatch: %d vs %d", pad, writer1.Bits(), writer2.Bits())
+			}
+			data1, data2 := writer1.Data(), writer2.Data()
+			if len(data1) != len(data2) {
+				t.Fatalf("padding %v: data length mismatch: %d vs %d", pad, len(data1), len(data2))
+			}
+			for i := range data1 {
+				if data1[i] != data2[i] {
+					t.Errorf("padding %v: data[%d] mismatch: %08b vs %08b", pad, i, data1[i], data2[i])
+				}
+			}
+		}
+	})
+	t.Run("Write", func(t *testing.T) {
+		src := []byte{0xDE, 0xAD, 0xBE, 0xEF, 0x01, 0x23, 0x45, 0x67, 0x89, 0xAB, 0xCD}
+		writer := NewBitWriter[uint16](1, 2)
+		writer.WriteBits(0b101, 3)
+		n, err := io.Copy(writer, bytes.NewReader(src))
+		if err != nil {
+			t.Fatalf("io.Copy returned error: %v", err)
+		}
+		if n != int64(len(src)) {
+			t.Errorf("io.Copy n = %d; want %d", n, len(src))
+		}
+		if writer.Bits() != 3+8*len(src) {
+			t.Errorf("expected bits to be %d, got %d", 3+8*len(src), writer.Bits())
+		}
+
+		reader := NewBitReader(writer.Data(), 1, 2)
+		reader.SetBits(writer.Bits())
+		reader.Skip(3)
+		got, _ := io.ReadAll(reader)
+		if !bytes.Equal(got, src) {
+			t.Errorf("round trip = %x; want %x", got, src)
+		}
+	})
+	t.Run("AlignTo", func(t *testing.T) {
+		writer := NewBitWriter[uint8](0, 1)
+		writer.AlignToByte()
+		if writer.Bits() != 0 {
+			t.Errorf("Bits() after AlignToByte() on empty writer = %d; want 0", writer.Bits())
+		}
+		writer.WriteBool(true)
+		writer.AlignToByte()
+		if writer.Bits() != 8 {
+			t.Errorf("Bits() after AlignToByte() = %d; want 8", writer.Bits())
+		}
+		writer.AlignTo(100)
+		if writer.Bits() != 100 {
+			t.Errorf("Bits() after AlignTo(100) = %d; want 100", writer.Bits())
+		}
+		if data := writer.Data(); data[0] != 0b10000000 || len(data) != 15 {
+			t.Errorf("expected data[0] = 10000000 and 15 elements, got %08b and %d", data[0], len(data))
+		}
+	})
 	t.Run("WriteBool", func(t *testing.T) {
 		writer := NewBitWriter[uint64](0, 0)
 		writer.WriteBool(true)
@@ -766,6 +1234,63 @@ func TestBitWriter(t *testing.T) {
 		}
 	})
 
+	t.Run("Reset", func(t *testing.T) {
+		writer := NewBitWriter[uint8](0, 0)
+		writer.WriteBits(0xFFFF, 16)
+		writer.Seek(3)
+		c := cap(writer.Data())
+		writer.Reset()
+		if writer.Bits() != 0 || writer.Pos() != 0 || len(writer.Data()) != 0 {
+			t.Errorf("after Reset: Bits() = %d, Pos() = %d, len(Data()) = %d; want 0, 0, 0", writer.Bits(), writer.Pos(), len(writer.Data()))
+		}
+		writer.WriteBits(0b1, 1)
+		data := writer.Data()
+		if data[0] != 0b10000000 {
+			t.Errorf("expected data[0] to be %08b after Reset, got %08b", 0b10000000, data[0])
+		}
+		if cap(data) != c {
+			t.Errorf("expected storage to be reused: cap = %d; want %d", cap(data), c)
+		}
+
+		writer.ResetWithCapacity(1000)
+		if cap(writer.Data()) < 125 {
+			t.Errorf("cap after ResetWithCapacity(1000) = %d; want >= 125", cap(writer.Data()))
+		}
+		if writer.Bits() != 0 || len(writer.Data()) != 0 {
+			t.Errorf("after ResetWithCapacity: Bits() = %d, len(Data()) = %d; want 0, 0", writer.Bits(), len(writer.Data()))
+		}
+		allocs := testing.AllocsPerRun(10, func() {
+			writer.Reset()
+			for range 125 {
+				writer.WriteBits(0xAB, 8)
+			}
+		})
+		if allocs != 0 {
+			t.Errorf("writing into reset writer allocated %v times; want 0", allocs)
+		}
+	})
+
+	t.Run("Grow", func(t *testing.T) {
+		writer := NewBitWriter[uint16](0, 4)
+		writer.WriteBits(0b101, 3)
+		writer.Grow(1200)
+		if c := cap(writer.Data()); c < 101 {
+			t.Errorf("cap after Grow(1200) = %d; want >= 101", c)
+		}
+		if writer.Bits() != 3 || len(writer.Data()) != 1 {
+			t.Errorf("Grow changed the contents: Bits() = %d, len(Data()) = %d", writer.Bits(), len(writer.Data()))
+		}
+		// AllocsPerRun calls the function twice, writing 1200 bits in total
+		allocs := testing.AllocsPerRun(1, func() {
+			for range 50 {
+				writer.WriteBits(0xABC, 12)
+			}
+		})
+		if allocs != 0 {
+			t.Errorf("writing after Grow allocated %v times; want 0", allocs)
+		}
+	})
+
 	t.Run("WriteBit", func(t *testing.T) {
 		writer := NewBitWriter[uint8](0, 0)
 
@@ -879,6 +1404,47 @@ func TestBitWriter(t *testing.T) {
 		}
 	})
 
+	t.Run("WriteBitsAt", func(t *testing.T) {
+		writer := NewBitWriter[uint8](1, 1)
+		// reserve a 10-bit length field, then write the payload
+		writer.WriteBits(0, 10)
+		writer.WriteBits(0xFFFF, 16)
+		if err := writer.WriteBitsAt(0, 10, 0b1000000001); err != nil {
+			t.Errorf("WriteBitsAt(0, 10) returned error: %v", err)
+		}
+		if writer.Bits() != 26 {
+			t.Errorf("expected bits to be 26, got %d", writer.Bits())
+		}
+		reader := NewBitReader(writer.Data(), 1, 1)
+		reader.SetBits(writer.Bits())
+		if got, _ := reader.ReadBits(10); got != 0b1000000001 {
+			t.Errorf("patched field = %010b; want %010b", got, 0b1000000001)
+		}
+		if got, _ := reader.ReadBits(16); got != 0xFFFF {
+			t.Errorf("payload = %016b; want %016b", got, 0xFFFF)
+		}
+
+		// overwrite ones with zeros across element boundaries
+		writer.WriteBitsAt(12, 8, 0)
+		reader = NewBitReader(writer.Data(), 1, 1)
+		reader.Seek(10)
+		if got, _ := reader.ReadBits(16); got != 0b1100000000111111 {
+			t.Errorf("payload after overwrite = %016b; want %016b", got, 0b1100000000111111)
+		}
+
+		// writing beyond the end extends the stream
+		writer.WriteBitsAt(30, 2, 0b11)
+		if writer.Bits() != 32 {
+			t.Errorf("expected bits to be 32, got %d", writer.Bits())
+		}
+		if writer.Pos() != 0 {
+			t.Errorf("Pos() after WriteBitsAt = %d; want 0", writer.Pos())
+		}
+		if err := writer.WriteBitsAt(-1, 1, 1); err != ErrNegativePosition {
+			t.Errorf("WriteBitsAt(-1) should return ErrNegativePosition, got %v", err)
+		}
+	})
+
 	t.Run("Seek_Writer", func(t *testing.T) {
 		writer := NewBitWriter[uint8](0, 0)
 
@@ -938,6 +1504,34 @@ func TestBitWriter(t *testing.T) {
 		}
 	})
 
+	t.Run("SeekBit_Writer", func(t *testing.T) {
+		writer := NewBitWriter[uint8](0, 0)
+		writer.WriteBits(0, 10)
+
+		pos, err := writer.SeekBit(-2, io.SeekEnd)
+		if err != nil || pos != 8 {
+			t.Errorf("SeekBit(-2, io.SeekEnd) = %d, %v; want 8, nil", pos, err)
+		}
+		writer.WriteBit(true)
+		pos, err = writer.SeekBit(-9, io.SeekCurrent)
+		if err != nil || pos != 0 {
+			t.Errorf("SeekBit(-9, io.SeekCurrent) = %d, %v; want 0, nil", pos, err)
+		}
+		writer.WriteBit(true)
+		if data := writer.Data(); data[0] != 0b10000000 || data[1] != 0b10000000 {
+			t.Errorf("expected data to be 10000000 10000000, got %08b %08b", data[0], data[1])
+		}
+		if _, err := writer.SeekBit(-1, io.SeekStart); err != ErrNegativePosition {
+			t.Errorf("SeekBit(-1, io.SeekStart) should return ErrNegativePosition, got %v", err)
+		}
+		if _, err := writer.SeekBit(0, -1); err != ErrInvalidWhence {
+			t.Errorf("SeekBit(0, -1) should return ErrInvalidWhence, got %v", err)
+		}
+		if writer.Pos() != 1 {
+			t.Errorf("Pos() after failed SeekBit = %d; want 1", writer.Pos())
+		}
+	})
+
 	t.Run("WriteBit_and_WriteBitAt_consistency", func(t *testing.T) {
 		writer1 := NewBitWriter[uint8](0, 0)
 		writer2 := NewBitWriter[uint8](0, 0)
@@ -961,4 +1555,139 @@ func TestBitWriter(t *testing.T) {
 			t.Errorf("WriteBit and WriteBitAt produced different results: %08b vs %08b", data1[0], data2[0])
 		}
 	})
+
+	t.Run("NegativeWidth", func(t *testing.T) {
+		writer := NewBitWriter[uint8](0, 0)
+		writer.WriteBits(0xA5, 8)
+		writer.WriteBits(0, -3)
+		writer.Write8(0, -2, 0)
+		writer.Write16(0, -1, 0)
+		writer.Write32(0, -5, 0)
+		writer.Write64(0, -64, 0)
+		writer.WriteGray(0, -4)
+		writer.WriteZigZag(-1, -4)
+		p := writer.Reserve(-6)
+		if writer.Bits() != 8 || writer.ToHex() != "a5" {
+			t.Errorf("after negative widths: Bits() = %d, ToHex() = %s; want 8, a5", writer.Bits(), writer.ToHex())
+		}
+		if p.Pos() != 8 || p.Bits() != 0 {
+			t.Errorf("Reserve(-6) = pos %d, bits %d; want 8, 0", p.Pos(), p.Bits())
+		}
+		p.Fill(0xFF)
+		writer.WriteBits(0x3, 2)
+		if writer.Bits() != 10 || writer.ToHex() != "a5c" {
+			t.Errorf("after Fill: Bits() = %d, ToHex() = %s; want 10, a5c", writer.Bits(), writer.ToHex())
+		}
+	})
+
+	t.Run("Unsync", func(t *testing.T) {
+		synced := NewBitWriter[uint16](3, 1)
+		unsynced := NewUnsyncBitWriter[uint16](3, 1)
+		for _, w := range []*BitWriter[uint16]{synced, unsynced} {
+			w.WriteBits(0x1ABC, 13)
+			w.WriteUE(7)
+			w.AlignToByte()
+			w.WriteBitAt(2, true)
+			w.Append(w)
+		}
+		if !slices.Equal(unsynced.Data(), synced.Data()) || unsynced.Bits() != synced.Bits() {
+			t.Errorf("unsync Data() = %v (%d bits); want %v (%d bits)",
+				unsynced.Data(), unsynced.Bits(), synced.Data(), synced.Bits())
+		}
+
+		other := NewBitWriter[uint16](3, 1)
+		other.Append(unsynced)
+		unsynced.Append(other)
+		if unsynced.Bits() != 2*synced.Bits() {
+			t.Errorf("Bits() after Append = %d; want %d", unsynced.Bits(), 2*synced.Bits())
+		}
+	})
+}
+
+func BenchmarkBitWriter(b *testing.B) {
+	b.Run("Write64/uint64", func(b *testing.B) {
+		for b.Loop() {
+			writer := NewBitWriter[uint64](0, 0)
+			for range 1024 {
+				writer.Write64(0, 64, 0xA5A5A5A5A5A5A5A5)
+			}
+		}
+	})
+	b.Run("Write64/uint8_padded", func(b *testing.B) {
+		for b.Loop() {
+			writer := NewBitWriter[uint8](1, 1)
+			for range 1024 {
+				writer.Write64(0, 64, 0xA5A5A5A5A5A5A5A5)
+			}
+		}
+	})
+	b.Run("WriteBits13/uint32", func(b *testing.B) {
+		for b.Loop() {
+			writer := NewBitWriter[uint32](0, 0)
+			for range 1024 {
+				writer.WriteBits(0x1A5A, 13)
+			}
+		}
+	})
+	b.Run("Write8/uint16", func(b *testing.B) {
+		for b.Loop() {
+			writer := NewBitWriter[uint16](0, 0)
+			for range 1024 {
+				writer.Write8(0, 8, 0xA5)
+			}
+		}
+	})
+	b.Run("WriteBits13/uint32_sync", func(b *testing.B) {
+		writer := NewBitWriter[uint32](0, 0)
+		for b.Loop() {
+			writer.Reset()
+			for range 1024 {
+				writer.WriteBits(0x1A5A, 13)
+			}
+		}
+	})
+	b.Run("WriteBits13/uint32_unsync", func(b *testing.B) {
+		writer := NewUnsyncBitWriter[uint32](0, 0)
+		for b.Loop() {
+			writer.Reset()
+			for range 1024 {
+				writer.WriteBits(0x1A5A, 13)
+			}
+		}
+	})
+}
+
+func BenchmarkBitReader(b *testing.B) {
+	data64 := make([]uint64, 1024)
+	data8 := make([]uint8, 8*1024)
+	for i := range data64 {
+		data64[i] = 0xA5A5A5A5A5A5A5A5 ^ uint64(i)
+	}
+	for i := range data8 {
+		data8[i] = 0xA5 ^ uint8(i)
+	}
+	b.Run("Read64R/uint64", func(b *testing.B) {
+		reader := NewBitReader(data64, 0, 0)
+		for b.Loop() {
+			for n := range 1024 {
+				reader.Read64R(64, n)
+			}
+		}
+	})
+	b.Run("Read64R/uint8", func(b *testing.B) {
+		reader := NewBitReader(data8, 0, 0)
+		for b.Loop() {
+			for n := range 1024 {
+				reader.Read64R(64, n)
+			}
+		}
+	})
+	b.Run("Read16R/uint8_padded", func(b *testing.B) {
+		reader := NewBitReader(data8, 1, 1)
+		for b.Loop() {
+			for n := range 1024 {
+				reader.Read16R(16, n)
+			}
+		}
+	})
 }
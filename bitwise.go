@@ -0,0 +1,42 @@
+package bitstream
+
+// Xor returns a new stream holding a XOR b, bit by bit, laid out with the element type
+// and padding of a. The readers may use different element types and paddings.
+// Cursors are not moved, and the result's cursor starts at 0.
+// Returns ErrLengthMismatch if a and b have different numbers of valid bits.
+func Xor[T, U Unsigned](a *BitReader[T], b *BitReader[U]) (*BitReader[T], error) {
+	return combine(a, b, func(x, y uint64) uint64 { return x ^ y })
+}
+
+// And returns a new stream holding a AND b, as described for Xor.
+func And[T, U Unsigned](a *BitReader[T], b *BitReader[U]) (*BitReader[T], error) {
+	return combine(a, b, func(x, y uint64) uint64 { return x & y })
+}
+
+// Or returns a new stream holding a OR b, as described for Xor.
+func Or[T, U Unsigned](a *BitReader[T], b *BitReader[U]) (*BitReader[T], error) {
+	return combine(a, b, func(x, y uint64) uint64 { return x | y })
+}
+
+// Not returns a new stream holding the complement of every valid bit of a,
+// with the element type and padding of a. Padding bits of the result are zero.
+func Not[T Unsigned](a *BitReader[T]) *BitReader[T] {
+	c, _ := combine(a, a, func(x, _ uint64) uint64 { return ^x })
+	return c
+}
+
+// combine applies op to 64-bit chunks of a and b.
+func combine[T, U Unsigned](a *BitReader[T], b *BitReader[U], op func(x, y uint64) uint64) (*BitReader[T], error) {
+	if a.bits != b.bits {
+		return nil, ErrLengthMismatch
+	}
+	w := NewUnsyncBitWriter[T](a.lp, a.rp)
+	w.grow(a.bits)
+	for pos := 0; pos < a.bits; pos += 64 {
+		k := min(64, a.bits-pos)
+		w.writeBits(op(a.bitsAt(pos, k), b.bitsAt(pos, k)), k)
+	}
+	r := NewBitReader(w.data, a.lp, a.rp)
+	r.bits = a.bits
+	return r, nil
+}
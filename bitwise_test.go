@@ -0,0 +1,70 @@
+package bitstream
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestBitwise(t *testing.T) {
+	a := NewBitReader([]uint8{0b0_1100_101, 0b0_0110_000}, 1, 0)
+	a.SetBits(10) // 1100101 011
+	b := NewBitReader([]uint16{0b1010_0110_1100_0000}, 0, 0)
+	b.SetBits(10) // 1010011011
+	a.Seek(4)
+
+	tests := []struct {
+		name string
+		op   func(*BitReader[uint8], *BitReader[uint16]) (*BitReader[uint8], error)
+		want []uint8
+	}{
+		{"Xor", Xor[uint8, uint16], []uint8{0b0_0110110, 0b0_0000000}},
+		{"And", And[uint8, uint16], []uint8{0b0_1000001, 0b0_0110000}},
+		{"Or", Or[uint8, uint16], []uint8{0b0_1110111, 0b0_0110000}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.op(a, b)
+			if err != nil {
+				t.Fatalf("%s() error = %v", tt.name, err)
+			}
+			if got.Bits() != 10 || got.Pos() != 0 {
+				t.Errorf("Bits(), Pos() = %d, %d; want 10, 0", got.Bits(), got.Pos())
+			}
+			if !slices.Equal(got.Data(), tt.want) {
+				t.Errorf("%s() = %08b; want %08b", tt.name, got.Data(), tt.want)
+			}
+		})
+	}
+
+	t.Run("Not", func(t *testing.T) {
+		got := Not(a)
+		if want := []uint8{0b0_0011010, 0b0_1000000}; !slices.Equal(got.Data(), want) || got.Bits() != 10 {
+			t.Errorf("Not() = %08b (%d bits); want %08b (10 bits)", got.Data(), got.Bits(), want)
+		}
+		if a.Pos() != 4 {
+			t.Errorf("Pos() = %d; want 4", a.Pos())
+		}
+	})
+
+	t.Run("Long", func(t *testing.T) {
+		x := make([]uint64, 5)
+		for i := range x {
+			x[i] = 0x0123456789ABCDEF * uint64(i+1)
+		}
+		ra := NewBitReader(x, 0, 0)
+		key, _ := Xor(ra, Not(ra))
+		if key.Count(0, key.Bits()) != 320 {
+			t.Errorf("Count(x XOR NOT x) = %d; want 320", key.Count(0, key.Bits()))
+		}
+		back, _ := Xor(key, Not(ra))
+		if Compare(back, ra) != 0 {
+			t.Error("Xor() is not its own inverse")
+		}
+	})
+
+	t.Run("LengthMismatch", func(t *testing.T) {
+		if _, err := Xor(a, NewBitReader([]uint8{0, 0}, 0, 0)); err != ErrLengthMismatch {
+			t.Errorf("Xor() error = %v; want ErrLengthMismatch", err)
+		}
+	})
+}
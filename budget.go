@@ -0,0 +1,78 @@
+package bitstream
+
+import "math"
+
+// NewBitWriterLimit creates a BitWriter like NewBitWriter that holds at most maxBits bits,
+// for building fixed-size packets. A write that would take the stream past maxBits is
+// discarded; methods with an error result such as WriteBit and WriteString return
+// ErrBudgetExceeded, and Err reports it for the others. The error is sticky: later writes
+// are discarded too until Reset, Truncate or Rollback makes room again.
+//
+// A code written by one call, such as a varint, an exp-Golomb code, a string or a Reserve
+// placeholder, is checked against the limit as a whole, so it is written completely or not
+// at all; so are frames, PackUints and WriteSimple8b. Write keeps the bytes that fit, and
+// WritePFOR may stop part way, so roll back to a Mark once Err reports an overrun.
+//
+//	w := bitstream.NewBitWriterLimit[uint8](0, 0, 188*8)
+//	m := w.Mark()
+//	encodeFrame(w)
+//	if w.Err() != nil {
+//		w.Rollback(m) // frame does not fit; try again in the next packet
+//	}
+//
+// Panics if leftPadd + rightPadd >= element bit size or maxBits <= 0.
+func NewBitWriterLimit[T Unsigned](leftPadd, rightPadd, maxBits int) *BitWriter[T] {
+	if maxBits <= 0 {
+		panic("bitstream: bit limit must be positive")
+	}
+	w := NewBitWriter[T](leftPadd, rightPadd)
+	w.limit = maxBits
+	w.grow(maxBits)
+	return w
+}
+
+// Limit returns the maximum number of bits set by NewBitWriterLimit, or 0 if w has no limit.
+func (w *BitWriter[T]) Limit() int {
+	w.lock()
+	defer w.unlock()
+	return w.limit
+}
+
+// Err returns ErrBudgetExceeded if a write was discarded because it would have exceeded
+// the limit set by NewBitWriterLimit, and nil otherwise.
+func (w *BitWriter[T]) Err() error {
+	w.lock()
+	defer w.unlock()
+	return w.err
+}
+
+// SetBudgetFunc sets a function called whenever a write is discarded because the stream
+// would have held need bits, more than the limit set by NewBitWriterLimit. It is called
+// with w locked, so it must not call methods of w. A nil fn removes the function.
+func (w *BitWriter[T]) SetBudgetFunc(fn func(need int)) {
+	w.lock()
+	defer w.unlock()
+	w.exceeded = fn
+}
+
+// reserve reports whether the stream may grow to end bits, recording ErrBudgetExceeded
+// if that would exceed the limit.
+func (w *BitWriter[T]) reserve(end int) bool {
+	if w.err != nil {
+		return false
+	}
+	if w.limit > 0 && end > w.limit {
+		w.err = ErrBudgetExceeded
+		if w.exceeded != nil {
+			w.exceeded(end)
+		}
+		return false
+	}
+	return true
+}
+
+// reserveBits reports whether n more bits fit within the limit. Methods that write a
+// field in several parts call it first, so that the field is written whole or not at all.
+func (w *BitWriter[T]) reserveBits(n int) bool {
+	return w.reserve(w.bits + min(n, math.MaxInt-w.bits))
+}
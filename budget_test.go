@@ -0,0 +1,208 @@
+package bitstream
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBitWriterLimit(t *testing.T) {
+	t.Run("Exceed", func(t *testing.T) {
+		writer := NewBitWriterLimit[uint8](0, 0, 12)
+		if writer.Limit() != 12 {
+			t.Errorf("Limit() = %d; want 12", writer.Limit())
+		}
+		writer.WriteBits(0xAB, 8)
+		writer.WriteBits(0xF, 4)
+		if err := writer.Err(); err != nil {
+			t.Fatalf("Err() at the limit = %v; want nil", err)
+		}
+		writer.WriteUE(3)
+		if !errors.Is(writer.Err(), ErrBudgetExceeded) || writer.Bits() != 12 {
+			t.Errorf("after overrun: Err() = %v, Bits() = %d; want ErrBudgetExceeded, 12", writer.Err(), writer.Bits())
+		}
+		if err := writer.WriteBitAt(0, false); !errors.Is(err, ErrBudgetExceeded) {
+			t.Errorf("WriteBitAt() after overrun = %v; want ErrBudgetExceeded", err)
+		}
+		if got := writer.ToHex(); got != "abf" {
+			t.Errorf("ToHex() = %s; want abf", got)
+		}
+	})
+
+	t.Run("Recover", func(t *testing.T) {
+		writer := NewBitWriterLimit[uint16](1, 1, 20)
+		writer.WriteBits(0x3FF, 10)
+		mark := writer.Mark()
+		writer.WriteBits(0, 8)
+		writer.WriteBits(0, 8)
+		if writer.Err() == nil {
+			t.Fatal("Err() = nil; want ErrBudgetExceeded")
+		}
+		writer.Rollback(mark)
+		if writer.Err() != nil || writer.Bits() != 10 {
+			t.Errorf("after Rollback: Err() = %v, Bits() = %d; want nil, 10", writer.Err(), writer.Bits())
+		}
+		writer.WriteBits(0x3FF, 10)
+		if writer.Err() != nil || writer.Bits() != 20 {
+			t.Errorf("after retry: Err() = %v, Bits() = %d; want nil, 20", writer.Err(), writer.Bits())
+		}
+		writer.WriteBool(true)
+		writer.Reset()
+		if writer.Err() != nil {
+			t.Errorf("Err() after Reset = %v; want nil", writer.Err())
+		}
+	})
+
+	t.Run("Write", func(t *testing.T) {
+		writer := NewBitWriterLimit[uint32](0, 0, 30)
+		n, err := writer.Write([]byte("hello"))
+		if n != 3 || !errors.Is(err, ErrBudgetExceeded) {
+			t.Errorf("Write() = %d, %v; want 3, ErrBudgetExceeded", n, err)
+		}
+		if n, err := writer.Write([]byte("!")); n != 0 || !errors.Is(err, ErrBudgetExceeded) {
+			t.Errorf("Write() after overrun = %d, %v; want 0, ErrBudgetExceeded", n, err)
+		}
+		if writer.Bits() != 24 {
+			t.Errorf("Bits() = %d; want 24", writer.Bits())
+		}
+	})
+
+	t.Run("Positional", func(t *testing.T) {
+		writer := NewBitWriterLimit[uint8](0, 0, 16)
+		if err := writer.WriteBitsAt(10, 6, 0x3F); err != nil {
+			t.Errorf("WriteBitsAt(10, 6) = %v; want nil", err)
+		}
+		if err := writer.WriteBitAt(16, true); !errors.Is(err, ErrBudgetExceeded) {
+			t.Errorf("WriteBitAt(16) = %v; want ErrBudgetExceeded", err)
+		}
+		writer.Truncate(8)
+		writer.Seek(8)
+		for i := range 8 {
+			if err := writer.WriteBit(true); err != nil {
+				t.Fatalf("WriteBit() #%d = %v; want nil", i, err)
+			}
+		}
+		if err := writer.WriteBit(true); !errors.Is(err, ErrBudgetExceeded) {
+			t.Errorf("WriteBit() past the limit = %v; want ErrBudgetExceeded", err)
+		}
+	})
+
+	t.Run("WholeCodes", func(t *testing.T) {
+		// each code follows one bit, so byte-aligned codes include 7 padding bits
+		tests := []struct {
+			name  string
+			write func(w *BitWriter[uint8]) error
+			bits  int
+		}{
+			{"String", func(w *BitWriter[uint8]) error { return w.WriteString("hi", 8) }, 24},
+			{"NullTerminated", func(w *BitWriter[uint8]) error { return w.WriteString("hi", 0) }, 24},
+			{"Uvarint", func(w *BitWriter[uint8]) error { w.WriteUvarint(300); return nil }, 23},
+			{"Varint", func(w *BitWriter[uint8]) error { w.WriteVarint(-300); return nil }, 23},
+			{"UE", func(w *BitWriter[uint8]) error { w.WriteUE(1 << 20); return nil }, 41},
+			{"SE", func(w *BitWriter[uint8]) error { w.WriteSE(-5); return nil }, 7},
+			{"Rice", func(w *BitWriter[uint8]) error { w.WriteRice(2, 100); return nil }, 28},
+			{"Golomb", func(w *BitWriter[uint8]) error { w.WriteGolomb(3, 100); return nil }, 36},
+			{"EliasGamma", func(w *BitWriter[uint8]) error { w.WriteEliasGamma(1000); return nil }, 19},
+			{"EliasDelta", func(w *BitWriter[uint8]) error { w.WriteEliasDelta(1000); return nil }, 16},
+			{"BCD", func(w *BitWriter[uint8]) error { return w.WriteBCD(1234, 4) }, 16},
+			{"PackedDecimal", func(w *BitWriter[uint8]) error { return w.WritePackedDecimal(-12, 3) }, 16},
+			{"ExpandableSize", func(w *BitWriter[uint8]) error { return w.WriteExpandableSize(1<<20, 0) }, 31},
+			{"RBSPTrailingBits", func(w *BitWriter[uint8]) error { w.WriteRBSPTrailingBits(); return nil }, 7},
+			{"Reserve", func(w *BitWriter[uint8]) error { w.Reserve(12); return nil }, 12},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				writer := NewBitWriterLimit[uint8](0, 0, 1+tt.bits)
+				writer.WriteBool(true)
+				if err := tt.write(writer); err != nil || writer.Err() != nil || writer.Bits() != 1+tt.bits {
+					t.Errorf("at the limit: error = %v, Err() = %v, Bits() = %d; want nil, nil, %d",
+						err, writer.Err(), writer.Bits(), 1+tt.bits)
+				}
+				writer = NewBitWriterLimit[uint8](0, 0, tt.bits)
+				writer.WriteBool(true)
+				err := tt.write(writer)
+				if !errors.Is(writer.Err(), ErrBudgetExceeded) || writer.Bits() != 1 {
+					t.Errorf("one bit short: Err() = %v, Bits() = %d; want ErrBudgetExceeded, 1", writer.Err(), writer.Bits())
+				}
+				if err != nil && !errors.Is(err, ErrBudgetExceeded) {
+					t.Errorf("one bit short: error = %v; want ErrBudgetExceeded", err)
+				}
+			})
+		}
+	})
+
+	t.Run("Blocks", func(t *testing.T) {
+		writer := NewBitWriterLimit[uint8](0, 0, 40)
+		payload := NewBitReader([]uint8{1, 2, 3, 4}, 0, 0)
+		fw := NewFrameWriter(writer, FrameFormat{Sync: 0x47, SyncBits: 8, LengthBits: 8})
+		if err := fw.WriteFrame(payload); !errors.Is(err, ErrBudgetExceeded) || writer.Bits() != 0 || payload.Pos() != 0 {
+			t.Errorf("WriteFrame() = %v with %d bits, payload at %d; want ErrBudgetExceeded with 0, payload at 0",
+				err, writer.Bits(), payload.Pos())
+		}
+
+		writer = NewBitWriterLimit[uint8](0, 0, 40)
+		if err := writer.PackUints([]uint64{1, 2, 3}, 16); !errors.Is(err, ErrBudgetExceeded) || writer.Bits() != 0 {
+			t.Errorf("PackUints() = %v with %d bits; want ErrBudgetExceeded with 0", err, writer.Bits())
+		}
+
+		// 100 ones take three words: 60 at width 1, 30 at width 2 and 10 at width 6
+		ones := make([]uint64, 100)
+		for i := range ones {
+			ones[i] = 1
+		}
+		writer = NewBitWriterLimit[uint8](0, 0, 191)
+		if err := writer.WriteSimple8b(ones); !errors.Is(err, ErrBudgetExceeded) || writer.Bits() != 0 {
+			t.Errorf("WriteSimple8b() = %v with %d bits; want ErrBudgetExceeded with 0", err, writer.Bits())
+		}
+		writer = NewBitWriterLimit[uint8](0, 0, 192)
+		if err := writer.WriteSimple8b(ones); err != nil || writer.Bits() != 192 {
+			t.Errorf("WriteSimple8b() at the limit = %v with %d bits; want nil with 192", err, writer.Bits())
+		}
+	})
+
+	t.Run("DiscardedPlaceholder", func(t *testing.T) {
+		writer := NewBitWriterLimit[uint8](0, 0, 16)
+		writer.WriteBits(0xAA, 8)
+		mark := writer.Mark()
+		p := writer.Reserve(16)
+		if p.Bits() != 0 {
+			t.Errorf("Reserve() past the limit: Bits() = %d; want 0", p.Bits())
+		}
+		writer.Rollback(mark)
+		writer.WriteBits(0x55, 8)
+		p.Fill(0xFFFF)
+		if got := writer.ToHex(); got != "aa55" {
+			t.Errorf("ToHex() after Fill = %s; want aa55", got)
+		}
+	})
+
+	t.Run("BudgetFunc", func(t *testing.T) {
+		writer := NewBitWriterLimit[uint64](0, 0, 64)
+		var need int
+		writer.SetBudgetFunc(func(n int) { need = n })
+		writer.WriteBits(0, 60)
+		writer.Append(writer)
+		if need != 120 {
+			t.Errorf("budget func got %d; want 120", need)
+		}
+		if writer.Bits() != 60 {
+			t.Errorf("Bits() = %d; want 60", writer.Bits())
+		}
+	})
+
+	t.Run("Unlimited", func(t *testing.T) {
+		writer := NewBitWriter[uint8](0, 0)
+		writer.Write(make([]byte, 100))
+		if writer.Limit() != 0 || writer.Err() != nil {
+			t.Errorf("Limit(), Err() = %d, %v; want 0, nil", writer.Limit(), writer.Err())
+		}
+	})
+
+	t.Run("Panics", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("NewBitWriterLimit(0) did not panic")
+			}
+		}()
+		NewBitWriterLimit[uint8](0, 0, 0)
+	})
+}
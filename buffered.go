@@ -0,0 +1,147 @@
+package bitstream
+
+import "io"
+
+// defaultBufSize is the buffer size of a BufferedBitWriter when none is given.
+const defaultBufSize = 4096
+
+// BufferedBitWriter accumulates bits and writes them to an io.Writer in byte-aligned
+// chunks, like bufio.Writer, so arbitrarily long streams can be produced with bounded
+// memory. Bits are packed MSB-first; whole bytes are written whenever the buffer fills and
+// on Flush, while a final partial byte stays buffered until FlushWithPadding.
+//
+// As with bufio.Writer, once a write to the underlying writer fails, every later write
+// and flush returns the same error. A BufferedBitWriter is not safe for concurrent use.
+type BufferedBitWriter struct {
+	w       io.Writer
+	buf     []byte
+	cur     byte // bits of the partial byte, right-aligned
+	curBits int  // number of bits in cur, 0 to 7
+	err     error
+}
+
+// NewBufferedBitWriter returns a BufferedBitWriter writing to w with a buffer of bufSize
+// bytes, or a default size if bufSize <= 0.
+func NewBufferedBitWriter(w io.Writer, bufSize int) *BufferedBitWriter {
+	if bufSize <= 0 {
+		bufSize = defaultBufSize
+	}
+	return &BufferedBitWriter{w: w, buf: make([]byte, 0, bufSize)}
+}
+
+// WriteBits appends the low bits bits of data, writing the buffer out if it fills.
+// Returns the error of the underlying writer, if any.
+//
+// Panics if bits > 64.
+func (b *BufferedBitWriter) WriteBits(data uint64, bits int) error {
+	if bits > 64 {
+		panic(&WidthError{Op: "write", Width: bits, Max: 64})
+	}
+	if b.err != nil {
+		return b.err
+	}
+	if bits <= 0 {
+		return nil
+	}
+	if bits < 64 {
+		data &= 1<<bits - 1
+	}
+	if b.curBits > 0 {
+		k := min(bits, 8-b.curBits)
+		b.cur = b.cur<<k | byte(data>>(bits-k))
+		b.curBits += k
+		bits -= k
+		if b.curBits < 8 {
+			return nil
+		}
+		b.emit(b.cur)
+		b.cur, b.curBits = 0, 0
+	}
+	for ; bits >= 8; bits -= 8 {
+		b.emit(byte(data >> (bits - 8)))
+	}
+	b.cur = byte(data) & (1<<bits - 1)
+	b.curBits = bits
+	return b.err
+}
+
+// emit appends a whole byte to the buffer, flushing it when full.
+func (b *BufferedBitWriter) emit(c byte) {
+	b.buf = append(b.buf, c)
+	if len(b.buf) == cap(b.buf) {
+		b.flush()
+	}
+}
+
+func (b *BufferedBitWriter) flush() error {
+	if b.err != nil {
+		return b.err
+	}
+	if len(b.buf) == 0 {
+		return nil
+	}
+	n, err := b.w.Write(b.buf)
+	if err == nil && n < len(b.buf) {
+		err = io.ErrShortWrite
+	}
+	if err != nil {
+		b.err = err
+		return err
+	}
+	b.buf = b.buf[:0]
+	return nil
+}
+
+// WriteBit appends one bit, with the errors of WriteBits.
+func (b *BufferedBitWriter) WriteBit(bit bool) error {
+	var v uint64
+	if bit {
+		v = 1
+	}
+	return b.WriteBits(v, 1)
+}
+
+// Write implements io.Writer, appending the bytes of p as 8-bit values at the current
+// bit position.
+func (b *BufferedBitWriter) Write(p []byte) (int, error) {
+	for i, c := range p {
+		if err := b.WriteBits(uint64(c), 8); err != nil {
+			return i, err
+		}
+	}
+	return len(p), nil
+}
+
+// Flush writes all buffered whole bytes to the underlying writer. A partial final byte
+// stays buffered so more bits can follow it.
+func (b *BufferedBitWriter) Flush() error {
+	return b.flush()
+}
+
+// FlushWithPadding completes a partial final byte with copies of bit, then writes all
+// buffered bytes to the underlying writer, ending the stream on a byte boundary.
+func (b *BufferedBitWriter) FlushWithPadding(bit bool) error {
+	if b.curBits > 0 {
+		var pad uint64
+		if bit {
+			pad = 0xFF
+		}
+		if err := b.WriteBits(pad, 8-b.curBits); err != nil {
+			return err
+		}
+	}
+	return b.flush()
+}
+
+// Buffered returns the number of bits written but not yet passed to the underlying writer.
+func (b *BufferedBitWriter) Buffered() int {
+	return len(b.buf)*8 + b.curBits
+}
+
+// Reset discards any buffered bits and error and makes b write to w.
+func (b *BufferedBitWriter) Reset(w io.Writer) {
+	b.w = w
+	b.buf = b.buf[:0]
+	b.cur, b.curBits = 0, 0
+	b.err = nil
+}
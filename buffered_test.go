@@ -0,0 +1,79 @@
+package bitstream
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+type failWriter struct{ n int }
+
+func (f *failWriter) Write(p []byte) (int, error) {
+	if f.n == 0 {
+		return 0, errors.New("write failed")
+	}
+	f.n--
+	return len(p), nil
+}
+
+func TestBufferedBitWriter(t *testing.T) {
+	t.Run("Flush", func(t *testing.T) {
+		var out bytes.Buffer
+		b := NewBufferedBitWriter(&out, 2)
+		b.WriteBits(0b101, 3)
+		b.WriteBits(0xFFFF, 16)
+		if out.Len() != 2 || b.Buffered() != 3 {
+			t.Errorf("after 19 bits: %d bytes written, %d bits buffered; want 2 and 3", out.Len(), b.Buffered())
+		}
+		b.WriteBit(false)
+		b.Write([]byte{0xA5})
+		b.Flush()
+		if b.Buffered() != 4 {
+			t.Errorf("Buffered() after Flush = %d; want 4", b.Buffered())
+		}
+		b.FlushWithPadding(true)
+		// 101 11111111 11111111 0 10100101 1111
+		if got, want := out.Bytes(), []byte{0xBF, 0xFF, 0xEA, 0x5F}; !bytes.Equal(got, want) {
+			t.Errorf("output = %x; want %x", got, want)
+		}
+		if b.Buffered() != 0 {
+			t.Errorf("Buffered() after FlushWithPadding = %d; want 0", b.Buffered())
+		}
+	})
+
+	t.Run("MatchesBitWriter", func(t *testing.T) {
+		var out bytes.Buffer
+		b := NewBufferedBitWriter(&out, 0)
+		w := NewBitWriter[uint8](0, 0)
+		for i := range 500 {
+			n := 1 + i%64
+			v := uint64(i) * 0x9E3779B97F4A7C15
+			b.WriteBits(v, n)
+			w.WriteBits(v, n)
+		}
+		b.FlushWithPadding(false)
+		w.AlignToByte()
+		if !bytes.Equal(out.Bytes(), w.Data()) {
+			t.Errorf("output differs from BitWriter")
+		}
+	})
+
+	t.Run("StickyError", func(t *testing.T) {
+		f := &failWriter{n: 1}
+		b := NewBufferedBitWriter(f, 1)
+		if err := b.WriteBits(0xAB, 8); err != nil {
+			t.Errorf("first WriteBits() error = %v; want nil", err)
+		}
+		if err := b.WriteBits(0xCD, 8); err == nil {
+			t.Errorf("WriteBits() to failing writer succeeded")
+		}
+		if err := b.WriteBit(true); err == nil || b.FlushWithPadding(false) == nil {
+			t.Errorf("error is not sticky")
+		}
+		var out bytes.Buffer
+		b.Reset(&out)
+		if err := b.WriteBits(0x12, 8); err != nil || out.Len() != 1 {
+			t.Errorf("WriteBits() after Reset = %v with %d bytes; want nil with 1", err, out.Len())
+		}
+	})
+}
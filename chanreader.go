@@ -0,0 +1,150 @@
+package bitstream
+
+import (
+	"context"
+	"io"
+)
+
+// ChanBitReader parses bits that arrive as chunks on a channel, such as buffers received
+// by a network or serial-port goroutine, without waiting for the whole message. Chunks are
+// slices of elements with the padding convention of NewBitReader, and their valid bits are
+// read in order as one stream. Closing the channel ends the stream.
+//
+// By default reads block until enough chunks have arrived; after SetBlocking(false) they
+// return ErrNoData instead, leaving the bits that did arrive to be read later.
+// A ChanBitReader is not safe for concurrent use.
+type ChanBitReader[T Unsigned] struct {
+	ch       <-chan []T
+	lp, rp   int
+	q        bitQueue
+	closed   bool
+	nonblock bool
+}
+
+// NewChanBitReader returns a blocking ChanBitReader receiving chunks from ch.
+//
+// Panics if leftPadd + rightPadd >= element bit size.
+func NewChanBitReader[T Unsigned](ch <-chan []T, leftPadd, rightPadd int) *ChanBitReader[T] {
+	NewBitReader[T](nil, leftPadd, rightPadd) // validate the padding up front
+	return &ChanBitReader[T]{ch: ch, lp: leftPadd, rp: rightPadd}
+}
+
+// SetBlocking sets whether reads wait for chunks (true, the default) or return ErrNoData
+// when too few bits have arrived (false).
+func (c *ChanBitReader[T]) SetBlocking(block bool) {
+	c.nonblock = !block
+}
+
+// add appends the valid bits of chunk to the queue.
+func (c *ChanBitReader[T]) add(chunk []T) {
+	r := NewBitReader(chunk, c.lp, c.rp)
+	for pos := 0; pos < r.bits; pos += 64 {
+		k := min(64, r.bits-pos)
+		c.q.push(r.bitsAt(pos, k), k)
+	}
+}
+
+// fill receives chunks until bits bits are buffered. It returns io.EOF or
+// io.ErrUnexpectedEOF when the channel is closed first, ErrNoData when a
+// non-blocking reader runs out of chunks, and ctx.Err() when ctx is done while waiting.
+func (c *ChanBitReader[T]) fill(ctx context.Context, bits int) error {
+	for c.q.len() < bits {
+		if c.closed {
+			if c.q.len() == 0 {
+				return io.EOF
+			}
+			return io.ErrUnexpectedEOF
+		}
+		var chunk []T
+		var ok bool
+		if c.nonblock {
+			select {
+			case chunk, ok = <-c.ch:
+			default:
+				return ErrNoData
+			}
+		} else {
+			// Take a chunk that is already waiting before considering ctx,
+			// so a done context does not discard data that has arrived.
+			select {
+			case chunk, ok = <-c.ch:
+			default:
+				select {
+				case chunk, ok = <-c.ch:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+		}
+		if !ok {
+			c.closed = true
+			continue
+		}
+		c.add(chunk)
+	}
+	return nil
+}
+
+// ReadBits reads bits bits and returns them right-aligned. It returns io.EOF if the channel
+// is closed with no bits left, io.ErrUnexpectedEOF if it is closed with fewer than bits left,
+// and ErrNoData from a non-blocking reader before enough bits arrive; nothing is consumed
+// on error.
+//
+// Panics if bits > 64.
+func (c *ChanBitReader[T]) ReadBits(bits int) (uint64, error) {
+	return c.ReadBitsContext(context.Background(), bits)
+}
+
+// ReadBitsContext is like ReadBits but stops waiting for chunks when ctx is done,
+// returning ctx.Err() without consuming any bits.
+//
+// Panics if bits > 64.
+func (c *ChanBitReader[T]) ReadBitsContext(ctx context.Context, bits int) (uint64, error) {
+	v, err := c.PeekBitsContext(ctx, bits)
+	if err == nil && bits > 0 {
+		c.q.pop(bits)
+	}
+	return v, err
+}
+
+// PeekBits returns the next bits bits without consuming them, with the errors of ReadBits.
+//
+// Panics if bits > 64.
+func (c *ChanBitReader[T]) PeekBits(bits int) (uint64, error) {
+	return c.PeekBitsContext(context.Background(), bits)
+}
+
+// PeekBitsContext is like PeekBits but stops waiting for chunks when ctx is done,
+// returning ctx.Err().
+//
+// Panics if bits > 64.
+func (c *ChanBitReader[T]) PeekBitsContext(ctx context.Context, bits int) (uint64, error) {
+	if bits > 64 {
+		panic(&WidthError{Op: "read", Width: bits, Max: 64})
+	}
+	if bits <= 0 {
+		return 0, nil
+	}
+	if err := c.fill(ctx, bits); err != nil {
+		return 0, err
+	}
+	return c.q.peek(bits), nil
+}
+
+// ReadBit reads one bit with the errors of ReadBits.
+func (c *ChanBitReader[T]) ReadBit() (bool, error) {
+	v, err := c.ReadBits(1)
+	return v != 0, err
+}
+
+// ReadBitContext is like ReadBit but stops waiting for chunks when ctx is done,
+// returning ctx.Err().
+func (c *ChanBitReader[T]) ReadBitContext(ctx context.Context) (bool, error) {
+	v, err := c.ReadBitsContext(ctx, 1)
+	return v != 0, err
+}
+
+// Buffered returns the number of bits received but not yet read, without receiving more.
+func (c *ChanBitReader[T]) Buffered() int {
+	return c.q.len()
+}
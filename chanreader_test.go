@@ -0,0 +1,97 @@
+package bitstream
+
+import (
+	"context"
+	"io"
+	"testing"
+)
+
+func TestChanBitReader(t *testing.T) {
+	t.Run("Blocking", func(t *testing.T) {
+		ch := make(chan []uint8)
+		go func() {
+			ch <- []uint8{0xAB}
+			ch <- []uint8{0xCD, 0xEF}
+			ch <- nil
+			ch <- []uint8{0x80}
+			close(ch)
+		}()
+		r := NewChanBitReader(ch, 0, 0)
+		if v, _ := r.ReadBits(4); v != 0xA {
+			t.Errorf("ReadBits(4) = %#x; want 0xa", v)
+		}
+		// spans two chunks
+		if v, _ := r.ReadBits(16); v != 0xBCDE {
+			t.Errorf("ReadBits(16) = %#x; want 0xbcde", v)
+		}
+		if v, _ := r.PeekBits(5); v != 0b11111 {
+			t.Errorf("PeekBits(5) = %b; want 11111", v)
+		}
+		if _, err := r.ReadBits(13); err != io.ErrUnexpectedEOF || r.Buffered() != 12 {
+			t.Errorf("ReadBits(13) error = %v with %d buffered; want io.ErrUnexpectedEOF with 12", err, r.Buffered())
+		}
+		r.ReadBits(4)
+		if bit, _ := r.ReadBit(); !bit {
+			t.Errorf("ReadBit() = false; want true")
+		}
+		r.ReadBits(7)
+		if _, err := r.ReadBit(); err != io.EOF {
+			t.Errorf("ReadBit() at end error = %v; want io.EOF", err)
+		}
+	})
+
+	t.Run("NonBlocking", func(t *testing.T) {
+		ch := make(chan []uint16, 4)
+		r := NewChanBitReader(ch, 4, 0) // 12 valid bits per element
+		r.SetBlocking(false)
+		if _, err := r.ReadBits(1); err != ErrNoData {
+			t.Errorf("ReadBits(1) on empty channel error = %v; want ErrNoData", err)
+		}
+		ch <- []uint16{0xFABC}
+		if _, err := r.ReadBits(16); err != ErrNoData || r.Buffered() != 12 {
+			t.Errorf("ReadBits(16) error = %v with %d buffered; want ErrNoData with 12", err, r.Buffered())
+		}
+		ch <- []uint16{0x0123}
+		if v, err := r.ReadBits(16); v != 0xABC1 || err != nil {
+			t.Errorf("ReadBits(16) = %#x, %v; want 0xabc1, nil", v, err)
+		}
+		close(ch)
+		if v, _ := r.ReadBits(8); v != 0x23 {
+			t.Errorf("ReadBits(8) = %#x; want 0x23", v)
+		}
+		if _, err := r.ReadBits(1); err != io.EOF {
+			t.Errorf("ReadBits(1) after close error = %v; want io.EOF", err)
+		}
+	})
+
+	t.Run("Context", func(t *testing.T) {
+		ch := make(chan []uint8, 1)
+		r := NewChanBitReader(ch, 0, 0)
+		ch <- []uint8{0xF0}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if _, err := r.ReadBitsContext(ctx, 9); err != context.Canceled || r.Buffered() != 8 {
+			t.Errorf("ReadBitsContext(9) = %v with %d buffered; want context.Canceled with 8", err, r.Buffered())
+		}
+		if _, err := r.PeekBitsContext(ctx, 16); err != context.Canceled {
+			t.Errorf("PeekBitsContext(16) error = %v; want context.Canceled", err)
+		}
+		if bit, err := r.ReadBitContext(ctx); !bit || err != nil {
+			t.Errorf("ReadBitContext() = %v, %v; want true, nil", bit, err)
+		}
+	})
+
+	t.Run("QueuedBeforeCancel", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		// select picks a ready case at random, so repeat to catch a done context winning
+		for i := range 100 {
+			ch := make(chan []uint8, 1)
+			ch <- []uint8{0xA5}
+			r := NewChanBitReader(ch, 0, 0)
+			if v, err := r.ReadBitsContext(ctx, 8); v != 0xA5 || err != nil {
+				t.Fatalf("#%d: ReadBitsContext(8) with a queued chunk = %#x, %v; want 0xa5, nil", i, v, err)
+			}
+		}
+	})
+}
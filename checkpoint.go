@@ -0,0 +1,53 @@
+package bitstream
+
+// Checkpoint records the cursor and bit limit of a BitReader, or the cursor and length
+// of a BitWriter, so they can be restored later.
+type Checkpoint struct {
+	pos  int
+	bits int
+}
+
+// Checkpoint returns the current read position and valid bit count,
+// including any limit set by SetBits.
+// Pass it to Restore to rewind after a failed speculative parse.
+func (r *BitReader[T]) Checkpoint() Checkpoint {
+	return Checkpoint{pos: r.pos, bits: r.bits}
+}
+
+// Restore rewinds the read position and valid bit count to a Checkpoint
+// taken earlier from the same BitReader.
+func (r *BitReader[T]) Restore(c Checkpoint) {
+	r.pos = c.pos
+	r.bits = c.bits
+}
+
+// Clone returns a new BitReader sharing the same data, padding, cursor,
+// and valid bit count. Moving the cursor of either reader does not affect the other.
+func (r *BitReader[T]) Clone() *BitReader[T] {
+	c := *r
+	return &c
+}
+
+// Mark returns the current write position and number of written bits.
+// Pass it to Rollback to discard a speculative encoding, for example after trying
+// one coding mode and measuring its size, without copying the buffer.
+func (w *BitWriter[T]) Mark() Checkpoint {
+	w.lock()
+	defer w.unlock()
+	return Checkpoint{pos: w.pos, bits: w.bits}
+}
+
+// Rollback discards every bit written past a Checkpoint taken earlier by Mark on the
+// same BitWriter, as Truncate does, and moves the cursor back to where it was.
+// Bits before the mark that were overwritten after seeking are not restored.
+//
+// Panics if fewer bits than at the time of the mark remain written.
+func (w *BitWriter[T]) Rollback(c Checkpoint) {
+	w.lock()
+	defer w.unlock()
+	if c.bits > w.bits {
+		panic("bitstream: rollback past the end of the stream")
+	}
+	w.truncate(c.bits)
+	w.pos = c.pos
+}
@@ -0,0 +1,71 @@
+package bitstream
+
+import "testing"
+
+func TestCheckpoint(t *testing.T) {
+	t.Run("Restore", func(t *testing.T) {
+		reader := NewBitReader([]uint8{0b10101100, 0b11100011}, 0, 0)
+		reader.Skip(2)
+		cp := reader.Checkpoint()
+
+		// try reading as ue(v), then rewind and read as a plain field
+		reader.SetBits(10)
+		if _, err := reader.ReadUE(); err != nil {
+			t.Fatalf("ReadUE() returned error: %v", err)
+		}
+		reader.Restore(cp)
+		if reader.Pos() != 2 || reader.Bits() != 16 {
+			t.Errorf("after Restore: Pos() = %d, Bits() = %d; want 2, 16", reader.Pos(), reader.Bits())
+		}
+		if got, _ := reader.ReadBits(14); got != 0b10110011100011 {
+			t.Errorf("ReadBits(14) after Restore = %014b; want %014b", got, 0b10110011100011)
+		}
+	})
+
+	t.Run("Clone", func(t *testing.T) {
+		reader := NewBitReader([]uint16{0xABCD}, 2, 2)
+		reader.SetBits(10)
+		reader.Skip(4)
+		clone := reader.Clone()
+		if clone.Pos() != 4 || clone.Bits() != 10 {
+			t.Errorf("clone: Pos() = %d, Bits() = %d; want 4, 10", clone.Pos(), clone.Bits())
+		}
+		a, _ := clone.ReadBits(6)
+		if clone.Pos() != 10 || reader.Pos() != 4 {
+			t.Errorf("after reading clone: clone.Pos() = %d, reader.Pos() = %d; want 10, 4", clone.Pos(), reader.Pos())
+		}
+		b, _ := reader.ReadBits(6)
+		if a != b {
+			t.Errorf("clone read %06b, reader read %06b; want equal", a, b)
+		}
+	})
+
+	t.Run("Rollback", func(t *testing.T) {
+		writer := NewBitWriter[uint8](1, 0)
+		writer.WriteBits(0b101, 3)
+		mark := writer.Mark()
+
+		// try an Exp-Golomb code, then roll back and write a fixed-width field instead
+		writer.WriteUE(30)
+		if writer.Bits() != 12 {
+			t.Fatalf("Bits() after WriteUE = %d; want 12", writer.Bits())
+		}
+		writer.Rollback(mark)
+		if writer.Bits() != 3 || len(writer.Data()) != 1 {
+			t.Errorf("after Rollback: Bits() = %d, %d elements; want 3, 1", writer.Bits(), len(writer.Data()))
+		}
+		writer.WriteBits(0b1010, 4)
+		if got := writer.ToHex(); got != "b4" {
+			t.Errorf("ToHex() = %s; want b4", got)
+		}
+
+		defer func() {
+			if recover() == nil {
+				t.Error("Rollback past the end did not panic")
+			}
+		}()
+		mark = writer.Mark()
+		writer.Truncate(0)
+		writer.Rollback(mark)
+	})
+}
@@ -0,0 +1,60 @@
+package bitstream
+
+// Parity returns 1 if the number of set bits in [from, to) is odd and 0 if it is even.
+// The range is clipped to the valid bits, as for Count; it does not move the cursor.
+func (r *BitReader[T]) Parity(from, to int) uint64 {
+	return uint64(r.Count(from, to) & 1)
+}
+
+// Checksum returns the sum of the consecutive width-bit fields in [from, to), modulo 2^width.
+// If the range is not a multiple of width, the last field is padded with zero bits on the right.
+// The range is clipped to the valid bits; it does not move the cursor.
+//
+// Panics if width < 1 or width > 64.
+func (r *BitReader[T]) Checksum(from, to, width int) uint64 {
+	if width < 1 || width > 64 {
+		panic("bitstream: checksum width must be between 1 and 64")
+	}
+	from, to = max(from, 0), min(to, r.bits)
+	var sum uint64
+	for pos := from; pos < to; pos += width {
+		k := min(width, to-pos)
+		sum += r.bitsAt(pos, k) << (width - k)
+	}
+	if width < 64 {
+		sum &= uint64(1)<<width - 1
+	}
+	return sum
+}
+
+// Parity is like BitReader.Parity over the written bits.
+func (w *BitWriter[T]) Parity(from, to int) uint64 {
+	w.lock()
+	defer w.unlock()
+	r := w.reader()
+	return r.Parity(from, to)
+}
+
+// Checksum is like BitReader.Checksum over the written bits.
+//
+// Panics if width < 1 or width > 64.
+func (w *BitWriter[T]) Checksum(from, to, width int) uint64 {
+	w.lock()
+	defer w.unlock()
+	r := w.reader()
+	return r.Checksum(from, to, width)
+}
+
+// WriteParity appends a parity bit covering the bits written from position from onwards.
+// With odd false the bit gives the covered bits and the parity bit an even number of ones
+// (even parity); with odd true, an odd number.
+func (w *BitWriter[T]) WriteParity(from int, odd bool) {
+	w.lock()
+	defer w.unlock()
+	r := w.reader()
+	p := r.Parity(from, w.bits)
+	if odd {
+		p ^= 1
+	}
+	w.writeBits(p, 1)
+}
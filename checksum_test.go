@@ -0,0 +1,69 @@
+package bitstream
+
+import "testing"
+
+func TestParity(t *testing.T) {
+	reader := NewBitReader([]uint8{0b10110100, 0b01111111}, 1, 0)
+	// valid bits: 0110100 1111111
+	tests := []struct {
+		from, to int
+		want     uint64
+	}{
+		{0, 7, 1},
+		{0, 14, 0},
+		{1, 3, 0},
+		{7, 14, 1},
+		{5, 5, 0},
+		{-3, 100, 0},
+	}
+	for _, tt := range tests {
+		if got := reader.Parity(tt.from, tt.to); got != tt.want {
+			t.Errorf("Parity(%d, %d) = %d; want %d", tt.from, tt.to, got, tt.want)
+		}
+	}
+
+	t.Run("WriteParity", func(t *testing.T) {
+		writer := NewBitWriter[uint16](0, 3)
+		writer.WriteBits(0b1011001, 7)
+		writer.WriteParity(0, false)
+		writer.WriteBits(0b1011001, 7)
+		writer.WriteParity(8, true)
+		if got := writer.Parity(0, 8); got != 0 {
+			t.Errorf("even Parity(0, 8) = %d; want 0", got)
+		}
+		if got := writer.Parity(8, 16); got != 1 {
+			t.Errorf("odd Parity(8, 16) = %d; want 1", got)
+		}
+		if got, want := writer.ToHex(), "b2b3"; got != want {
+			t.Errorf("ToHex() = %q; want %q", got, want)
+		}
+	})
+}
+
+func TestChecksum(t *testing.T) {
+	writer := NewBitWriter[uint32](1, 1)
+	writer.Write([]byte{0xFF, 0x01, 0x80, 0x7F})
+	writer.WriteBits(0b101, 3)
+	reader := NewBitReader(writer.Data(), 1, 1)
+	reader.SetBits(writer.Bits())
+
+	tests := []struct {
+		from, to, width int
+		want            uint64
+	}{
+		{0, 32, 8, 0xFF},                       // 0xFF+0x01+0x80+0x7F = 0x1FF
+		{0, 32, 16, 0x7F80},                    // 0xFF01+0x807F = 0x17F80
+		{0, 35, 8, 0x9F},                       // the partial field 101 is padded to 0xA0
+		{8, 24, 4, 0x9},                        // nibbles 0, 1, 8, 0
+		{0, 35, 64, (0xFF01807F<<3 | 5) << 29}, // one field wider than the range
+		{0, 0, 8, 0},
+	}
+	for _, tt := range tests {
+		if got := reader.Checksum(tt.from, tt.to, tt.width); got != tt.want {
+			t.Errorf("Checksum(%d, %d, %d) = %#x; want %#x", tt.from, tt.to, tt.width, got, tt.want)
+		}
+		if got := writer.Checksum(tt.from, tt.to, tt.width); got != tt.want {
+			t.Errorf("writer Checksum(%d, %d, %d) = %#x; want %#x", tt.from, tt.to, tt.width, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,207 @@
+// Command bitdump prints the bits of a file, like xxd for bit-level formats.
+//
+// Usage:
+//
+//	bitdump [flags] [file]
+//
+// The file, or standard input if none is given, is shown as binary digits in groups of
+// -g bits, 64 bits per line, each line prefixed by the bit offset of its first bit.
+// -s and -n restrict the output to a window of the stream.
+//
+// With -find, every occurrence of a bit pattern in the window, at any alignment, is marked
+// with carets on the line below the bits. The pattern is written in binary ("0b1011" or
+// "1011") or, with a 0x prefix, in hexadecimal ("0x47"), and is at most 64 bits long.
+//
+// With -fields, the window is parsed as a list of named fields instead, given as
+// name:width pairs separated by commas, where the width is a number of bits or "ue" for an
+// unsigned Exp-Golomb code, and a layout table is printed (or JSON with -json):
+//
+//	bitdump -fields sync:8,pid:13,len:ue stream.ts
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/yyyoichi/bitstream-go"
+)
+
+// options holds the command-line flags.
+type options struct {
+	group  int    // bits per group
+	offset int    // first bit shown
+	length int    // number of bits shown, or -1 for the rest of the stream
+	find   string // pattern to mark
+	fields string // field list to extract
+	json   bool   // print the field layout as JSON
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("bitdump: ")
+	var opts options
+	flag.IntVar(&opts.group, "g", 8, "number of bits per group")
+	flag.IntVar(&opts.offset, "s", 0, "bit offset to start at")
+	flag.IntVar(&opts.length, "n", -1, "number of bits to show; default the rest of the input")
+	flag.StringVar(&opts.find, "find", "", "bit pattern to mark, in binary or 0x-prefixed hexadecimal")
+	flag.StringVar(&opts.fields, "fields", "", "comma-separated name:width field list to extract")
+	flag.BoolVar(&opts.json, "json", false, "print extracted fields as JSON")
+	flag.Parse()
+
+	var data []byte
+	var err error
+	switch args := flag.Args(); len(args) {
+	case 0:
+		data, err = io.ReadAll(os.Stdin)
+	case 1:
+		data, err = os.ReadFile(args[0])
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := run(opts, data, os.Stdout); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// run writes the output selected by opts for data to out.
+func run(opts options, data []byte, out io.Writer) error {
+	r := bitstream.NewBitReader(data, 0, 0)
+	end := r.Bits()
+	if opts.length >= 0 {
+		end = opts.offset + opts.length
+	}
+	if opts.offset < 0 || opts.offset > end || end > r.Bits() {
+		return fmt.Errorf("window [%d, %d) is outside the %d bits of the input", opts.offset, end, r.Bits())
+	}
+	if opts.group < 1 {
+		return errors.New("group size must be positive")
+	}
+	if opts.fields != "" {
+		return dumpFields(r, opts, end, out)
+	}
+	var marks []bool
+	if opts.find != "" {
+		pattern, n, err := parsePattern(opts.find)
+		if err != nil {
+			return err
+		}
+		marks = findAll(r.Slice(0, end), pattern, n, opts.offset)
+	}
+	return dump(r, opts.offset, end, opts.group, marks, out)
+}
+
+// parsePattern parses a binary or 0x-prefixed hexadecimal bit pattern.
+func parsePattern(s string) (uint64, int, error) {
+	digits, base, bitsPer := strings.TrimPrefix(s, "0b"), 2, 1
+	if rest, ok := strings.CutPrefix(s, "0x"); ok {
+		digits, base, bitsPer = rest, 16, 4
+	}
+	n := len(digits) * bitsPer
+	if n == 0 || n > 64 {
+		return 0, 0, fmt.Errorf("pattern %q must be 1 to 64 bits long", s)
+	}
+	v, err := strconv.ParseUint(digits, base, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid pattern %q", s)
+	}
+	return v, n, nil
+}
+
+// findAll marks the bits of every occurrence of pattern that starts at or after from.
+// Occurrences may overlap.
+func findAll(r *bitstream.BitReader[uint8], pattern uint64, n, from int) []bool {
+	marks := make([]bool, r.Bits())
+	for pos, err := r.Find(pattern, n, from); err == nil; pos, err = r.Find(pattern, n, pos+1) {
+		for i := pos; i < pos+n; i++ {
+			marks[i] = true
+		}
+	}
+	return marks
+}
+
+// dump writes the bits in [from, to) as lines of 64 bits, followed by a line of carets
+// under the marked bits of each line that has any.
+func dump(r *bitstream.BitReader[uint8], from, to, group int, marks []bool, out io.Writer) error {
+	perLine := max(64/group, 1) * group
+	width := len(strconv.Itoa(max(to-1, 0)))
+	var line, caret []byte
+	for start := from; start < to; start += perLine {
+		line = fmt.Appendf(line[:0], "%*d: ", width, start)
+		caret = append(caret[:0], strings.Repeat(" ", len(line))...)
+		marked := false
+		for pos := start; pos < min(start+perLine, to); pos++ {
+			if pos > start && (pos-from)%group == 0 {
+				line = append(line, ' ')
+				caret = append(caret, ' ')
+			}
+			bit, _ := r.ReadBitAt(pos)
+			line = append(line, "01"[b2i(bit)])
+			if marks != nil && marks[pos] {
+				caret = append(caret, '^')
+				marked = true
+			} else {
+				caret = append(caret, ' ')
+			}
+		}
+		line = append(line, '\n')
+		if marked {
+			line = append(line, strings.TrimRight(string(caret), " ")...)
+			line = append(line, '\n')
+		}
+		if _, err := out.Write(line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dumpFields parses the fields listed in opts starting at opts.offset and writes their layout.
+func dumpFields(r *bitstream.BitReader[uint8], opts options, end int, out io.Writer) error {
+	r = r.Slice(0, end)
+	r.Seek(opts.offset)
+	f := bitstream.NewFieldRecorder(r)
+	for spec := range strings.SplitSeq(opts.fields, ",") {
+		name, width, ok := strings.Cut(spec, ":")
+		if !ok || name == "" {
+			return fmt.Errorf("invalid field %q; want name:width", spec)
+		}
+		var err error
+		if width == "ue" {
+			_, err = f.FieldFunc(name, (*bitstream.BitReader[uint8]).ReadUE)
+		} else if n, perr := strconv.Atoi(width); perr != nil || n < 1 || n > 64 {
+			return fmt.Errorf("invalid width %q for field %s; want 1 to 64 or ue", width, name)
+		} else {
+			_, err = f.Field(name, n)
+		}
+		if err != nil {
+			return fmt.Errorf("field %s at bit %d: %w", name, r.Pos(), err)
+		}
+	}
+	if opts.json {
+		b, err := json.MarshalIndent(f, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(out, "%s\n", b)
+		return err
+	}
+	return f.WriteLayout(out)
+}
+
+func b2i(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	data := []byte{0x47, 0x1F, 0xFF, 0x10, 0x47}
+	tests := map[string]struct {
+		opts options
+		want string
+	}{
+		"Dump": {
+			opts: options{group: 8, length: -1},
+			want: " 0: 01000111 00011111 11111111 00010000 01000111\n",
+		},
+		"Window": {
+			opts: options{group: 4, offset: 4, length: 12},
+			want: " 4: 0111 0001 1111\n",
+		},
+		"Lines": {
+			opts: options{group: 33, length: -1},
+			want: " 0: 010001110001111111111111000100000\n" +
+				"33: 1000111\n",
+		},
+		"Find": {
+			opts: options{group: 8, length: -1, find: "0x47"},
+			want: " 0: 01000111 00011111 11111111 00010000 01000111\n" +
+				"    ^^^^^^^^                            ^^^^^^^^\n",
+		},
+		"FindUnaligned": {
+			opts: options{group: 8, offset: 8, length: 16, find: "0b1111"},
+			want: " 8: 00011111 11111111\n" +
+				"       ^^^^^ ^^^^^^^^\n",
+		},
+		"Fields": {
+			opts: options{group: 8, length: -1, fields: "sync:8,tei:1,pid:13,len:ue"},
+			want: "OFFSET  BITS  NAME  VALUE\n" +
+				"0       8     sync  71 (01000111)\n" +
+				"8       1     tei   0 (0)\n" +
+				"9       13    pid   2047 (0011111111111)\n" +
+				"22      1     len   0 (0)\n",
+		},
+		"JSON": {
+			opts: options{group: 8, offset: 32, length: -1, fields: "sync:8", json: true},
+			want: "[\n  {\n    \"name\": \"sync\",\n    \"offset\": 32,\n    \"bits\": 8,\n    \"value\": 71\n  }\n]\n",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var sb strings.Builder
+			if err := run(tt.opts, data, &sb); err != nil {
+				t.Fatalf("run() returned error: %v", err)
+			}
+			if sb.String() != tt.want {
+				t.Errorf("run() wrote\n%q\nwant\n%q", sb.String(), tt.want)
+			}
+		})
+	}
+
+	t.Run("Errors", func(t *testing.T) {
+		tests := map[string]options{
+			"window":  {group: 8, offset: 8, length: 40},
+			"group":   {group: 0, length: -1},
+			"pattern": {group: 8, length: -1, find: "0b012"},
+			"long":    {group: 8, length: -1, find: "0x" + strings.Repeat("f", 17)},
+			"spec":    {group: 8, length: -1, fields: "sync"},
+			"width":   {group: 8, length: -1, fields: "sync:65"},
+			"eof":     {group: 8, length: -1, fields: "a:32,b:9"},
+		}
+		for name, opts := range tests {
+			if err := run(opts, data, io.Discard); err == nil {
+				t.Errorf("%s: run() error = nil; want error", name)
+			}
+		}
+	})
+}
@@ -0,0 +1,33 @@
+// Package example holds structs used to test the code emitted by bitstreamgen.
+package example
+
+import "github.com/yyyoichi/bitstream-go/cmd/bitstreamgen/internal/example/wire"
+
+//go:generate go run ../.. -type=Packet,Point -output=packet_bits.go
+
+// Channel is a named integer type.
+type Channel int8
+
+// Point is a nested struct with signed fields.
+type Point struct {
+	X int16 `bits:"6,signed"`
+	Y int16 `bits:"6,signed"`
+}
+
+// Packet exercises every supported field kind.
+type Packet struct {
+	Version uint8 `bits:"3"`
+	Flag    bool
+	Delta   int8 `bits:"5,signed"`
+	Skipped int
+	Ignored uint8 `bits:"-"`
+	Origin  Point
+	Coeffs  [3]uint16     `bits:"12"`
+	Length  uint64        `bits:"64"`
+	Offset  int64         `bits:"64,signed"`
+	Channel Channel       `bits:"4,signed"`
+	Levels  [2]wire.Level `bits:"3"`
+	Header  wire.Header
+	Route   [2]wire.Header
+	hidden  uint8 `bits:"8"`
+}
@@ -0,0 +1,76 @@
+package example
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/yyyoichi/bitstream-go"
+	"github.com/yyyoichi/bitstream-go/cmd/bitstreamgen/internal/example/wire"
+)
+
+func TestGenerated(t *testing.T) {
+	in := Packet{
+		Version: 6,
+		Flag:    true,
+		Delta:   -16,
+		Skipped: 42,
+		Ignored: 7,
+		Origin:  Point{X: -32, Y: 31},
+		Coeffs:  [3]uint16{0xFFF, 0, 0xABC},
+		Length:  1<<64 - 1,
+		Offset:  -1 << 63,
+		Channel: -8,
+		Levels:  [2]wire.Level{5, 2},
+		Header:  wire.Header{Kind: 9, Urgent: true, Level: 7, Trace: [2]int8{-8, 7}},
+		Route:   [2]wire.Header{{Kind: 1}, {Urgent: true, Trace: [2]int8{-1, 0}}},
+	}
+
+	t.Run("Marshal", func(t *testing.T) {
+		want, err := bitstream.Marshal(&in)
+		if err != nil {
+			t.Fatalf("Marshal() error = %v", err)
+		}
+		w := bitstream.NewBitWriter[uint8](0, 0)
+		in.EncodeBits(w)
+		if !slices.Equal(w.Data(), want) {
+			t.Errorf("EncodeBits() = %x; want %x", w.Data(), want)
+		}
+	})
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		w := bitstream.NewBitWriter[uint32](1, 2)
+		in.EncodeBits(w)
+		var out Packet
+		if err := out.DecodeBits(bitstream.NewBitReader(w.Data(), 1, 2)); err != nil {
+			t.Fatalf("DecodeBits() error = %v", err)
+		}
+		want := in
+		want.Skipped, want.Ignored = 0, 0
+		if out != want {
+			t.Errorf("DecodeBits() = %+v; want %+v", out, want)
+		}
+	})
+
+	t.Run("Truncated", func(t *testing.T) {
+		var out Packet
+		if err := out.DecodeBits(bitstream.NewBitReader([]uint8{0xFF}, 0, 0)); err == nil {
+			t.Error("DecodeBits() error = nil; want error")
+		}
+	})
+
+	t.Run("Allocs", func(t *testing.T) {
+		w := bitstream.NewBitWriter[uint64](0, 0)
+		w.Grow(1024)
+		r := bitstream.NewBitReader(make([]uint64, 8), 0, 0)
+		var out Packet
+		allocs := testing.AllocsPerRun(10, func() {
+			w.Reset()
+			in.EncodeBits(w)
+			r.Seek(0)
+			out.DecodeBits(r)
+		})
+		if allocs != 0 {
+			t.Errorf("allocs = %v; want 0", allocs)
+		}
+	})
+}
@@ -0,0 +1,156 @@
+// Code generated by bitstreamgen; DO NOT EDIT.
+
+package example
+
+import (
+	"github.com/yyyoichi/bitstream-go"
+	"github.com/yyyoichi/bitstream-go/cmd/bitstreamgen/internal/example/wire"
+)
+
+// EncodeBits writes v to w as bitstream.Marshal would.
+func (v *Packet) EncodeBits(w bitstream.Writer) {
+	w.WriteBits(uint64(v.Version), 3)
+	if v.Flag {
+		w.WriteBits(1, 1)
+	} else {
+		w.WriteBits(0, 1)
+	}
+	w.WriteBits(uint64(v.Delta), 5)
+	v.Origin.EncodeBits(w)
+	for i := range v.Coeffs {
+		w.WriteBits(uint64(v.Coeffs[i]), 12)
+	}
+	w.WriteBits(uint64(v.Length), 64)
+	w.WriteBits(uint64(v.Offset), 64)
+	w.WriteBits(uint64(v.Channel), 4)
+	for i := range v.Levels {
+		w.WriteBits(uint64(v.Levels[i]), 3)
+	}
+	w.WriteBits(uint64(v.Header.Kind), 4)
+	if v.Header.Urgent {
+		w.WriteBits(1, 1)
+	} else {
+		w.WriteBits(0, 1)
+	}
+	w.WriteBits(uint64(v.Header.Level), 3)
+	for i1 := range v.Header.Trace {
+		w.WriteBits(uint64(v.Header.Trace[i1]), 4)
+	}
+	for i := range v.Route {
+		w.WriteBits(uint64(v.Route[i].Kind), 4)
+		if v.Route[i].Urgent {
+			w.WriteBits(1, 1)
+		} else {
+			w.WriteBits(0, 1)
+		}
+		w.WriteBits(uint64(v.Route[i].Level), 3)
+		for i1 := range v.Route[i].Trace {
+			w.WriteBits(uint64(v.Route[i].Trace[i1]), 4)
+		}
+	}
+}
+
+// DecodeBits reads v from r as bitstream.Unmarshal would.
+func (v *Packet) DecodeBits(r bitstream.Reader) error {
+	var u uint64
+	var err error
+	if u, err = r.ReadBits(3); err != nil {
+		return err
+	}
+	v.Version = uint8(u)
+	if u, err = r.ReadBits(1); err != nil {
+		return err
+	}
+	v.Flag = u != 0
+	if u, err = r.ReadBits(5); err != nil {
+		return err
+	}
+	v.Delta = int8(int64(u<<59) >> 59)
+	if err := v.Origin.DecodeBits(r); err != nil {
+		return err
+	}
+	for i := range v.Coeffs {
+		if u, err = r.ReadBits(12); err != nil {
+			return err
+		}
+		v.Coeffs[i] = uint16(u)
+	}
+	if u, err = r.ReadBits(64); err != nil {
+		return err
+	}
+	v.Length = uint64(u)
+	if u, err = r.ReadBits(64); err != nil {
+		return err
+	}
+	v.Offset = int64(u)
+	if u, err = r.ReadBits(4); err != nil {
+		return err
+	}
+	v.Channel = Channel(int64(u<<60) >> 60)
+	for i := range v.Levels {
+		if u, err = r.ReadBits(3); err != nil {
+			return err
+		}
+		v.Levels[i] = wire.Level(u)
+	}
+	if u, err = r.ReadBits(4); err != nil {
+		return err
+	}
+	v.Header.Kind = uint8(u)
+	if u, err = r.ReadBits(1); err != nil {
+		return err
+	}
+	v.Header.Urgent = u != 0
+	if u, err = r.ReadBits(3); err != nil {
+		return err
+	}
+	v.Header.Level = wire.Level(u)
+	for i1 := range v.Header.Trace {
+		if u, err = r.ReadBits(4); err != nil {
+			return err
+		}
+		v.Header.Trace[i1] = int8(int64(u<<60) >> 60)
+	}
+	for i := range v.Route {
+		if u, err = r.ReadBits(4); err != nil {
+			return err
+		}
+		v.Route[i].Kind = uint8(u)
+		if u, err = r.ReadBits(1); err != nil {
+			return err
+		}
+		v.Route[i].Urgent = u != 0
+		if u, err = r.ReadBits(3); err != nil {
+			return err
+		}
+		v.Route[i].Level = wire.Level(u)
+		for i1 := range v.Route[i].Trace {
+			if u, err = r.ReadBits(4); err != nil {
+				return err
+			}
+			v.Route[i].Trace[i1] = int8(int64(u<<60) >> 60)
+		}
+	}
+	return nil
+}
+
+// EncodeBits writes v to w as bitstream.Marshal would.
+func (v *Point) EncodeBits(w bitstream.Writer) {
+	w.WriteBits(uint64(v.X), 6)
+	w.WriteBits(uint64(v.Y), 6)
+}
+
+// DecodeBits reads v from r as bitstream.Unmarshal would.
+func (v *Point) DecodeBits(r bitstream.Reader) error {
+	var u uint64
+	var err error
+	if u, err = r.ReadBits(6); err != nil {
+		return err
+	}
+	v.X = int16(int64(u<<58) >> 58)
+	if u, err = r.ReadBits(6); err != nil {
+		return err
+	}
+	v.Y = int16(int64(u<<58) >> 58)
+	return nil
+}
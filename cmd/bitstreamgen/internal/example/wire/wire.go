@@ -0,0 +1,14 @@
+// Package wire holds types from another package for the structs of package example.
+package wire
+
+// Level is a named integer type.
+type Level uint8
+
+// Header is a struct without generated methods, which bitstreamgen encodes inline.
+type Header struct {
+	Kind   uint8 `bits:"4"`
+	Urgent bool
+	Level  Level   `bits:"3"`
+	Trace  [2]int8 `bits:"4,signed"`
+	note   uint8
+}
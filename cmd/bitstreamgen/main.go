@@ -0,0 +1,363 @@
+// Command bitstreamgen generates EncodeBits and DecodeBits methods for structs annotated
+// with the bits struct tags understood by bitstream.Marshal, so they can be packed
+// without reflection and without allocating.
+//
+// Usage:
+//
+//	//go:generate go run github.com/yyyoichi/bitstream-go/cmd/bitstreamgen -type=Header,Point
+//
+// For every listed type T, the generated file declares
+//
+//	func (v *T) EncodeBits(w bitstream.Writer)
+//	func (v *T) DecodeBits(r bitstream.Reader) error
+//
+// The package is type-checked, so field types are resolved as bitstream.Marshal resolves
+// them: named types count by their underlying type, and fields are laid out exactly as by
+// Marshal. Nested struct fields whose type is declared in the same package call the methods
+// of that type, which must be generated too; structs from other packages and anonymous
+// structs are encoded inline. Unlike Marshal, EncodeBits does not check that values fit in
+// their fields; only the low bits of each value are written.
+// DecodeBits returns the first error from ReadBits unchanged.
+//
+// The output is written to <first type>_bits.go in the package directory unless -output is set.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("bitstreamgen: ")
+	typeNames := flag.String("type", "", "comma-separated list of struct type names; required")
+	output := flag.String("output", "", "output file name; default <dir>/<type>_bits.go")
+	flag.Parse()
+	if *typeNames == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	dir := "."
+	if args := flag.Args(); len(args) > 0 {
+		dir = args[0]
+	}
+	names := strings.Split(*typeNames, ",")
+	if *output == "" {
+		*output = filepath.Join(dir, strings.ToLower(names[0])+"_bits.go")
+	}
+
+	pkg, err := loadDir(dir, *output)
+	if err != nil {
+		log.Fatal(err)
+	}
+	src, err := generate(pkg, names)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*output, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// loadDir parses and type-checks the non-test Go files of dir, skipping output.
+// Type errors are ignored, since the package may use methods from a stale or missing
+// output file; fields whose types cannot be resolved are reported by generate.
+func loadDir(dir, output string) (*types.Package, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") || filepath.Clean(path) == filepath.Clean(output) {
+			continue
+		}
+		f, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no Go files in %s", dir)
+	}
+	conf := types.Config{
+		Importer: importer.ForCompiler(fset, "source", nil),
+		Error:    func(error) {},
+	}
+	pkg, _ := conf.Check(files[0].Name.Name, fset, files, nil)
+	return pkg, nil
+}
+
+// generator emits the methods of the struct types of one package.
+type generator struct {
+	pkg     *types.Package
+	buf     bytes.Buffer
+	imports map[string]string // path to name of the packages named in the output
+	scalar  bool              // a field read with ReadBits was emitted
+}
+
+// field is a struct field that takes part in the encoding.
+type field struct {
+	name   string
+	x      string     // expression of the field
+	typ    types.Type // element type for arrays
+	array  bool
+	bits   int
+	signed bool
+}
+
+// generate returns the formatted source declaring the methods of the named struct types of pkg.
+func generate(pkg *types.Package, names []string) ([]byte, error) {
+	g := &generator{pkg: pkg, imports: map[string]string{}}
+	for _, name := range names {
+		obj, ok := pkg.Scope().Lookup(name).(*types.TypeName)
+		if !ok {
+			return nil, fmt.Errorf("struct type %s not found", name)
+		}
+		st, ok := obj.Type().Underlying().(*types.Struct)
+		if !ok {
+			return nil, fmt.Errorf("struct type %s not found", name)
+		}
+		if err := g.writeEncode(name, st); err != nil {
+			return nil, err
+		}
+		if err := g.writeDecode(name, st); err != nil {
+			return nil, err
+		}
+	}
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by bitstreamgen; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\n", pkg.Name())
+	paths := []string{"github.com/yyyoichi/bitstream-go"}
+	for path := range g.imports {
+		paths = append(paths, path)
+	}
+	slices.Sort(paths)
+	if len(paths) == 1 {
+		fmt.Fprintf(&buf, "import %q\n", paths[0])
+	} else {
+		fmt.Fprintf(&buf, "import (\n")
+		for _, path := range paths {
+			fmt.Fprintf(&buf, "%q\n", path)
+		}
+		fmt.Fprintf(&buf, ")\n")
+	}
+	buf.Write(g.buf.Bytes())
+	return format.Source(buf.Bytes())
+}
+
+// qualifier names the packages of types in the generated code, recording their imports.
+func (g *generator) qualifier(p *types.Package) string {
+	if p == g.pkg {
+		return ""
+	}
+	g.imports[p.Path()] = p.Name()
+	return p.Name()
+}
+
+// structFields returns the encoded fields of st in order, following the rules of
+// bitstream.Marshal. x is the expression of the struct and name its name in errors.
+func structFields(name, x string, st *types.Struct) ([]field, error) {
+	var fields []field
+	for i := range st.NumFields() {
+		f := st.Field(i)
+		tag, tagged := reflect.StructTag(st.Tag(i)).Lookup("bits")
+		if tag == "-" || !f.Exported() {
+			continue
+		}
+		fd := field{name: name + "." + f.Name(), x: x + "." + f.Name(), typ: f.Type()}
+		if at, ok := fd.typ.Underlying().(*types.Array); ok {
+			fd.typ, fd.array = at.Elem(), true
+		}
+		if t, ok := fd.typ.Underlying().(*types.Basic); ok && t.Kind() == types.Invalid {
+			return nil, fmt.Errorf("%s: cannot resolve field type", fd.name)
+		}
+		if !tagged {
+			switch t := fd.typ.Underlying().(type) {
+			case *types.Basic:
+				if t.Kind() != types.Bool {
+					continue
+				}
+				fd.bits = 1
+			case *types.Struct:
+			default:
+				continue
+			}
+			fields = append(fields, fd)
+			continue
+		}
+		if err := parseTag(&fd, tag); err != nil {
+			return nil, fmt.Errorf("%s: %v", fd.name, err)
+		}
+		fields = append(fields, fd)
+	}
+	return fields, nil
+}
+
+// basicBits is the width of each integer kind, as reported by reflect on 64-bit platforms.
+var basicBits = map[types.BasicKind]int{
+	types.Int: 64, types.Int8: 8, types.Int16: 16, types.Int32: 32, types.Int64: 64,
+	types.Uint: 64, types.Uint8: 8, types.Uint16: 16, types.Uint32: 32, types.Uint64: 64,
+	types.Uintptr: 64,
+}
+
+func parseTag(fd *field, tag string) error {
+	size, opts, _ := strings.Cut(tag, ",")
+	bits, err := strconv.Atoi(size)
+	if err != nil || bits < 1 || bits > 64 {
+		return fmt.Errorf("invalid bits tag %q", tag)
+	}
+	fd.bits = bits
+	switch opts {
+	case "":
+	case "signed":
+		fd.signed = true
+	default:
+		return fmt.Errorf("unknown bits tag option %q", opts)
+	}
+	t, ok := fd.typ.Underlying().(*types.Basic)
+	switch {
+	case !ok || t.Kind() != types.Bool && basicBits[t.Kind()] == 0:
+		return fmt.Errorf("unsupported field type %s", fd.typ)
+	case t.Kind() == types.Bool:
+		if bits != 1 || fd.signed {
+			return fmt.Errorf("bool fields take exactly 1 bit")
+		}
+	case bits > basicBits[t.Kind()] || fd.signed && t.Info()&types.IsUnsigned != 0:
+		return fmt.Errorf("invalid bits tag %q for %s", tag, fd.typ)
+	}
+	return nil
+}
+
+// local reports whether t is a struct type declared in the generated package, which
+// gets its own methods; other struct types are encoded inline.
+func (g *generator) local(t types.Type) bool {
+	named, ok := t.(*types.Named)
+	return ok && named.Obj().Pkg() == g.pkg
+}
+
+func (g *generator) writeEncode(name string, st *types.Struct) error {
+	fmt.Fprintf(&g.buf, "\n// EncodeBits writes v to w as bitstream.Marshal would.\n")
+	fmt.Fprintf(&g.buf, "func (v *%s) EncodeBits(w bitstream.Writer) {\n", name)
+	if err := g.encodeStruct(name, "v", st, 0); err != nil {
+		return err
+	}
+	fmt.Fprintf(&g.buf, "}\n")
+	return nil
+}
+
+func (g *generator) encodeStruct(name, x string, st *types.Struct, depth int) error {
+	fields, err := structFields(name, x, st)
+	if err != nil {
+		return err
+	}
+	buf := &g.buf
+	for _, f := range fields {
+		x := f.x
+		if f.array {
+			i := index(depth)
+			fmt.Fprintf(buf, "for %s := range %s {\n", i, x)
+			x += "[" + i + "]"
+		}
+		switch t := f.typ.Underlying().(type) {
+		case *types.Struct:
+			if g.local(f.typ) {
+				fmt.Fprintf(buf, "%s.EncodeBits(w)\n", x)
+			} else if err := g.encodeStruct(f.name, x, t, depth+1); err != nil {
+				return err
+			}
+		case *types.Basic:
+			if t.Kind() == types.Bool {
+				fmt.Fprintf(buf, "if %s {\nw.WriteBits(1, 1)\n} else {\nw.WriteBits(0, 1)\n}\n", x)
+			} else {
+				fmt.Fprintf(buf, "w.WriteBits(uint64(%s), %d)\n", x, f.bits)
+			}
+		}
+		if f.array {
+			fmt.Fprintf(buf, "}\n")
+		}
+	}
+	return nil
+}
+
+func (g *generator) writeDecode(name string, st *types.Struct) error {
+	out := g.buf
+	g.buf, g.scalar = bytes.Buffer{}, false
+	if err := g.decodeStruct(name, "v", st, 0); err != nil {
+		return err
+	}
+	body := g.buf
+	g.buf = out
+	fmt.Fprintf(&g.buf, "\n// DecodeBits reads v from r as bitstream.Unmarshal would.\n")
+	fmt.Fprintf(&g.buf, "func (v *%s) DecodeBits(r bitstream.Reader) error {\n", name)
+	if g.scalar {
+		fmt.Fprintf(&g.buf, "var u uint64\nvar err error\n")
+	}
+	g.buf.Write(body.Bytes())
+	fmt.Fprintf(&g.buf, "return nil\n}\n")
+	return nil
+}
+
+func (g *generator) decodeStruct(name, x string, st *types.Struct, depth int) error {
+	fields, err := structFields(name, x, st)
+	if err != nil {
+		return err
+	}
+	buf := &g.buf
+	for _, f := range fields {
+		x := f.x
+		if f.array {
+			i := index(depth)
+			fmt.Fprintf(buf, "for %s := range %s {\n", i, x)
+			x += "[" + i + "]"
+		}
+		switch t := f.typ.Underlying().(type) {
+		case *types.Struct:
+			if g.local(f.typ) {
+				fmt.Fprintf(buf, "if err := %s.DecodeBits(r); err != nil {\nreturn err\n}\n", x)
+			} else if err := g.decodeStruct(f.name, x, t, depth+1); err != nil {
+				return err
+			}
+		case *types.Basic:
+			g.scalar = true
+			fmt.Fprintf(buf, "if u, err = r.ReadBits(%d); err != nil {\nreturn err\n}\n", f.bits)
+			typ := types.TypeString(f.typ, g.qualifier)
+			switch {
+			case t.Kind() == types.Bool:
+				fmt.Fprintf(buf, "%s = u != 0\n", x)
+			case f.signed && f.bits < 64:
+				fmt.Fprintf(buf, "%s = %s(int64(u<<%d) >> %d)\n", x, typ, 64-f.bits, 64-f.bits)
+			default:
+				fmt.Fprintf(buf, "%s = %s(u)\n", x, typ)
+			}
+		}
+		if f.array {
+			fmt.Fprintf(buf, "}\n")
+		}
+	}
+	return nil
+}
+
+// index returns the name of the loop variable for arrays nested depth structs deep.
+func index(depth int) string {
+	if depth == 0 {
+		return "i"
+	}
+	return "i" + strconv.Itoa(depth)
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	t.Run("Golden", func(t *testing.T) {
+		dir := filepath.Join("internal", "example")
+		out := filepath.Join(dir, "packet_bits.go")
+		pkg, err := loadDir(dir, out)
+		if err != nil {
+			t.Fatalf("loadDir() error = %v", err)
+		}
+		got, err := generate(pkg, []string{"Packet", "Point"})
+		if err != nil {
+			t.Fatalf("generate() error = %v", err)
+		}
+		want, err := os.ReadFile(out)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("generated code differs from %s; run go generate", out)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		tests := map[string]string{
+			"missing":     "type T struct{}",
+			"width":       "type S struct{ A uint8 `bits:\"9\"` }",
+			"option":      "type S struct{ A int8 `bits:\"3,packed\"` }",
+			"signed uint": "type S struct{ A uint8 `bits:\"3,signed\"` }",
+			"type":        "type S struct{ A string `bits:\"8\"` }",
+			"slice":       "type S struct{ A []uint8 `bits:\"8\"` }",
+			"struct":      "type S struct{ A struct{ B uint8 } `bits:\"8\"` }",
+			"unresolved":  "type S struct{ A missing.Header }",
+		}
+		for name, src := range tests {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, "s.go"), []byte("package p\n"+src+"\n"), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			pkg, err := loadDir(dir, filepath.Join(dir, "s_bits.go"))
+			if err != nil {
+				t.Fatalf("%s: loadDir() error = %v", name, err)
+			}
+			if _, err := generate(pkg, []string{"S"}); err == nil {
+				t.Errorf("%s: generate() error = nil; want error", name)
+			} else if name == "missing" && !strings.Contains(err.Error(), "not found") {
+				t.Errorf("%s: generate() error = %v; want not found", name, err)
+			}
+		}
+	})
+}
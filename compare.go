@@ -0,0 +1,34 @@
+package bitstream
+
+import "cmp"
+
+// EqualRange reports whether the n bits of a starting at fromA equal the n bits of b
+// starting at fromB. The readers may use different element types and paddings.
+// It returns false if either range is not within the valid bits. Cursors are not moved.
+func EqualRange[T, U Unsigned](a *BitReader[T], b *BitReader[U], fromA, fromB, n int) bool {
+	if n < 0 || fromA < 0 || fromB < 0 || fromA+n > a.bits || fromB+n > b.bits {
+		return false
+	}
+	for i := 0; i < n; i += 64 {
+		k := min(64, n-i)
+		if a.bitsAt(fromA+i, k) != b.bitsAt(fromB+i, k) {
+			return false
+		}
+	}
+	return true
+}
+
+// Compare compares the valid bits of a and b lexicographically, bit 0 first, and returns
+// -1, 0 or +1. A stream that is a proper prefix of the other compares as less.
+// The readers may use different element types and paddings; use Slice to compare spans.
+// Cursors are not moved.
+func Compare[T, U Unsigned](a *BitReader[T], b *BitReader[U]) int {
+	n := min(a.bits, b.bits)
+	for i := 0; i < n; i += 64 {
+		k := min(64, n-i)
+		if c := cmp.Compare(a.bitsAt(i, k), b.bitsAt(i, k)); c != 0 {
+			return c
+		}
+	}
+	return cmp.Compare(a.bits, b.bits)
+}
@@ -0,0 +1,60 @@
+package bitstream
+
+import "testing"
+
+func TestCompare(t *testing.T) {
+	w8 := NewBitWriter[uint8](1, 2)
+	w64 := NewBitWriter[uint64](0, 5)
+	for i := range 40 {
+		w8.WriteBits(uint64(i*7), 9)
+		w64.WriteBits(uint64(i*7), 9)
+	}
+	a := NewBitReader(w8.Data(), 1, 2)
+	a.SetBits(w8.Bits())
+	b := NewBitReader(w64.Data(), 0, 5)
+	b.SetBits(w64.Bits())
+
+	t.Run("EqualRange", func(t *testing.T) {
+		tests := []struct {
+			fromA, fromB, n int
+			want            bool
+		}{
+			{0, 0, 360, true},
+			{9, 9, 200, true},
+			{0, 9, 9, false},
+			{27, 27, 0, true},
+			{0, 0, 361, false},
+			{-1, 0, 1, false},
+		}
+		for _, tt := range tests {
+			if got := EqualRange(a, b, tt.fromA, tt.fromB, tt.n); got != tt.want {
+				t.Errorf("EqualRange(%d, %d, %d) = %v; want %v", tt.fromA, tt.fromB, tt.n, got, tt.want)
+			}
+		}
+		// 7 = 000000111 and 14 = 000001110 share their first 5 bits
+		if !EqualRange(a, b, 9, 18, 5) || EqualRange(a, b, 9, 18, 6) {
+			t.Error("EqualRange() does not stop at the first differing bit")
+		}
+	})
+
+	t.Run("Compare", func(t *testing.T) {
+		tests := []struct {
+			name string
+			x    *BitReader[uint8]
+			y    *BitReader[uint64]
+			want int
+		}{
+			{"equal", a, b, 0},
+			{"prefix", a.Slice(0, 100), b, -1},
+			{"longer", a, b.Slice(0, 359), 1},
+			{"less", a.Slice(9, 18), b.Slice(18, 27), -1},
+			{"greater", a.Slice(18, 200), b.Slice(9, 300), 1},
+			{"empty", a.Slice(0, 0), b.Slice(5, 5), 0},
+		}
+		for _, tt := range tests {
+			if got := Compare(tt.x, tt.y); got != tt.want {
+				t.Errorf("%s: Compare() = %d; want %d", tt.name, got, tt.want)
+			}
+		}
+	})
+}
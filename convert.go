@@ -0,0 +1,47 @@
+package bitstream
+
+// Convert returns a reader over a copy of the valid bits of r, stored in elements of type U
+// with the given padding. The bit count, SetBits limit and cursor position carry over,
+// so a stream built as []uint64 can be re-viewed as []uint8 and vice versa.
+// The data of r is not modified.
+//
+// Panics if leftPadd + rightPadd >= bit size of U.
+func Convert[T, U Unsigned](r *BitReader[T], leftPadd, rightPadd int) *BitReader[U] {
+	w := NewBitWriter[U](leftPadd, rightPadd)
+	appendBits64(w, r, 0, r.bits)
+	c := NewBitReader(w.data, leftPadd, rightPadd)
+	c.bits = r.bits
+	c.pos = r.pos
+	return c
+}
+
+// ConvertWriter returns a new writer holding a copy of the bits written to w, stored in
+// elements of type U with the given padding. The cursor position carries over, and
+// later writes to either writer do not affect the other.
+//
+// Panics if leftPadd + rightPadd >= bit size of U.
+func ConvertWriter[T, U Unsigned](w *BitWriter[T], leftPadd, rightPadd int) *BitWriter[U] {
+	w.lock()
+	defer w.unlock()
+	c := NewBitWriter[U](leftPadd, rightPadd)
+	r := w.reader()
+	appendBits64(c, &r, 0, w.bits)
+	c.pos = w.pos
+	return c
+}
+
+// appendBits64 appends the bits of r in [from, to) to w, 64 bits at a time.
+// The caller must hold the lock of w.
+func appendBits64[T, U Unsigned](w *BitWriter[U], r *BitReader[T], from, to int) {
+	w.grow(to - from)
+	for pos := from; pos < to; pos += 64 {
+		k := min(64, to-pos)
+		w.writeBits(r.bitsAt(pos, k), k)
+	}
+}
+
+// reader returns a reader over the bits written so far.
+// The caller must hold the lock of w, and the reader must not outlive it.
+func (w *BitWriter[T]) reader() BitReader[T] {
+	return BitReader[T]{data: w.data, bits: w.bits, s: w.s, msb: w.msb, lp: w.lp, rp: w.rp}
+}
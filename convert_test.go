@@ -0,0 +1,58 @@
+package bitstream
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestConvert(t *testing.T) {
+	t.Run("Convert", func(t *testing.T) {
+		src := []uint64{0x0123456789ABCDEF, 0xFEDCBA9876543210}
+		reader := NewBitReader(src, 0, 0)
+		reader.SetBits(100)
+		reader.Seek(12)
+
+		got := Convert[uint64, uint8](reader, 0, 0)
+		if got.Bits() != 100 || got.Pos() != 12 {
+			t.Errorf("Bits(), Pos() = %d, %d; want 100, 12", got.Bits(), got.Pos())
+		}
+		want := []uint8{0x01, 0x23, 0x45, 0x67, 0x89, 0xAB, 0xCD, 0xEF, 0xFE, 0xDC, 0xBA, 0x98, 0x70}
+		if !slices.Equal(got.Data(), want) {
+			t.Errorf("Data() = %x; want %x", got.Data(), want)
+		}
+		if v, _ := got.ReadBits(8); v != 0x34 {
+			t.Errorf("ReadBits(8) = %x; want 34", v)
+		}
+	})
+
+	t.Run("Convert_padding", func(t *testing.T) {
+		reader := NewBitReader([]uint8{0b01010100, 0b00111100, 0b01000000}, 1, 2)
+		got := Convert[uint8, uint16](reader, 4, 0)
+		if want := []uint16{0b0000_101010111110, 0b0000_000000000000}; !slices.Equal(got.Data(), want) {
+			t.Errorf("Data() = %016b; want %016b", got.Data(), want)
+		}
+		back := Convert[uint16, uint8](got, 1, 2)
+		if !slices.Equal(back.Data(), reader.Data()) {
+			t.Errorf("round trip Data() = %08b; want %08b", back.Data(), reader.Data())
+		}
+	})
+
+	t.Run("ConvertWriter", func(t *testing.T) {
+		writer := NewBitWriter[uint8](0, 0)
+		writer.Write([]byte{0xAB, 0xCD, 0xEF})
+		writer.WriteBits(0b1, 1)
+		writer.Seek(5)
+
+		got := ConvertWriter[uint8, uint32](writer, 2, 2)
+		if got.Bits() != 25 || got.Pos() != 5 {
+			t.Errorf("Bits(), Pos() = %d, %d; want 25, 5", got.Bits(), got.Pos())
+		}
+		if got.ToHex() != writer.ToHex() {
+			t.Errorf("ToHex() = %s; want %s", got.ToHex(), writer.ToHex())
+		}
+		got.WriteBits(0xF, 4)
+		if writer.Bits() != 25 {
+			t.Errorf("source Bits() after write = %d; want 25", writer.Bits())
+		}
+	})
+}
@@ -0,0 +1,205 @@
+package bitstream
+
+import (
+	"math"
+	"math/bits"
+)
+
+// CountingBitWriter has the Write methods of BitWriter but only counts the bits they
+// would write, without storing them. Encoders use it to measure candidate encodings
+// cheaply before committing to one, as in rate-distortion decisions.
+// Methods panic on the same arguments as their BitWriter counterparts.
+//
+// The zero value is ready to use. It is not safe for concurrent use.
+type CountingBitWriter struct {
+	bits int
+}
+
+// Bits returns the number of bits counted so far.
+func (c *CountingBitWriter) Bits() int {
+	return c.bits
+}
+
+// Reset sets the count back to 0.
+func (c *CountingBitWriter) Reset() {
+	c.bits = 0
+}
+
+// WriteBits counts bits bits. See BitWriter.WriteBits.
+func (c *CountingBitWriter) WriteBits(data uint64, bits int) {
+	if bits > 64 {
+		panic(&WidthError{Op: "write", Width: bits, Max: 64})
+	}
+	c.bits += max(bits, 0)
+}
+
+// WriteBool counts one bit. See BitWriter.WriteBool.
+func (c *CountingBitWriter) WriteBool(data bool) {
+	c.bits++
+}
+
+// WriteBit counts one bit. It always returns nil. See BitWriter.WriteBit.
+func (c *CountingBitWriter) WriteBit(bit bool) error {
+	c.bits++
+	return nil
+}
+
+// Write implements io.Writer, counting 8 bits per byte of p.
+// It always returns len(p) and a nil error.
+func (c *CountingBitWriter) Write(p []byte) (int, error) {
+	c.bits += 8 * len(p)
+	return len(p), nil
+}
+
+// AlignTo counts the padding bits needed to make Bits() a multiple of k.
+// See BitWriter.AlignTo.
+func (c *CountingBitWriter) AlignTo(k int) {
+	if k <= 0 {
+		panic("bitstream: alignment must be positive")
+	}
+	c.bits += padLen(c.bits, k)
+}
+
+// AlignToByte counts the padding bits needed to make Bits() a multiple of 8.
+func (c *CountingBitWriter) AlignToByte() {
+	c.AlignTo(8)
+}
+
+// WriteUE counts the bits of v as an unsigned exponential-Golomb code. See BitWriter.WriteUE.
+func (c *CountingBitWriter) WriteUE(v uint64) {
+	if v == math.MaxUint64 {
+		panic("bitstream: value out of range for exp-Golomb code")
+	}
+	c.bits += ueLen(v)
+}
+
+// WriteSE counts the bits of v as a signed exponential-Golomb code. See BitWriter.WriteSE.
+func (c *CountingBitWriter) WriteSE(v int64) {
+	if v == math.MinInt64 {
+		panic("bitstream: value out of range for exp-Golomb code")
+	}
+	k := uint64(v)<<1 - 1
+	if v <= 0 {
+		k = uint64(-v) << 1
+	}
+	c.bits += ueLen(k)
+}
+
+// WriteRice counts the bits of v as a Rice code with parameter k. See BitWriter.WriteRice.
+func (c *CountingBitWriter) WriteRice(k int, v uint64) {
+	if k < 0 || k > 64 {
+		panic("bitstream: Rice parameter must be between 0 and 64")
+	}
+	c.bits += riceLen(k, v)
+}
+
+// WriteGolomb counts the bits of v as a Golomb code with parameter m. See BitWriter.WriteGolomb.
+func (c *CountingBitWriter) WriteGolomb(m uint64, v uint64) {
+	if m == 0 {
+		panic("bitstream: Golomb parameter must be positive")
+	}
+	c.bits += golombLen(m, v)
+}
+
+// WriteEliasGamma counts the bits of v as an Elias gamma code. See BitWriter.WriteEliasGamma.
+func (c *CountingBitWriter) WriteEliasGamma(v uint64) {
+	if v == 0 {
+		panic("bitstream: Elias codes require a positive value")
+	}
+	c.bits += gammaLen(v)
+}
+
+// WriteEliasDelta counts the bits of v as an Elias delta code. See BitWriter.WriteEliasDelta.
+func (c *CountingBitWriter) WriteEliasDelta(v uint64) {
+	if v == 0 {
+		panic("bitstream: Elias codes require a positive value")
+	}
+	c.bits += deltaLen(v)
+}
+
+// WriteUvarint counts the byte alignment and the bits of v as an unsigned LEB128 varint.
+// See BitWriter.WriteUvarint.
+func (c *CountingBitWriter) WriteUvarint(v uint64) {
+	c.bits += padLen(c.bits, 8) + uvarintLen(v)
+}
+
+// WriteVarint counts the byte alignment and the bits of v as a signed LEB128 varint.
+// See BitWriter.WriteVarint.
+func (c *CountingBitWriter) WriteVarint(v int64) {
+	c.WriteUvarint(zigzag(v))
+}
+
+// WriteZigZag counts a bits-wide zig-zag code. See BitWriter.WriteZigZag.
+func (c *CountingBitWriter) WriteZigZag(v int64, bits int) {
+	c.WriteBits(0, bits)
+}
+
+// WriteGray counts a bits-wide Gray code. See BitWriter.WriteGray.
+func (c *CountingBitWriter) WriteGray(data uint64, bits int) {
+	c.WriteBits(0, bits)
+}
+
+// WriteFloat32 counts 32 bits.
+func (c *CountingBitWriter) WriteFloat32(v float32) {
+	c.bits += 32
+}
+
+// WriteFloat64 counts 64 bits.
+func (c *CountingBitWriter) WriteFloat64(v float64) {
+	c.bits += 64
+}
+
+// The functions below give the widths of the codes, shared by CountingBitWriter and
+// the BitWriter methods that reserve a whole code before writing it.
+
+// padLen returns the padding bits that take n to a multiple of k.
+func padLen(n, k int) int {
+	return (k - n%k) % k
+}
+
+// unaryLen returns the width of q in unary, saturating far below math.MaxInt so that
+// callers can add the width of a remainder without overflow.
+func unaryLen(q uint64) int {
+	if q >= math.MaxInt/2 {
+		return math.MaxInt / 2
+	}
+	return int(q) + 1
+}
+
+func ueLen(v uint64) int {
+	return 2*bits.Len64(v+1) - 1
+}
+
+func riceLen(k int, v uint64) int {
+	var q uint64
+	if k < 64 {
+		q = v >> k
+	}
+	return unaryLen(q) + k
+}
+
+func golombLen(m, v uint64) int {
+	n := unaryLen(v / m)
+	b := bits.Len64(m - 1)
+	if b == 0 {
+		return n
+	}
+	if v%m < uint64(1)<<b-m {
+		return n + b - 1
+	}
+	return n + b
+}
+
+func gammaLen(v uint64) int {
+	return 2*bits.Len64(v) - 1
+}
+
+func deltaLen(v uint64) int {
+	n := bits.Len64(v)
+	return gammaLen(uint64(n)) + n - 1
+}
+
+// uvarintLen returns the width of v as an LEB128 varint, without alignment.
+func uvarintLen(v uint64) int {
+	return 8 * max(1, (bits.Len64(v)+6)/7)
+}
@@ -0,0 +1,90 @@
+package bitstream
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCountingBitWriter(t *testing.T) {
+	t.Run("MatchesBitWriter", func(t *testing.T) {
+		values := []uint64{0, 1, 2, 3, 7, 8, 127, 128, 1000, 1 << 20, 1<<63 - 1, math.MaxUint64 - 1}
+		var c CountingBitWriter
+		writer := NewBitWriter[uint32](3, 2)
+		check := func(name string) {
+			t.Helper()
+			if c.Bits() != writer.Bits() {
+				t.Errorf("%s: Bits() = %d; want %d", name, c.Bits(), writer.Bits())
+			}
+		}
+		for _, v := range values {
+			c.WriteBits(v, 13)
+			writer.WriteBits(v, 13)
+			check("WriteBits")
+			c.WriteUE(v)
+			writer.WriteUE(v)
+			check("WriteUE")
+			c.WriteSE(int64(v))
+			writer.WriteSE(int64(v))
+			check("WriteSE")
+			c.WriteSE(-int64(v >> 1))
+			writer.WriteSE(-int64(v >> 1))
+			check("WriteSE negative")
+			c.WriteUvarint(v)
+			writer.WriteUvarint(v)
+			check("WriteUvarint")
+			c.WriteVarint(-int64(v))
+			writer.WriteVarint(-int64(v))
+			check("WriteVarint")
+			c.WriteRice(60, v)
+			writer.WriteRice(60, v)
+			check("WriteRice")
+			c.WriteGolomb(3<<60, v)
+			writer.WriteGolomb(3<<60, v)
+			check("WriteGolomb")
+			if v > 0 {
+				c.WriteEliasGamma(v)
+				writer.WriteEliasGamma(v)
+				check("WriteEliasGamma")
+				c.WriteEliasDelta(v)
+				writer.WriteEliasDelta(v)
+				check("WriteEliasDelta")
+			}
+			c.WriteBool(true)
+			writer.WriteBool(true)
+			c.AlignTo(5)
+			writer.AlignTo(5)
+			check("AlignTo")
+		}
+		c.Write([]byte("counting"))
+		writer.Write([]byte("counting"))
+		c.WriteFloat64(1.5)
+		writer.WriteFloat64(1.5)
+		check("Write")
+
+		c.Reset()
+		if c.Bits() != 0 {
+			t.Errorf("Bits() after Reset = %d; want 0", c.Bits())
+		}
+	})
+
+	t.Run("Panics", func(t *testing.T) {
+		tests := map[string]func(c *CountingBitWriter){
+			"WriteBits":       func(c *CountingBitWriter) { c.WriteBits(0, 65) },
+			"WriteUE":         func(c *CountingBitWriter) { c.WriteUE(math.MaxUint64) },
+			"WriteRice":       func(c *CountingBitWriter) { c.WriteRice(65, 0) },
+			"WriteGolomb":     func(c *CountingBitWriter) { c.WriteGolomb(0, 0) },
+			"WriteEliasGamma": func(c *CountingBitWriter) { c.WriteEliasGamma(0) },
+			"AlignTo":         func(c *CountingBitWriter) { c.AlignTo(0) },
+		}
+		for name, fn := range tests {
+			func() {
+				defer func() {
+					if recover() == nil {
+						t.Errorf("%s did not panic", name)
+					}
+				}()
+				fn(&CountingBitWriter{})
+			}()
+		}
+	})
+}
@@ -0,0 +1,68 @@
+package bitstream
+
+import (
+	"hash/crc32"
+	"testing"
+)
+
+func TestCRC(t *testing.T) {
+	check := []byte("123456789")
+
+	t.Run("Check", func(t *testing.T) {
+		tests := []struct {
+			name  string
+			model CRCModel
+			want  uint64
+		}{
+			{"CRC8", CRC8, 0xF4},
+			{"CRC15CAN", CRC15CAN, 0x059E},
+			{"CRC16ARC", CRC16ARC, 0xBB3D},
+			{"CRC16CCITT", CRC16CCITT, 0x29B1},
+			{"CRC24BLE", CRC24BLE, 0xC25A56},
+			{"CRC32", CRC32, 0xCBF43926},
+			{"CRC32C", CRC32C, 0xE3069283},
+			{"CRC32MPEG2", CRC32MPEG2, 0x0376E6E7},
+			{"CRC64XZ", CRCModel{Width: 64, Poly: 0x42F0E1EBA9EA3693, Init: 1<<64 - 1, RefIn: true, RefOut: true, XorOut: 1<<64 - 1}, 0x995DC9BBDF1939FA},
+			{"CRC3GSM", CRCModel{Width: 3, Poly: 0x3, XorOut: 0x7}, 0x4},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				reader := NewBitReader(check, 0, 0)
+				if got := reader.CRC(tt.model, 0, reader.Bits()); got != tt.want {
+					t.Errorf("CRC() = %#x; want %#x", got, tt.want)
+				}
+			})
+		}
+	})
+
+	t.Run("Unaligned", func(t *testing.T) {
+		// The same bytes placed after a 5-bit prefix in a padded writer give the same CRC.
+		writer := NewBitWriter[uint16](1, 2)
+		writer.WriteBits(0b10110, 5)
+		writer.Write(check)
+		writer.WriteBits(0b1, 1)
+		if got := writer.CRC(CRC32, 5, 5+72); got != uint64(crc32.ChecksumIEEE(check)) {
+			t.Errorf("CRC() = %#x; want %#x", got, crc32.ChecksumIEEE(check))
+		}
+	})
+
+	t.Run("Residue", func(t *testing.T) {
+		// Appending the CRC of a non-reflected model without XorOut gives a zero remainder,
+		// including for spans that are not a whole number of bytes.
+		writer := NewBitWriter[uint8](0, 0)
+		writer.WriteBits(0x5A5A5, 19)
+		writer.WriteBits(writer.CRC(CRC15CAN, 0, 19), 15)
+		if got := writer.CRC(CRC15CAN, 0, 34); got != 0 {
+			t.Errorf("CRC() over data and CRC = %#x; want 0", got)
+		}
+	})
+
+	t.Run("Panic", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("expected panic for width 0")
+			}
+		}()
+		NewBitReader(check, 0, 0).CRC(CRCModel{}, 0, 8)
+	})
+}
@@ -0,0 +1,33 @@
+package bitstream
+
+import "unsafe"
+
+// DataAs returns the storage of w reinterpreted as a slice of U, without copying.
+// The result shares memory with w: it reflects the bytes of the elements written so far
+// and is invalidated by any later write that grows the storage.
+//
+// The view follows the host's memory layout, not the bit order of the stream. Viewing
+// []uint64 as []uint8 on a little-endian machine yields the bytes of each word in reverse
+// order; use ConvertWriter when stream-ordered bytes are needed.
+// Padding bits of T are included in the view as they are stored.
+//
+// Panics if the storage size is not a multiple of the size of U, or if the storage
+// is not suitably aligned for U.
+func DataAs[U, T Unsigned](w *BitWriter[T]) []U {
+	w.lock()
+	defer w.unlock()
+	var t T
+	var u U
+	n := len(w.data) * int(unsafe.Sizeof(t))
+	if n == 0 {
+		return []U{}
+	}
+	if n%int(unsafe.Sizeof(u)) != 0 {
+		panic("bitstream: storage size is not a multiple of the target element size")
+	}
+	p := unsafe.Pointer(unsafe.SliceData(w.data))
+	if uintptr(p)%unsafe.Alignof(u) != 0 {
+		panic("bitstream: storage is not aligned for the target element type")
+	}
+	return unsafe.Slice((*U)(p), n/int(unsafe.Sizeof(u)))
+}
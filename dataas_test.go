@@ -0,0 +1,55 @@
+package bitstream
+
+import (
+	"encoding/binary"
+	"slices"
+	"testing"
+)
+
+func TestDataAs(t *testing.T) {
+	t.Run("uint64_as_uint8", func(t *testing.T) {
+		writer := NewBitWriter[uint64](0, 0)
+		writer.WriteBits(0x0123456789ABCDEF, 64)
+		writer.WriteBits(0xFF, 8)
+		got := DataAs[uint8](writer)
+		want := binary.NativeEndian.AppendUint64(nil, 0x0123456789ABCDEF)
+		want = binary.NativeEndian.AppendUint64(want, 0xFF<<56)
+		if !slices.Equal(got, want) {
+			t.Errorf("DataAs[uint8]() = %x; want %x", got, want)
+		}
+		got[0] ^= 0xFF
+		if writer.Data()[0] == 0x0123456789ABCDEF {
+			t.Error("DataAs[uint8]() does not share storage with the writer")
+		}
+	})
+
+	t.Run("uint16_as_uint32", func(t *testing.T) {
+		writer := NewBitWriter[uint16](0, 0)
+		writer.Grow(64)
+		writer.WriteBits(0x1234_5678, 32)
+		got := DataAs[uint32](writer)
+		var want [4]byte
+		binary.NativeEndian.PutUint16(want[:2], 0x1234)
+		binary.NativeEndian.PutUint16(want[2:], 0x5678)
+		if len(got) != 1 || got[0] != binary.NativeEndian.Uint32(want[:]) {
+			t.Errorf("DataAs[uint32]() = %x; want [%x]", got, binary.NativeEndian.Uint32(want[:]))
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		if got := DataAs[uint64](NewBitWriter[uint8](0, 0)); len(got) != 0 {
+			t.Errorf("DataAs[uint64]() = %v; want empty", got)
+		}
+	})
+
+	t.Run("size_panic", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("expected panic for 3 bytes viewed as uint16")
+			}
+		}()
+		writer := NewBitWriter[uint8](0, 0)
+		writer.WriteBits(0, 24)
+		DataAs[uint16](writer)
+	})
+}
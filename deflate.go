@@ -0,0 +1,102 @@
+package bitstream
+
+import (
+	"io"
+	"math/bits"
+)
+
+// DEFLATE (RFC 1951) and formats built on it pack bits least significant bit first within
+// each byte. The helpers below map such a byte stream onto the MSB-first bit order of this
+// package by reversing every byte, after which the RFC's conventions carry over directly:
+// data fields such as BTYPE, HLIT or extra bits are read with ReadBitsLE and written with
+// WriteBitsLE, Huffman codes are read and written most significant bit first with ReadBits
+// and WriteBits (or the huffman subpackage), and stored blocks start after AlignToByte and
+// are copied with ReadBytesLSB and WriteBytesLSB.
+//
+//	r := bitstream.NewBitReaderLSB(compressed)
+//	final, _ := r.ReadBitsLE(1)
+//	btype, _ := r.ReadBitsLE(2)
+//	if btype == 0 {
+//		r.AlignToByte()
+//		n, _ := r.ReadBitsLE(16)
+//		r.Skip(16) // NLEN
+//		stored, _ := r.ReadBytesLSB(int(n))
+//	}
+
+// NewBitReaderLSB creates a reader over bytes whose bits are packed least significant bit
+// first, as in DEFLATE, so that the first bit read is bit 0 of data[0].
+// The bytes are copied, so data can be reused.
+func NewBitReaderLSB(data []byte) *BitReader[uint8] {
+	c := make([]uint8, len(data))
+	for i, b := range data {
+		c[i] = bits.Reverse8(b)
+	}
+	return NewBitReader(c, 0, 0)
+}
+
+// BytesLSB returns the written bits packed least significant bit first into bytes, the
+// inverse of NewBitReaderLSB. A final partial byte is padded with zero bits at the top.
+func (w *BitWriter[T]) BytesLSB() []byte {
+	w.lock()
+	defer w.unlock()
+	r := w.reader()
+	p := make([]byte, (r.bits+7)/8)
+	for i := range p {
+		k := min(8, r.bits-i*8)
+		p[i] = bits.Reverse8(uint8(r.bitsAt(i*8, k) << (8 - k)))
+	}
+	return p
+}
+
+// ReadBytesLSB reads n whole bytes from a byte-aligned position of a reader created by
+// NewBitReaderLSB and returns them as they appeared in the original data, for DEFLATE
+// stored blocks. Returns ErrNotAligned if the cursor is not on a byte boundary, io.EOF if
+// no bits remain, and io.ErrUnexpectedEOF if fewer than n bytes remain; the cursor is not
+// moved on error.
+func (r *BitReader[T]) ReadBytesLSB(n int) ([]byte, error) {
+	if r.pos%8 != 0 {
+		return nil, ErrNotAligned
+	}
+	if n <= 0 {
+		return []byte{}, nil
+	}
+	if r.pos >= r.bits {
+		return nil, io.EOF
+	}
+	if n > (r.bits-r.pos)/8 {
+		return nil, io.ErrUnexpectedEOF
+	}
+	p := make([]byte, n)
+	for i := range p {
+		p[i] = bits.Reverse8(uint8(r.bitsAt(r.pos, 8)))
+		r.pos += 8
+	}
+	return p, nil
+}
+
+// WriteBytesLSB appends p so that BytesLSB reproduces it unchanged when the writer is
+// byte-aligned, for DEFLATE stored blocks. Call AlignToByte first.
+func (w *BitWriter[T]) WriteBytesLSB(p []byte) {
+	w.lock()
+	defer w.unlock()
+	w.grow(len(p) * 8)
+	for _, b := range p {
+		w.writeBits(uint64(bits.Reverse8(b)), 8)
+	}
+}
+
+// ReverseCode reverses the low length bits of a Huffman code, converting between the
+// MSB-first codes of canonical Huffman construction and the LSB-first order in which
+// DEFLATE transmits them, as needed to build lookup tables indexed by raw input bits.
+// Returns 0 for length 0.
+//
+// Panics if length < 0 or length > 64.
+func ReverseCode(code uint64, length int) uint64 {
+	if length < 0 || length > 64 {
+		panic("bitstream: code length must be between 0 and 64")
+	}
+	if length == 0 {
+		return 0
+	}
+	return reverseBits(code, length)
+}
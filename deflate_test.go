@@ -0,0 +1,103 @@
+package bitstream
+
+import (
+	"bytes"
+	"compress/flate"
+	"io"
+	"testing"
+)
+
+func TestDeflate(t *testing.T) {
+	t.Run("StoredBlock", func(t *testing.T) {
+		var buf bytes.Buffer
+		fw, _ := flate.NewWriter(&buf, flate.NoCompression)
+		fw.Write([]byte("hello, deflate"))
+		fw.Close()
+
+		reader := NewBitReaderLSB(buf.Bytes())
+		var got []byte
+		for {
+			final, _ := reader.ReadBitsLE(1)
+			btype, _ := reader.ReadBitsLE(2)
+			if btype == 1 {
+				// compress/flate closes the stream with an empty fixed Huffman block
+				if eob, _ := reader.ReadBits(7); eob != 0 || final != 1 {
+					t.Fatalf("fixed block = %07b (final %d); want empty final block", eob, final)
+				}
+				break
+			}
+			if btype != 0 {
+				t.Fatalf("BTYPE = %d; want 0", btype)
+			}
+			if _, err := reader.ReadBytesLSB(1); err != ErrNotAligned {
+				t.Errorf("ReadBytesLSB() before alignment error = %v; want ErrNotAligned", err)
+			}
+			reader.AlignToByte()
+			n, _ := reader.ReadBitsLE(16)
+			nlen, _ := reader.ReadBitsLE(16)
+			if n^nlen != 0xFFFF {
+				t.Fatalf("LEN = %#x, NLEN = %#x; want complements", n, nlen)
+			}
+			p, err := reader.ReadBytesLSB(int(n))
+			if err != nil {
+				t.Fatalf("ReadBytesLSB(%d) error = %v", n, err)
+			}
+			got = append(got, p...)
+			if final == 1 {
+				break
+			}
+		}
+		if string(got) != "hello, deflate" {
+			t.Errorf("stored data = %q; want %q", got, "hello, deflate")
+		}
+		reader.AlignToByte()
+		if _, err := reader.ReadBytesLSB(1); err != io.EOF {
+			t.Errorf("ReadBytesLSB() at end error = %v; want io.EOF", err)
+		}
+	})
+
+	t.Run("FixedHuffmanBlock", func(t *testing.T) {
+		writer := NewBitWriter[uint8](0, 0)
+		writer.WriteBitsLE(1, 1) // BFINAL
+		writer.WriteBitsLE(1, 2) // BTYPE = fixed Huffman
+		for _, c := range []byte("abc") {
+			// literals 0-143 have 8-bit codes starting at 00110000
+			writer.WriteBits(0x30+uint64(c), 8)
+		}
+		writer.WriteBits(0, 7) // end of block
+		got, err := io.ReadAll(flate.NewReader(bytes.NewReader(writer.BytesLSB())))
+		if err != nil || string(got) != "abc" {
+			t.Errorf("inflate = %q, %v; want \"abc\", nil", got, err)
+		}
+	})
+
+	t.Run("StoredRoundTrip", func(t *testing.T) {
+		writer := NewBitWriter[uint16](0, 0)
+		writer.WriteBitsLE(1, 3)
+		writer.AlignToByte()
+		writer.WriteBitsLE(3, 16)
+		writer.WriteBitsLE(^uint64(3), 16)
+		writer.WriteBytesLSB([]byte{0x01, 0x80, 0xFF})
+		got, err := io.ReadAll(flate.NewReader(bytes.NewReader(writer.BytesLSB())))
+		if err != nil || !bytes.Equal(got, []byte{0x01, 0x80, 0xFF}) {
+			t.Errorf("inflate = %x, %v; want 0180ff, nil", got, err)
+		}
+		reader := NewBitReaderLSB(writer.BytesLSB())
+		reader.Skip(40)
+		if _, err := reader.ReadBytesLSB(4); err != io.ErrUnexpectedEOF || reader.Pos() != 40 {
+			t.Errorf("ReadBytesLSB(4) = %v at %d; want io.ErrUnexpectedEOF at 40", err, reader.Pos())
+		}
+	})
+
+	t.Run("ReverseCode", func(t *testing.T) {
+		if got := ReverseCode(0b110, 3); got != 0b011 {
+			t.Errorf("ReverseCode(110, 3) = %b; want 11", got)
+		}
+		if got := ReverseCode(0b1, 7); got != 0b1000000 {
+			t.Errorf("ReverseCode(1, 7) = %b; want 1000000", got)
+		}
+		if got := ReverseCode(5, 0); got != 0 {
+			t.Errorf("ReverseCode(5, 0) = %d; want 0", got)
+		}
+	})
+}
@@ -0,0 +1,92 @@
+package bitstream
+
+// DeltaWriter writes a sequence of integers as the differences between successive values,
+// zig-zag coded so that small steps in either direction take few bits. It is the usual
+// first stage of time-series compression (timestamps, counters, sensor samples).
+// The first value is stored as its difference from zero.
+//
+//	dw := bitstream.NewDeltaWriter(w, 0)
+//	for _, ts := range timestamps {
+//		dw.Write(ts)
+//	}
+type DeltaWriter[T Unsigned] struct {
+	w     *BitWriter[T]
+	width int
+	prev  int64
+}
+
+// NewDeltaWriter returns a DeltaWriter appending to w. If width is positive each delta takes
+// exactly width bits; if it is 0 each delta is written with WriteVarint, byte-aligned.
+//
+// Panics if width is negative or greater than 64.
+func NewDeltaWriter[T Unsigned](w *BitWriter[T], width int) *DeltaWriter[T] {
+	if width < 0 || width > 64 {
+		panic("bitstream: invalid delta width")
+	}
+	return &DeltaWriter[T]{w: w, width: width}
+}
+
+// Write appends the difference between v and the previous value.
+// Returns ErrOverflow, writing nothing, if the difference does not fit in the fixed width,
+// and the error of the writer, such as ErrBudgetExceeded, if the difference was discarded;
+// the previous value is kept then, so the sequence continues after a Rollback.
+func (d *DeltaWriter[T]) Write(v int64) error {
+	delta := v - d.prev
+	if d.width == 0 {
+		d.w.WriteVarint(delta)
+	} else {
+		if d.width < 64 && (delta < -1<<(d.width-1) || delta >= 1<<(d.width-1)) {
+			return ErrOverflow
+		}
+		d.w.WriteZigZag(delta, d.width)
+	}
+	if err := d.w.Err(); err != nil {
+		return err
+	}
+	d.prev = v
+	return nil
+}
+
+// Reset makes the next value be stored as its difference from zero, starting a new sequence.
+func (d *DeltaWriter[T]) Reset() {
+	d.prev = 0
+}
+
+// DeltaReader reads a sequence written by DeltaWriter with the same width.
+type DeltaReader[T Unsigned] struct {
+	r     *BitReader[T]
+	width int
+	prev  int64
+}
+
+// NewDeltaReader returns a DeltaReader consuming deltas from r at its cursor.
+//
+// Panics if width is negative or greater than 64.
+func NewDeltaReader[T Unsigned](r *BitReader[T], width int) *DeltaReader[T] {
+	if width < 0 || width > 64 {
+		panic("bitstream: invalid delta width")
+	}
+	return &DeltaReader[T]{r: r, width: width}
+}
+
+// Read returns the next value. Errors are reported as for ReadZigZag or ReadVarint;
+// on error the cursor and the previous value are unchanged.
+func (d *DeltaReader[T]) Read() (int64, error) {
+	var delta int64
+	var err error
+	if d.width == 0 {
+		delta, err = d.r.ReadVarint()
+	} else {
+		delta, err = d.r.ReadZigZag(d.width)
+	}
+	if err != nil {
+		return 0, err
+	}
+	d.prev += delta
+	return d.prev, nil
+}
+
+// Reset makes the next value be read as a difference from zero, matching DeltaWriter.Reset.
+func (d *DeltaReader[T]) Reset() {
+	d.prev = 0
+}
@@ -0,0 +1,98 @@
+package bitstream
+
+import (
+	"errors"
+	"io"
+	"math"
+	"testing"
+)
+
+func TestDelta(t *testing.T) {
+	values := []int64{1700000000, 1700000010, 1700000020, 1700000019, 1700000100, 1700000100}
+
+	t.Run("Varint", func(t *testing.T) {
+		writer := NewBitWriter[uint8](0, 0)
+		dw := NewDeltaWriter(writer, 0)
+		for _, v := range values {
+			if err := dw.Write(v); err != nil {
+				t.Fatalf("Write(%d) error = %v; want nil", v, err)
+			}
+		}
+		if got, want := writer.Bits(), 5*8+6*8; got != want {
+			t.Errorf("Bits() = %d; want %d", got, want)
+		}
+		dr := NewDeltaReader(NewBitReader(writer.Data(), 0, 0), 0)
+		for _, want := range values {
+			if got, err := dr.Read(); got != want || err != nil {
+				t.Errorf("Read() = %d, %v; want %d, nil", got, err, want)
+			}
+		}
+		if _, err := dr.Read(); err != io.EOF {
+			t.Errorf("Read() at end error = %v; want io.EOF", err)
+		}
+	})
+
+	t.Run("FixedWidth", func(t *testing.T) {
+		writer := NewBitWriter[uint16](0, 0)
+		dw := NewDeltaWriter(writer, 8)
+		dw.Write(100)
+		dw.Write(90)
+		if err := dw.Write(300); err != ErrOverflow {
+			t.Errorf("Write(300) error = %v; want ErrOverflow", err)
+		}
+		dw.Write(217)
+		dw.Reset()
+		dw.Write(-5)
+		if got := writer.Bits(); got != 32 {
+			t.Errorf("Bits() = %d; want 32", got)
+		}
+		reader := NewBitReader(writer.Data(), 0, 0)
+		reader.SetBits(writer.Bits())
+		dr := NewDeltaReader(reader, 8)
+		for _, want := range []int64{100, 90, 217} {
+			if got, _ := dr.Read(); got != want {
+				t.Errorf("Read() = %d; want %d", got, want)
+			}
+		}
+		dr.Reset()
+		if got, _ := dr.Read(); got != -5 {
+			t.Errorf("Read() after Reset = %d; want -5", got)
+		}
+	})
+
+	t.Run("Budget", func(t *testing.T) {
+		writer := NewBitWriterLimit[uint8](0, 0, 16)
+		dw := NewDeltaWriter(writer, 0)
+		dw.Write(10)
+		mark := writer.Mark()
+		// a delta of 300 takes two bytes, past the limit
+		if err := dw.Write(310); !errors.Is(err, ErrBudgetExceeded) {
+			t.Errorf("Write(310) error = %v; want ErrBudgetExceeded", err)
+		}
+		writer.Rollback(mark)
+		if err := dw.Write(11); err != nil {
+			t.Fatalf("Write(11) after Rollback error = %v; want nil", err)
+		}
+		dr := NewDeltaReader(NewBitReader(writer.Data(), 0, 0), 0)
+		for _, want := range []int64{10, 11} {
+			if got, err := dr.Read(); got != want || err != nil {
+				t.Errorf("Read() = %d, %v; want %d, nil", got, err, want)
+			}
+		}
+	})
+
+	t.Run("Wraparound", func(t *testing.T) {
+		writer := NewBitWriter[uint64](0, 0)
+		dw := NewDeltaWriter(writer, 64)
+		seq := []int64{math.MinInt64, math.MaxInt64, 0, math.MinInt64}
+		for _, v := range seq {
+			dw.Write(v)
+		}
+		dr := NewDeltaReader(NewBitReader(writer.Data(), 0, 0), 64)
+		for _, want := range seq {
+			if got, _ := dr.Read(); got != want {
+				t.Errorf("Read() = %d; want %d", got, want)
+			}
+		}
+	})
+}
@@ -0,0 +1,82 @@
+package bitstream
+
+import (
+	"io"
+	"strconv"
+	"strings"
+)
+
+// String returns the valid bits of the stream as binary digits in groups of 8,
+// e.g. "10101100 11100011". Padding bits are not included and the cursor is not moved.
+func (r *BitReader[T]) String() string {
+	var sb strings.Builder
+	sb.Write(r.appendBits(nil, 0, r.bits, 8))
+	return sb.String()
+}
+
+// Dump writes the valid bits of the stream to w as binary digits in groups of groupBits bits.
+// Each line holds as many whole groups as fit in 64 bits and is prefixed by the bit offset
+// of its first bit, e.g.
+//
+//	 0: 10101100 11100011 ...
+//	64: 00011111
+//
+// Panics if groupBits < 1.
+func (r *BitReader[T]) Dump(w io.Writer, groupBits int) error {
+	if groupBits < 1 {
+		panic("bitstream: group size must be positive")
+	}
+	perLine := max(64/groupBits, 1) * groupBits
+	width := len(strconv.Itoa(max(r.bits-1, 0)))
+	var buf []byte
+	for from := 0; from < r.bits; from += perLine {
+		buf = buf[:0]
+		for n := len(strconv.Itoa(from)); n < width; n++ {
+			buf = append(buf, ' ')
+		}
+		buf = strconv.AppendInt(buf, int64(from), 10)
+		buf = append(buf, ": "...)
+		buf = r.appendBits(buf, from, min(from+perLine, r.bits), groupBits)
+		buf = append(buf, '\n')
+		if _, err := w.Write(buf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// String returns the written bits as binary digits in groups of 8,
+// e.g. "10101100 11100011". Padding bits are not included.
+func (w *BitWriter[T]) String() string {
+	w.lock()
+	defer w.unlock()
+	r := w.reader()
+	return r.String()
+}
+
+// Dump writes the written bits to dst in the format described for BitReader.Dump.
+//
+// Panics if groupBits < 1.
+func (w *BitWriter[T]) Dump(dst io.Writer, groupBits int) error {
+	w.lock()
+	defer w.unlock()
+	r := w.reader()
+	return r.Dump(dst, groupBits)
+}
+
+// appendBits appends the bits in [from, to) to buf as '0' and '1' characters.
+// A space is inserted before every bit whose position is a multiple of groupBits,
+// except the first one appended.
+func (r *BitReader[T]) appendBits(buf []byte, from, to, groupBits int) []byte {
+	for pos := from; pos < to; pos++ {
+		if pos > from && pos%groupBits == 0 {
+			buf = append(buf, ' ')
+		}
+		if r.readBitAt(pos) {
+			buf = append(buf, '1')
+		} else {
+			buf = append(buf, '0')
+		}
+	}
+	return buf
+}
@@ -0,0 +1,51 @@
+package bitstream
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDump(t *testing.T) {
+	t.Run("String", func(t *testing.T) {
+		reader := NewBitReader([]uint16{0b1010110011100011, 0b1100000000000000}, 0, 0)
+		reader.SetBits(18)
+		if got, want := reader.String(), "10101100 11100011 11"; got != want {
+			t.Errorf("String() = %q; want %q", got, want)
+		}
+
+		writer := NewBitWriter[uint8](2, 1)
+		writer.WriteBits(0b10101100111, 11)
+		if got, want := writer.String(), "10101100 111"; got != want {
+			t.Errorf("String() = %q; want %q", got, want)
+		}
+
+		if got := NewBitWriter[uint8](0, 0).String(); got != "" {
+			t.Errorf("String() on empty writer = %q; want \"\"", got)
+		}
+	})
+
+	t.Run("Dump", func(t *testing.T) {
+		writer := NewBitWriter[uint32](0, 0)
+		writer.WriteBits(0xFFFFFFFFFFFFFFFF, 64)
+		writer.WriteBits(0b101, 3)
+		var sb strings.Builder
+		if err := writer.Dump(&sb, 16); err != nil {
+			t.Fatalf("Dump() error = %v", err)
+		}
+		want := " 0: 1111111111111111 1111111111111111 1111111111111111 1111111111111111\n" +
+			"64: 101\n"
+		if sb.String() != want {
+			t.Errorf("Dump() = %q; want %q", sb.String(), want)
+		}
+	})
+
+	t.Run("Dump_odd_group", func(t *testing.T) {
+		reader := NewBitReader([]uint8{0b10110011, 0b10000000}, 1, 0)
+		reader.SetBits(8)
+		var sb strings.Builder
+		reader.Dump(&sb, 3)
+		if want := "0: 011 001 10\n"; sb.String() != want {
+			t.Errorf("Dump() = %q; want %q", sb.String(), want)
+		}
+	})
+}
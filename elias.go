@@ -0,0 +1,94 @@
+package bitstream
+
+import "math/bits"
+
+// ReadEliasGamma reads an Elias gamma code at the current position and advances the cursor.
+// The code is n zero bits followed by the (n+1)-bit binary value, which always starts with a one bit.
+// Decoded values are always positive.
+// Returns io.EOF if no valid bits remain, io.ErrUnexpectedEOF if the code is truncated,
+// and ErrOverflow if the code has more than 63 leading zero bits.
+// The cursor is not moved on error.
+func (r *BitReader[T]) ReadEliasGamma() (uint64, error) {
+	start := r.pos
+	v, err := r.readEliasGamma()
+	if err != nil {
+		return 0, r.rewind(start, err)
+	}
+	return v, nil
+}
+
+// ReadEliasDelta reads an Elias delta code at the current position and advances the cursor.
+// The code is the bit length n of the value as an Elias gamma code,
+// followed by the low n-1 bits of the value.
+// Decoded values are always positive.
+// Errors are reported as for ReadEliasGamma.
+func (r *BitReader[T]) ReadEliasDelta() (uint64, error) {
+	start := r.pos
+	n, err := r.readEliasGamma()
+	if err != nil {
+		return 0, r.rewind(start, err)
+	}
+	if n > 64 {
+		return 0, r.rewind(start, ErrOverflow)
+	}
+	low, err := r.ReadBits(int(n) - 1)
+	if err != nil {
+		return 0, r.rewind(start, err)
+	}
+	return 1<<(n-1) | low, nil
+}
+
+func (r *BitReader[T]) readEliasGamma() (uint64, error) {
+	n, err := r.readUnary()
+	if err != nil {
+		return 0, err
+	}
+	if n > 63 {
+		return 0, ErrOverflow
+	}
+	low, err := r.ReadBits(int(n))
+	if err != nil {
+		return 0, err
+	}
+	return 1<<n | low, nil
+}
+
+// WriteEliasGamma writes v as an Elias gamma code:
+// n zero bits followed by the (n+1)-bit binary value of v.
+//
+// Panics if v is 0, which has no Elias gamma representation.
+func (w *BitWriter[T]) WriteEliasGamma(v uint64) {
+	if v == 0 {
+		panic("bitstream: Elias codes require a positive value")
+	}
+	w.lock()
+	defer w.unlock()
+	if !w.reserveBits(gammaLen(v)) {
+		return
+	}
+	w.writeEliasGamma(v)
+}
+
+// WriteEliasDelta writes v as an Elias delta code:
+// the bit length n of v as an Elias gamma code, followed by the low n-1 bits of v.
+//
+// Panics if v is 0, which has no Elias delta representation.
+func (w *BitWriter[T]) WriteEliasDelta(v uint64) {
+	if v == 0 {
+		panic("bitstream: Elias codes require a positive value")
+	}
+	w.lock()
+	defer w.unlock()
+	if !w.reserveBits(deltaLen(v)) {
+		return
+	}
+	n := bits.Len64(v)
+	w.writeEliasGamma(uint64(n))
+	w.writeBits(v, n-1)
+}
+
+func (w *BitWriter[T]) writeEliasGamma(v uint64) {
+	n := bits.Len64(v)
+	w.writeBits(0, n-1)
+	w.writeBits(v, n)
+}
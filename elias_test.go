@@ -0,0 +1,110 @@
+package bitstream
+
+import (
+	"io"
+	"math"
+	"testing"
+)
+
+func TestElias(t *testing.T) {
+	t.Run("WriteEliasGamma", func(t *testing.T) {
+		tests := []struct {
+			v    uint64
+			code uint64
+			bits int
+		}{
+			{1, 0b1, 1},
+			{2, 0b010, 3},
+			{3, 0b011, 3},
+			{4, 0b00100, 5},
+			{9, 0b0001001, 7},
+		}
+		for _, tt := range tests {
+			writer := NewBitWriter[uint8](0, 0)
+			writer.WriteEliasGamma(tt.v)
+			if writer.Bits() != tt.bits {
+				t.Errorf("WriteEliasGamma(%d) wrote %d bits; want %d", tt.v, writer.Bits(), tt.bits)
+			}
+			reader := NewBitReader(writer.Data(), 0, 0)
+			if got, _ := reader.ReadBits(tt.bits); got != tt.code {
+				t.Errorf("WriteEliasGamma(%d) code = %b; want %b", tt.v, got, tt.code)
+			}
+		}
+	})
+
+	t.Run("WriteEliasDelta", func(t *testing.T) {
+		tests := []struct {
+			v    uint64
+			code uint64
+			bits int
+		}{
+			{1, 0b1, 1},
+			{2, 0b0100, 4},
+			{3, 0b0101, 4},
+			{4, 0b01100, 5},
+			{10, 0b00100010, 8},
+			{17, 0b001010001, 9},
+		}
+		for _, tt := range tests {
+			writer := NewBitWriter[uint8](0, 0)
+			writer.WriteEliasDelta(tt.v)
+			if writer.Bits() != tt.bits {
+				t.Errorf("WriteEliasDelta(%d) wrote %d bits; want %d", tt.v, writer.Bits(), tt.bits)
+			}
+			reader := NewBitReader(writer.Data(), 0, 0)
+			if got, _ := reader.ReadBits(tt.bits); got != tt.code {
+				t.Errorf("WriteEliasDelta(%d) code = %b; want %b", tt.v, got, tt.code)
+			}
+		}
+	})
+
+	t.Run("roundTrip", func(t *testing.T) {
+		values := []uint64{1, 2, 3, 4, 5, 100, 1 << 40, math.MaxUint64}
+		writer := NewBitWriter[uint64](2, 2)
+		for _, v := range values {
+			writer.WriteEliasGamma(v)
+			writer.WriteEliasDelta(v)
+		}
+		reader := NewBitReader(writer.Data(), 2, 2)
+		reader.SetBits(writer.Bits())
+		for _, want := range values {
+			if got, err := reader.ReadEliasGamma(); err != nil || got != want {
+				t.Errorf("ReadEliasGamma() = %d, %v; want %d, nil", got, err, want)
+			}
+			if got, err := reader.ReadEliasDelta(); err != nil || got != want {
+				t.Errorf("ReadEliasDelta() = %d, %v; want %d, nil", got, err, want)
+			}
+		}
+		if _, err := reader.ReadEliasDelta(); err != io.EOF {
+			t.Errorf("ReadEliasDelta() at end should return io.EOF, got %v", err)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		reader := NewBitReader([]uint8{0b00100000}, 0, 0)
+		reader.SetBits(4)
+		if _, err := reader.ReadEliasGamma(); err != io.ErrUnexpectedEOF {
+			t.Errorf("ReadEliasGamma() on truncated code should return io.ErrUnexpectedEOF, got %v", err)
+		}
+		if reader.Pos() != 0 {
+			t.Errorf("Pos() after failed ReadEliasGamma() = %d; want 0", reader.Pos())
+		}
+		// delta length 65 (gamma 0000001000001)
+		writer := NewBitWriter[uint8](0, 0)
+		writer.WriteEliasGamma(65)
+		writer.WriteBits(0, 64)
+		reader = NewBitReader(writer.Data(), 0, 0)
+		if _, err := reader.ReadEliasDelta(); err != ErrOverflow {
+			t.Errorf("ReadEliasDelta() with length 65 should return ErrOverflow, got %v", err)
+		}
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("expected panic for zero value")
+			}
+		}()
+		NewBitWriter[uint8](0, 0).WriteEliasGamma(0)
+	})
+}
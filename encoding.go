@@ -0,0 +1,210 @@
+package bitstream
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+	"unsafe"
+)
+
+// ToHex returns the written bits as a lowercase hexadecimal string, MSB first, ignoring padding.
+// One digit is produced per started group of 4 bits; the last digit is padded with zero bits.
+func (w *BitWriter[T]) ToHex() string {
+	w.lock()
+	defer w.unlock()
+	return hex.EncodeToString(w.bytes())[:(w.bits+3)/4]
+}
+
+// ToBase64 returns the written bits packed MSB-first into bytes, ignoring padding,
+// encoded with standard padded base64. The last byte is padded with zero bits.
+func (w *BitWriter[T]) ToBase64() string {
+	w.lock()
+	defer w.unlock()
+	return base64.StdEncoding.EncodeToString(w.bytes())
+}
+
+// FromHex returns a BitReader over the bits of the hexadecimal string s.
+// Each digit contributes 4 bits, so an odd number of digits is allowed and Bits()
+// reports 4*len(s). Use SetBits to drop trailing padding bits written by ToHex.
+func FromHex(s string) (*BitReader[uint8], error) {
+	src := s
+	if len(src)%2 != 0 {
+		src += "0"
+	}
+	data, err := hex.DecodeString(src)
+	if err != nil {
+		return nil, err
+	}
+	r := NewBitReader(data, 0, 0)
+	r.bits = 4 * len(s)
+	return r, nil
+}
+
+// FromBase64 returns a BitReader over the bytes of the standard base64 string s.
+// Use SetBits to drop trailing padding bits written by ToBase64.
+func FromBase64(s string) (*BitReader[uint8], error) {
+	data, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	return NewBitReader(data, 0, 0), nil
+}
+
+// ExtractBytes returns the nBits valid bits starting at fromBit packed MSB-first into a new
+// byte slice, ignoring padding; the last byte is padded with zero bits. It does not move the cursor.
+// This hands a sub-field, such as a checksummed region, to APIs that take []byte.
+//
+// Panics if the range is not within the valid bits.
+func (r *BitReader[T]) ExtractBytes(fromBit, nBits int) []byte {
+	if nBits < 0 {
+		panic("bitstream: slice bounds out of range")
+	}
+	return r.Slice(fromBit, fromBit+nBits).bytes()
+}
+
+// ExtractBytes is like BitReader.ExtractBytes over the bits written so far.
+//
+// Panics if the range is not within the written bits.
+func (w *BitWriter[T]) ExtractBytes(fromBit, nBits int) []byte {
+	w.lock()
+	defer w.unlock()
+	r := w.reader()
+	return r.ExtractBytes(fromBit, nBits)
+}
+
+// bytes returns the written bits packed MSB-first into bytes, ignoring padding.
+// The last byte is padded with zero bits.
+func (w *BitWriter[T]) bytes() []byte {
+	r := w.reader()
+	return r.bytes()
+}
+
+// bytes returns all valid bits packed MSB-first into bytes, regardless of the cursor.
+// The last byte is padded with zero bits.
+func (r *BitReader[T]) bytes() []byte {
+	c := *r
+	c.pos = 0
+	buf := make([]byte, (r.bits+7)/8)
+	c.Read(buf)
+	return buf
+}
+
+// jsonBits is the JSON form of a bit stream.
+type jsonBits struct {
+	Bits int    `json:"bits"`
+	Data []byte `json:"data"`
+}
+
+// MarshalJSON implements json.Marshaler. The stream is encoded as
+// {"bits": N, "data": "..."}, where data holds the N valid bits packed MSB-first
+// into bytes as standard base64, ignoring padding. The cursor is not encoded.
+func (r *BitReader[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonBits{Bits: r.bits, Data: r.bytes()})
+}
+
+// UnmarshalJSON implements json.Unmarshaler, restoring a stream encoded by MarshalJSON.
+// The padding of r is kept, so decoding into a reader created by NewBitReader(nil, leftPadd, rightPadd)
+// lays the bits out with that padding; a zero BitReader uses none. The cursor is moved back to 0.
+// Returns ErrInvalidFormat if bits does not match the length of data.
+func (r *BitReader[T]) UnmarshalJSON(b []byte) error {
+	var v jsonBits
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	if v.Bits < 0 || (v.Bits+7)/8 != len(v.Data) {
+		return ErrInvalidFormat
+	}
+	w := NewBitWriter[T](r.lp, r.rp)
+	w.Write(v.Data)
+	r.Reset(w.data[:(v.Bits+w.s-1)/w.s], r.lp, r.rp)
+	r.bits = v.Bits
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler using the format of BitReader.MarshalJSON,
+// so the output can be decoded into a BitReader.
+func (w *BitWriter[T]) MarshalJSON() ([]byte, error) {
+	w.lock()
+	defer w.unlock()
+	return json.Marshal(jsonBits{Bits: w.bits, Data: w.bytes()})
+}
+
+// binaryVersion is the first byte of the MarshalBinary encoding.
+const binaryVersion = 1
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The encoding is a version byte, the element size in bytes, the left and right padding,
+// the bit count as a uvarint, and then every element holding written bits in big-endian order.
+// The cursor position is not encoded.
+func (w *BitWriter[T]) MarshalBinary() ([]byte, error) {
+	w.lock()
+	defer w.unlock()
+	size := w.size() / 8
+	n := (w.bits + w.s - 1) / w.s
+	buf := make([]byte, 0, 4+binary.MaxVarintLen64+n*size)
+	buf = append(buf, binaryVersion, byte(size), byte(w.lp), byte(w.rp))
+	buf = binary.AppendUvarint(buf, uint64(w.bits))
+	for _, v := range w.data[:n] {
+		for i := size - 1; i >= 0; i-- {
+			buf = append(buf, byte(uint64(v)>>(8*i)))
+		}
+	}
+	return buf, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+// It replaces the contents and the padding of w with those encoded by MarshalBinary,
+// and moves the cursor back to 0. data is copied, so it may be reused afterwards.
+// Returns ErrInvalidFormat if data is malformed or its element size differs from T.
+func (w *BitWriter[T]) UnmarshalBinary(data []byte) error {
+	if w.mu == nil && w.s == 0 {
+		// Zero BitWriter, e.g. a field filled in by gob: make it safe like NewBitWriter.
+		w.mu = &sync.Mutex{}
+	}
+	w.lock()
+	defer w.unlock()
+	bitSize := w.size()
+	size := bitSize / 8
+	if len(data) < 4 || data[0] != binaryVersion || int(data[1]) != size {
+		return ErrInvalidFormat
+	}
+	lp, rp := int(data[2]), int(data[3])
+	if lp+rp >= bitSize {
+		return ErrInvalidFormat
+	}
+	s := bitSize - lp - rp
+	nbits, k := binary.Uvarint(data[4:])
+	if k <= 0 || nbits > uint64(len(data)*8) {
+		return ErrInvalidFormat
+	}
+	n := (int(nbits) + s - 1) / s
+	body := data[4+k:]
+	if len(body) != n*size {
+		return ErrInvalidFormat
+	}
+	elems := make([]T, n)
+	for i := range elems {
+		var v uint64
+		for _, b := range body[i*size : (i+1)*size] {
+			v = v<<8 | uint64(b)
+		}
+		elems[i] = T(v)
+	}
+	w.data = elems
+	w.bits = int(nbits)
+	w.s = s
+	w.msb = T(1) << (bitSize - lp - 1)
+	w.lp = lp
+	w.rp = rp
+	w.pos = 0
+	w.err = nil
+	return nil
+}
+
+// size returns the element size of T in bits.
+func (w *BitWriter[T]) size() int {
+	var zero T
+	return int(unsafe.Sizeof(zero)) * 8
+}
@@ -0,0 +1,253 @@
+package bitstream
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestEncoding(t *testing.T) {
+	t.Run("ToHex", func(t *testing.T) {
+		writer := NewBitWriter[uint16](3, 2)
+		writer.WriteBits(0xABCDE, 20)
+		writer.WriteBits(0b11, 2)
+		if got, want := writer.ToHex(), "abcdec"; got != want {
+			t.Errorf("ToHex() = %q; want %q", got, want)
+		}
+		writer.WriteBits(0b11, 2)
+		if got, want := writer.ToHex(), "abcdef"; got != want {
+			t.Errorf("ToHex() = %q; want %q", got, want)
+		}
+		if got := NewBitWriter[uint8](0, 0).ToHex(); got != "" {
+			t.Errorf("ToHex() on empty writer = %q; want \"\"", got)
+		}
+	})
+
+	t.Run("ToBase64", func(t *testing.T) {
+		writer := NewBitWriter[uint32](1, 1)
+		writer.Write([]byte("bit"))
+		writer.WriteBits(0b1, 1)
+		if got, want := writer.ToBase64(), "Yml0gA=="; got != want {
+			t.Errorf("ToBase64() = %q; want %q", got, want)
+		}
+	})
+
+	t.Run("FromHex", func(t *testing.T) {
+		reader, err := FromHex("abcde")
+		if err != nil {
+			t.Fatalf("FromHex() error = %v", err)
+		}
+		if reader.Bits() != 20 {
+			t.Errorf("Bits() = %d; want 20", reader.Bits())
+		}
+		if v, _ := reader.ReadBits(20); v != 0xABCDE {
+			t.Errorf("ReadBits(20) = %x; want abcde", v)
+		}
+		if _, err := FromHex("xy"); err == nil {
+			t.Error("FromHex(\"xy\") error = nil; want error")
+		}
+	})
+
+	t.Run("FromBase64", func(t *testing.T) {
+		writer := NewBitWriter[uint64](5, 7)
+		for i := range 30 {
+			writer.WriteBits(uint64(i), 5)
+		}
+		reader, err := FromBase64(writer.ToBase64())
+		if err != nil {
+			t.Fatalf("FromBase64() error = %v", err)
+		}
+		reader.SetBits(writer.Bits())
+		for i := range 30 {
+			if v, _ := reader.ReadBits(5); v != uint64(i) {
+				t.Errorf("ReadBits(5) = %d; want %d", v, i)
+			}
+		}
+		if _, err := FromBase64("!"); err == nil {
+			t.Error("FromBase64(\"!\") error = nil; want error")
+		}
+	})
+}
+
+func TestBinaryMarshal(t *testing.T) {
+	t.Run("RoundTrip", func(t *testing.T) {
+		writer := NewBitWriter[uint16](3, 2)
+		for i := range 20 {
+			writer.WriteBits(uint64(i), 7)
+		}
+		b, err := writer.MarshalBinary()
+		if err != nil {
+			t.Fatalf("MarshalBinary() error = %v", err)
+		}
+		if b[0] != 1 || b[1] != 2 || b[2] != 3 || b[3] != 2 || b[4] != 140 || b[5] != 1 {
+			t.Errorf("header = %v; want [1 2 3 2 140 1]", b[:6])
+		}
+		if len(b) != 6+13*2 {
+			t.Errorf("len(MarshalBinary()) = %d; want %d", len(b), 6+13*2)
+		}
+
+		var got BitWriter[uint16]
+		if err := got.UnmarshalBinary(b); err != nil {
+			t.Fatalf("UnmarshalBinary() error = %v", err)
+		}
+		if got.Bits() != writer.Bits() {
+			t.Errorf("Bits() = %d; want %d", got.Bits(), writer.Bits())
+		}
+		if !slices.Equal(got.Data(), writer.Data()) {
+			t.Errorf("Data() = %v; want %v", got.Data(), writer.Data())
+		}
+		got.WriteBits(0b1111111, 7)
+		reader := NewBitReader(got.Data(), 3, 2)
+		reader.Skip(140)
+		if v, _ := reader.ReadBits(7); v != 0b1111111 {
+			t.Errorf("ReadBits(7) after append = %b; want 1111111", v)
+		}
+	})
+
+	t.Run("Gob", func(t *testing.T) {
+		writer := NewBitWriter[uint64](0, 4)
+		writer.WriteBits(0xDEADBEEF, 32)
+		var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode(writer); err != nil {
+			t.Fatalf("Encode() error = %v", err)
+		}
+		got := NewBitWriter[uint64](0, 0)
+		if err := gob.NewDecoder(&buf).Decode(got); err != nil {
+			t.Fatalf("Decode() error = %v", err)
+		}
+		if got.ToHex() != "deadbeef" {
+			t.Errorf("ToHex() = %q; want \"deadbeef\"", got.ToHex())
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		writer := NewBitWriter[uint8](1, 0)
+		writer.WriteBits(0b101, 3)
+		valid, _ := writer.MarshalBinary()
+		tests := map[string][]byte{
+			"empty":        {},
+			"version":      {2, 1, 1, 0, 3, 0b1010000},
+			"element size": {1, 2, 1, 0, 3, 0, 0b1010000},
+			"padding":      {1, 1, 4, 4, 3, 0b1010000},
+			"truncated":    valid[:len(valid)-1],
+			"trailing":     append(slices.Clone(valid), 0),
+		}
+		for name, data := range tests {
+			var got BitWriter[uint8]
+			if err := got.UnmarshalBinary(data); err != ErrInvalidFormat {
+				t.Errorf("%s: UnmarshalBinary() error = %v; want ErrInvalidFormat", name, err)
+			}
+		}
+	})
+}
+
+func TestJSON(t *testing.T) {
+	t.Run("Marshal", func(t *testing.T) {
+		writer := NewBitWriter[uint16](2, 2)
+		writer.Write([]byte("bit"))
+		writer.WriteBits(0b1, 1)
+		b, err := json.Marshal(writer)
+		if err != nil {
+			t.Fatalf("Marshal() error = %v", err)
+		}
+		if want := `{"bits":25,"data":"Yml0gA=="}`; string(b) != want {
+			t.Errorf("Marshal() = %s; want %s", b, want)
+		}
+
+		reader := NewBitReader(writer.Data(), 2, 2)
+		reader.SetBits(writer.Bits())
+		reader.Skip(3)
+		b, _ = json.Marshal(reader)
+		if want := `{"bits":25,"data":"Yml0gA=="}`; string(b) != want {
+			t.Errorf("Marshal(reader) = %s; want %s", b, want)
+		}
+	})
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		writer := NewBitWriter[uint32](0, 0)
+		for i := range 25 {
+			writer.WriteBits(uint64(i), 5)
+		}
+		b, _ := json.Marshal(struct {
+			Payload *BitWriter[uint32] `json:"payload"`
+		}{writer})
+
+		var v struct {
+			Payload *BitReader[uint8] `json:"payload"`
+		}
+		if err := json.Unmarshal(b, &v); err != nil {
+			t.Fatalf("Unmarshal() error = %v", err)
+		}
+		if v.Payload.Bits() != 125 {
+			t.Errorf("Bits() = %d; want 125", v.Payload.Bits())
+		}
+		for i := range 25 {
+			if got, _ := v.Payload.ReadBits(5); got != uint64(i) {
+				t.Errorf("ReadBits(5) = %d; want %d", got, i)
+			}
+		}
+	})
+
+	t.Run("Padding", func(t *testing.T) {
+		reader := NewBitReader[uint8](nil, 1, 2)
+		if err := json.Unmarshal([]byte(`{"bits":12,"data":"q8A="}`), reader); err != nil {
+			t.Fatalf("Unmarshal() error = %v", err)
+		}
+		if want := []uint8{0b01010100, 0b00111100, 0b00000000}; !slices.Equal(reader.Data(), want) {
+			t.Errorf("Data() = %08b; want %08b", reader.Data(), want)
+		}
+		if v, _ := reader.ReadBits(12); v != 0xABC {
+			t.Errorf("ReadBits(12) = %x; want abc", v)
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		var reader BitReader[uint8]
+		if err := json.Unmarshal([]byte(`{"bits":17,"data":"q8A="}`), &reader); err != ErrInvalidFormat {
+			t.Errorf("Unmarshal() error = %v; want ErrInvalidFormat", err)
+		}
+		if err := json.Unmarshal([]byte(`{"bits":"x"}`), &reader); err == nil {
+			t.Error("Unmarshal() error = nil; want error")
+		}
+	})
+}
+
+func TestExtractBytes(t *testing.T) {
+	writer := NewBitWriter[uint32](2, 3)
+	writer.WriteBits(0b101, 3)
+	writer.Write([]byte{0xDE, 0xAD, 0xBE, 0xEF})
+	writer.WriteBits(0b11, 2)
+	reader := NewBitReader(writer.Data(), 2, 3)
+	reader.SetBits(writer.Bits())
+
+	tests := []struct {
+		from, n int
+		want    []byte
+	}{
+		{3, 32, []byte{0xDE, 0xAD, 0xBE, 0xEF}},
+		{3, 12, []byte{0xDE, 0xA0}},
+		{0, 11, []byte{0b10111011, 0b11000000}},
+		{35, 2, []byte{0b11000000}},
+		{10, 0, []byte{}},
+	}
+	for _, tt := range tests {
+		if got := reader.ExtractBytes(tt.from, tt.n); !slices.Equal(got, tt.want) {
+			t.Errorf("ExtractBytes(%d, %d) = %08b; want %08b", tt.from, tt.n, got, tt.want)
+		}
+		if got := writer.ExtractBytes(tt.from, tt.n); !slices.Equal(got, tt.want) {
+			t.Errorf("writer ExtractBytes(%d, %d) = %08b; want %08b", tt.from, tt.n, got, tt.want)
+		}
+	}
+	if reader.Pos() != 0 {
+		t.Errorf("Pos() = %d; want 0", reader.Pos())
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected panic for range past the end")
+		}
+	}()
+	reader.ExtractBytes(30, 8)
+}
@@ -0,0 +1,56 @@
+package bitstream
+
+import "math/bits"
+
+// AllowUnaligned controls whether the ReadUintNLE/BE methods accept a cursor that is not
+// on a byte boundary. By default they return ErrNotAligned in that case; when allowed,
+// the bytes are taken from the next 8, 16, 32 or 64 bits wherever the cursor is.
+// Reset restores the default.
+func (r *BitReader[T]) AllowUnaligned(allow bool) {
+	r.unaligned = allow
+}
+
+// ReadUint16BE reads a big-endian 16-bit integer at the current position and advances the cursor.
+// Returns ErrNotAligned if the cursor is not byte-aligned (see AllowUnaligned),
+// and otherwise errors as for ReadBits. The cursor is not moved on error.
+func (r *BitReader[T]) ReadUint16BE() (uint16, error) {
+	v, err := r.readUint(16)
+	return uint16(v), err
+}
+
+// ReadUint16LE reads a little-endian 16-bit integer, as described for ReadUint16BE.
+func (r *BitReader[T]) ReadUint16LE() (uint16, error) {
+	v, err := r.readUint(16)
+	return bits.ReverseBytes16(uint16(v)), err
+}
+
+// ReadUint32BE reads a big-endian 32-bit integer, as described for ReadUint16BE.
+func (r *BitReader[T]) ReadUint32BE() (uint32, error) {
+	v, err := r.readUint(32)
+	return uint32(v), err
+}
+
+// ReadUint32LE reads a little-endian 32-bit integer, as described for ReadUint16BE.
+func (r *BitReader[T]) ReadUint32LE() (uint32, error) {
+	v, err := r.readUint(32)
+	return bits.ReverseBytes32(uint32(v)), err
+}
+
+// ReadUint64BE reads a big-endian 64-bit integer, as described for ReadUint16BE.
+func (r *BitReader[T]) ReadUint64BE() (uint64, error) {
+	return r.readUint(64)
+}
+
+// ReadUint64LE reads a little-endian 64-bit integer, as described for ReadUint16BE.
+func (r *BitReader[T]) ReadUint64LE() (uint64, error) {
+	v, err := r.readUint(64)
+	return bits.ReverseBytes64(v), err
+}
+
+// readUint reads n bits as a big-endian integer after checking alignment.
+func (r *BitReader[T]) readUint(n int) (uint64, error) {
+	if !r.unaligned && r.pos%8 != 0 {
+		return 0, ErrNotAligned
+	}
+	return r.ReadBits(n)
+}
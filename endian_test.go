@@ -0,0 +1,66 @@
+package bitstream
+
+import (
+	"io"
+	"testing"
+)
+
+func TestEndian(t *testing.T) {
+	data := []uint8{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0A, 0x0B, 0x0C, 0x0D, 0x0E, 0x0F, 0x10}
+
+	t.Run("Aligned", func(t *testing.T) {
+		reader := NewBitReader(data, 0, 0)
+		if v, err := reader.ReadUint16BE(); v != 0x0102 || err != nil {
+			t.Errorf("ReadUint16BE() = %#x, %v; want 0x102, nil", v, err)
+		}
+		if v, err := reader.ReadUint16LE(); v != 0x0403 || err != nil {
+			t.Errorf("ReadUint16LE() = %#x, %v; want 0x403, nil", v, err)
+		}
+		if v, err := reader.ReadUint32BE(); v != 0x05060708 || err != nil {
+			t.Errorf("ReadUint32BE() = %#x, %v; want 0x5060708, nil", v, err)
+		}
+		if v, err := reader.ReadUint32LE(); v != 0x0C0B0A09 || err != nil {
+			t.Errorf("ReadUint32LE() = %#x, %v; want 0xc0b0a09, nil", v, err)
+		}
+		reader.Seek(0)
+		if v, err := reader.ReadUint64BE(); v != 0x0102030405060708 || err != nil {
+			t.Errorf("ReadUint64BE() = %#x, %v; want 0x102030405060708, nil", v, err)
+		}
+		if v, err := reader.ReadUint64LE(); v != 0x100F0E0D0C0B0A09 || err != nil {
+			t.Errorf("ReadUint64LE() = %#x, %v; want 0x100f0e0d0c0b0a09, nil", v, err)
+		}
+		if _, err := reader.ReadUint16LE(); err != io.EOF {
+			t.Errorf("ReadUint16LE() at end error = %v; want io.EOF", err)
+		}
+	})
+
+	t.Run("Unaligned", func(t *testing.T) {
+		reader := NewBitReader(data, 0, 0)
+		reader.Skip(4)
+		if _, err := reader.ReadUint32LE(); err != ErrNotAligned || reader.Pos() != 4 {
+			t.Errorf("ReadUint32LE() = %v at %d; want ErrNotAligned at 4", err, reader.Pos())
+		}
+		reader.AllowUnaligned(true)
+		if v, err := reader.ReadUint16LE(); v != 0x2010 || err != nil {
+			t.Errorf("ReadUint16LE() = %#x, %v; want 0x2010, nil", v, err)
+		}
+		reader.Reset(data, 0, 0)
+		reader.Skip(1)
+		if _, err := reader.ReadUint16BE(); err != ErrNotAligned {
+			t.Errorf("ReadUint16BE() after Reset error = %v; want ErrNotAligned", err)
+		}
+	})
+
+	t.Run("Padded", func(t *testing.T) {
+		writer := NewBitWriter[uint16](2, 1)
+		writer.WriteBits(0x1234, 16)
+		writer.WriteBits(0xBEEF, 16)
+		reader := NewBitReader(writer.Data(), 2, 1)
+		if v, _ := reader.ReadUint16BE(); v != 0x1234 {
+			t.Errorf("ReadUint16BE() = %#x; want 0x1234", v)
+		}
+		if v, _ := reader.ReadUint16LE(); v != 0xEFBE {
+			t.Errorf("ReadUint16LE() = %#x; want 0xefbe", v)
+		}
+	})
+}
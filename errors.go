@@ -0,0 +1,75 @@
+package bitstream
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// RangeError reports a read that ran past the valid bits, with where it happened.
+// Readers return it in place of io.EOF and io.ErrUnexpectedEOF after SetDetailedErrors(true);
+// it wraps the sentinel, so errors.Is(err, io.EOF) keeps working.
+type RangeError struct {
+	Pos  int   // Bit position the read started at
+	Want int   // Number of bits requested
+	Have int   // Number of valid bits remaining at Pos
+	Err  error // io.EOF or io.ErrUnexpectedEOF
+}
+
+func (e *RangeError) Error() string {
+	return fmt.Sprintf("bitstream: reading %d bits at bit %d: %d bits remain: %v", e.Want, e.Pos, e.Have, e.Err)
+}
+
+func (e *RangeError) Unwrap() error { return e.Err }
+
+// WidthError reports a field width larger than a method supports. The Read and Write
+// methods panic with a *WidthError, which Try returns as an error wrapping ErrInvalidArgument.
+type WidthError struct {
+	Op    string // "read" or "write"
+	Width int    // Width requested
+	Max   int    // Largest width supported
+}
+
+func (e *WidthError) Error() string {
+	if e.Op == "write" {
+		return fmt.Sprintf("bitstream: cannot write %d bits from uint64; max %d", e.Width, e.Max)
+	}
+	return fmt.Sprintf("bitstream: cannot read %d bits into uint64; max %d", e.Width, e.Max)
+}
+
+func (e *WidthError) Unwrap() error { return ErrInvalidArgument }
+
+// SetDetailedErrors makes ReadBit, ReadBits, PeekBits, ReadBitAt and ReadBitsAt, and the
+// methods that pass on their errors, return a *RangeError giving the position of a failed
+// read instead of plain io.EOF or io.ErrUnexpectedEOF. It is off by default because callers
+// comparing err == io.EOF must switch to errors.Is. Read keeps returning plain io.EOF, as
+// io.Reader requires. Readers returned by Slice and Clone inherit the setting.
+func (r *BitReader[T]) SetDetailedErrors(on bool) {
+	r.detailed = on
+}
+
+// eof returns the error for a read of want bits at pos that does not fit in the valid bits.
+func (r *BitReader[T]) eof(pos, want int) error {
+	err := io.ErrUnexpectedEOF
+	if pos >= r.bits {
+		err = io.EOF
+	}
+	if !r.detailed {
+		return err
+	}
+	return &RangeError{Pos: pos, Want: want, Have: max(r.bits-pos, 0), Err: err}
+}
+
+// eofAsUnexpected reports io.EOF in the middle of a structure as io.ErrUnexpectedEOF,
+// keeping the position of a *RangeError.
+func eofAsUnexpected(err error) error {
+	if !errors.Is(err, io.EOF) {
+		return err
+	}
+	if re, ok := err.(*RangeError); ok {
+		c := *re
+		c.Err = io.ErrUnexpectedEOF
+		return &c
+	}
+	return io.ErrUnexpectedEOF
+}
@@ -0,0 +1,86 @@
+package bitstream
+
+import (
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestRangeError(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		reader := NewBitReader([]uint8{0xFF}, 0, 0)
+		reader.Skip(4)
+		if _, err := reader.ReadBits(8); err != io.ErrUnexpectedEOF {
+			t.Errorf("ReadBits(8) = %v; want io.ErrUnexpectedEOF", err)
+		}
+	})
+
+	t.Run("Detailed", func(t *testing.T) {
+		reader := NewBitReader([]uint16{0xABCD}, 2, 2)
+		reader.SetDetailedErrors(true)
+		reader.Skip(5)
+		_, err := reader.ReadBits(8)
+		var re *RangeError
+		if !errors.As(err, &re) || *re != (RangeError{Pos: 5, Want: 8, Have: 7, Err: io.ErrUnexpectedEOF}) {
+			t.Fatalf("ReadBits(8) = %v; want RangeError at 5 with 7 bits left", err)
+		}
+		if !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("errors.Is(%v, io.ErrUnexpectedEOF) = false; want true", err)
+		}
+		if got, want := err.Error(), "bitstream: reading 8 bits at bit 5: 7 bits remain: unexpected EOF"; got != want {
+			t.Errorf("Error() = %q; want %q", got, want)
+		}
+		reader.Skip(7)
+		if _, err := reader.ReadBit(); !errors.Is(err, io.EOF) || !errors.As(err, &re) || re.Pos != 12 || re.Have != 0 {
+			t.Errorf("ReadBit() at the end = %v; want RangeError wrapping io.EOF at 12", err)
+		}
+		if _, err := reader.ReadBitsAt(10, 3); !errors.As(err, &re) || re.Pos != 10 || re.Have != 2 {
+			t.Errorf("ReadBitsAt(10, 3) = %v; want RangeError at 10 with 2 bits left", err)
+		}
+		if _, err := reader.Read(make([]byte, 1)); err != io.EOF {
+			t.Errorf("Read() at the end = %v; want plain io.EOF", err)
+		}
+	})
+
+	t.Run("Codes", func(t *testing.T) {
+		// a Rice code whose low bits are cut off: the error points at the low bits
+		reader := NewBitReader([]uint8{0b00000001, 0b10000000}, 0, 0)
+		reader.SetDetailedErrors(true)
+		reader.SetBits(10)
+		reader.Skip(4)
+		_, err := reader.ReadRice(5)
+		var re *RangeError
+		if !errors.As(err, &re) || re.Err != io.ErrUnexpectedEOF || re.Pos != 8 || reader.Pos() != 4 {
+			t.Errorf("ReadRice(5) = %v, Pos() = %d; want RangeError wrapping io.ErrUnexpectedEOF at 8, cursor 4", err, reader.Pos())
+		}
+		slice := reader.Slice(0, 2)
+		slice.Skip(2)
+		if _, err := slice.ReadBit(); !errors.As(err, &re) {
+			t.Errorf("Slice().ReadBit() = %v; want RangeError", err)
+		}
+	})
+}
+
+func TestWidthError(t *testing.T) {
+	err := Try(func() error {
+		NewBitWriter[uint8](0, 0).WriteBits(0, 70)
+		return nil
+	})
+	var we *WidthError
+	if !errors.As(err, &we) || *we != (WidthError{Op: "write", Width: 70, Max: 64}) {
+		t.Fatalf("Try(WriteBits(0, 70)) = %v; want WidthError", err)
+	}
+	if !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("errors.Is(%v, ErrInvalidArgument) = false; want true", err)
+	}
+	if got, want := err.Error(), "bitstream: cannot write 70 bits from uint64; max 64"; got != want {
+		t.Errorf("Error() = %q; want %q", got, want)
+	}
+	err = Try(func() error {
+		_, err := NewBitReader([]uint8{0}, 0, 0).PeekBits(65)
+		return err
+	})
+	if !errors.As(err, &we) || we.Op != "read" || we.Width != 65 {
+		t.Errorf("Try(PeekBits(65)) = %v; want read WidthError", err)
+	}
+}
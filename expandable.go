@@ -0,0 +1,67 @@
+package bitstream
+
+import "io"
+
+// maxExpandableBytes is the longest size field allowed by ISO/IEC 14496-1.
+const maxExpandableBytes = 4
+
+// ReadExpandableSize aligns the cursor to the next byte boundary and reads the size of an
+// MPEG-4 descriptor (sizeOfInstance in ISO/IEC 14496-1, as in the esds box of MP4 files):
+// up to four bytes, each holding a continuation flag and 7 bits of the size, most
+// significant first. Returns io.EOF if no valid bits remain after alignment,
+// io.ErrUnexpectedEOF if the field is truncated, and ErrInvalidFormat if it is longer than
+// four bytes. The cursor is not moved on error.
+func (r *BitReader[T]) ReadExpandableSize() (uint32, error) {
+	start := r.pos
+	r.AlignToByte()
+	if r.pos >= r.bits {
+		r.pos = start
+		return 0, io.EOF
+	}
+	var size uint32
+	for range maxExpandableBytes {
+		b, err := r.ReadBits(8)
+		if err != nil {
+			r.pos = start
+			return 0, io.ErrUnexpectedEOF
+		}
+		size = size<<7 | uint32(b&0x7f)
+		if b < 0x80 {
+			return size, nil
+		}
+	}
+	r.pos = start
+	return 0, ErrInvalidFormat
+}
+
+// WriteExpandableSize pads the stream to the next byte boundary and writes size as an
+// MPEG-4 descriptor size. If n is 0 the shortest form is used; otherwise the field takes
+// exactly n bytes, padded with 0x80 bytes, as muxers do to reserve room for a size that is
+// patched once the descriptor is complete. Returns ErrOverflow, writing nothing, if size
+// does not fit in n bytes (28 bits when n is 0), and ErrBudgetExceeded if the field would
+// exceed the limit set by NewBitWriterLimit.
+//
+// Panics if n < 0 or n > 4.
+func (w *BitWriter[T]) WriteExpandableSize(size uint32, n int) error {
+	if n < 0 || n > maxExpandableBytes {
+		panic("bitstream: expandable size must take between 0 and 4 bytes")
+	}
+	if n == 0 {
+		for n = 1; n < maxExpandableBytes && size>>(7*n) != 0; n++ {
+		}
+	}
+	if size>>(7*n) != 0 {
+		return ErrOverflow
+	}
+	w.lock()
+	defer w.unlock()
+	if !w.reserveBits(padLen(w.bits, 8) + 8*n) {
+		return w.err
+	}
+	w.alignTo(8)
+	for i := n - 1; i > 0; i-- {
+		w.writeBits(uint64(size>>(7*i)&0x7f|0x80), 8)
+	}
+	w.writeBits(uint64(size&0x7f), 8)
+	return nil
+}
@@ -0,0 +1,64 @@
+package bitstream
+
+import (
+	"io"
+	"testing"
+)
+
+func TestExpandableSize(t *testing.T) {
+	t.Run("RoundTrip", func(t *testing.T) {
+		tests := []struct {
+			size uint32
+			n    int
+			hex  string
+		}{
+			{0, 0, "00"},
+			{0x7f, 0, "7f"},
+			{0x80, 0, "8100"},
+			{0x1234, 0, "a434"},
+			{1<<28 - 1, 0, "ffffff7f"},
+			{0x22, 4, "80808022"},
+			{0x80, 3, "808100"},
+		}
+		for _, tt := range tests {
+			writer := NewBitWriter[uint8](0, 0)
+			writer.WriteBool(true)
+			if err := writer.WriteExpandableSize(tt.size, tt.n); err != nil {
+				t.Fatalf("WriteExpandableSize(%#x, %d) error = %v", tt.size, tt.n, err)
+			}
+			if got := writer.ToHex(); got != "80"+tt.hex {
+				t.Errorf("WriteExpandableSize(%#x, %d) = %s; want 80%s", tt.size, tt.n, got, tt.hex)
+			}
+			reader := NewBitReader(writer.Data(), 0, 0)
+			reader.Skip(1)
+			if got, err := reader.ReadExpandableSize(); got != tt.size || err != nil {
+				t.Errorf("ReadExpandableSize() = %#x, %v; want %#x, nil", got, err, tt.size)
+			}
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		writer := NewBitWriter[uint8](0, 0)
+		if err := writer.WriteExpandableSize(1<<28, 0); err != ErrOverflow {
+			t.Errorf("WriteExpandableSize(1<<28, 0) error = %v; want ErrOverflow", err)
+		}
+		if err := writer.WriteExpandableSize(0x80, 1); err != ErrOverflow || writer.Bits() != 0 {
+			t.Errorf("WriteExpandableSize(0x80, 1) error = %v with %d bits; want ErrOverflow with 0", err, writer.Bits())
+		}
+
+		tests := []struct {
+			data []uint8
+			want error
+		}{
+			{[]uint8{0x80, 0x80, 0x80, 0x80, 0x01}, ErrInvalidFormat},
+			{[]uint8{0x81}, io.ErrUnexpectedEOF},
+			{[]uint8{}, io.EOF},
+		}
+		for _, tt := range tests {
+			reader := NewBitReader(tt.data, 0, 0)
+			if _, err := reader.ReadExpandableSize(); err != tt.want || reader.Pos() != 0 {
+				t.Errorf("ReadExpandableSize() on %x error = %v at %d; want %v at 0", tt.data, err, reader.Pos(), tt.want)
+			}
+		}
+	})
+}
@@ -0,0 +1,137 @@
+package fec
+
+import (
+	"io"
+	"math/bits"
+)
+
+// Convolutional is a rate 1/n binary convolutional code with constraint length k and one
+// generator polynomial per output bit, decoded with the hard-decision Viterbi algorithm.
+//
+// Polynomials are written in the usual octal form, with the most significant of their k bits
+// tapping the newest input bit: the NASA/CCSDS K=7 code is NewConvolutional(7, 0o171, 0o133).
+// For every input bit the n output bits are sent in polynomial order.
+type Convolutional struct {
+	k     int
+	polys []uint32
+	out   []uint8 // output symbol for every k-bit register value
+}
+
+// CCSDS is the K=7, rate 1/2 code with generators 171 and 133 (octal) used by Voyager,
+// CCSDS, 802.11a/g and DVB-S.
+var CCSDS = NewConvolutional(7, 0o171, 0o133)
+
+// NewConvolutional returns the rate 1/len(polys) code with constraint length k.
+//
+// Panics if k is not between 2 and 16, there are not 1 to 8 polynomials,
+// or a polynomial is zero or has bits at or above k.
+func NewConvolutional(k int, polys ...uint32) *Convolutional {
+	if k < 2 || k > 16 {
+		panic("fec: constraint length must be between 2 and 16")
+	}
+	if len(polys) < 1 || len(polys) > 8 {
+		panic("fec: convolutional code must have 1 to 8 polynomials")
+	}
+	for _, p := range polys {
+		if p == 0 || p>>k != 0 {
+			panic("fec: convolutional polynomial does not match the constraint length")
+		}
+	}
+	c := &Convolutional{k: k, polys: append([]uint32(nil), polys...), out: make([]uint8, 1<<k)}
+	for reg := range c.out {
+		var sym uint8
+		for _, p := range polys {
+			sym = sym<<1 | uint8(bits.OnesCount32(uint32(reg)&p)&1)
+		}
+		c.out[reg] = sym
+	}
+	return c
+}
+
+// Encode reads the remaining bits of r and writes len(polys) code bits for each to w,
+// followed by the code bits of k-1 zero bits that return the encoder to its initial state.
+// Returns the first error from r.
+func (c *Convolutional) Encode(r BitReader, w BitWriter) error {
+	n := len(c.polys)
+	var state uint32
+	for r.Pos() < r.Bits() {
+		b, err := r.ReadBits(1)
+		if err != nil {
+			return err
+		}
+		state = c.step(state, uint32(b), w, n)
+	}
+	for range c.k - 1 {
+		state = c.step(state, 0, w, n)
+	}
+	return nil
+}
+
+// step shifts bit b into the encoder, writes its output and returns the new state.
+func (c *Convolutional) step(state, b uint32, w BitWriter, n int) uint32 {
+	reg := b<<(c.k-1) | state
+	w.WriteBits(uint64(c.out[reg]), n)
+	return reg >> 1
+}
+
+// Decode reads the remaining bits of r as the output of Encode, finds the most likely input
+// with the Viterbi algorithm and writes it to w, without the k-1 flush bits.
+// It returns the number of received bits that differ from the re-encoded decoded sequence,
+// an estimate of the channel errors corrected.
+// Returns io.EOF if no bits remain, and io.ErrUnexpectedEOF, writing nothing, if the remaining
+// bits are not a whole number of output symbols or too few to hold the flush bits.
+func (c *Convolutional) Decode(r BitReader, w BitWriter) (int, error) {
+	n := len(c.polys)
+	avail := r.Bits() - r.Pos()
+	if avail <= 0 {
+		return 0, io.EOF
+	}
+	if avail%n != 0 || avail/n < c.k-1 {
+		return 0, io.ErrUnexpectedEOF
+	}
+	steps := avail / n
+	states := 1 << (c.k - 1)
+	words := (states + 63) / 64
+	decisions := make([]uint64, steps*words)
+	metric := make([]int, states)
+	next := make([]int, states)
+	const inf = 1 << 30
+	for s := 1; s < states; s++ {
+		metric[s] = inf
+	}
+	for t := range steps {
+		v, err := r.ReadBits(n)
+		if err != nil {
+			return 0, err
+		}
+		rx := uint8(v)
+		d := decisions[t*words : (t+1)*words]
+		for ns := range states {
+			// the register holding ns's newest bit and either value of the dropped oldest bit
+			reg0 := ns << 1
+			reg1 := reg0 | 1
+			m0 := metric[reg0&(states-1)] + bits.OnesCount8(c.out[reg0]^rx)
+			m1 := metric[reg1&(states-1)] + bits.OnesCount8(c.out[reg1]^rx)
+			if m1 < m0 {
+				next[ns] = m1
+				d[ns/64] |= 1 << (ns % 64)
+			} else {
+				next[ns] = m0
+			}
+		}
+		metric, next = next, metric
+	}
+
+	// trace back from the all-zero state the flush bits lead to
+	in := make([]uint8, steps)
+	state := 0
+	for t := steps - 1; t >= 0; t-- {
+		in[t] = uint8(state >> (c.k - 2))
+		x := int(decisions[t*words+state/64] >> (state % 64) & 1)
+		state = (state<<1 | x) & (states - 1)
+	}
+	for _, b := range in[:steps-(c.k-1)] {
+		w.WriteBits(uint64(b), 1)
+	}
+	return metric[0], nil
+}
@@ -0,0 +1,75 @@
+package fec
+
+import (
+	"io"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/yyyoichi/bitstream-go"
+)
+
+func TestConvolutional(t *testing.T) {
+	t.Run("Encode", func(t *testing.T) {
+		// textbook K=3 (7, 5) code: 1011 -> 11 10 00 01 | 01 11
+		c := NewConvolutional(3, 0o7, 0o5)
+		r := bitstream.NewBitReader([]uint8{0b1011_0000}, 0, 0)
+		r.SetBits(4)
+		w := bitstream.NewBitWriter[uint8](0, 0)
+		if err := c.Encode(r, w); err != nil {
+			t.Fatalf("Encode() error = %v; want nil", err)
+		}
+		if got := w.String(); got != "11100001 0111" {
+			t.Errorf("Encode(1011) = %q; want \"11100001 0111\"", got)
+		}
+	})
+
+	t.Run("Correction", func(t *testing.T) {
+		codes := []struct {
+			name string
+			c    *Convolutional
+			gap  int
+		}{
+			{"K3", NewConvolutional(3, 0o7, 0o5), 12},
+			{"CCSDS", CCSDS, 24},
+			{"K9Rate1/3", NewConvolutional(9, 0o557, 0o663, 0o711), 30},
+		}
+		rnd := rand.New(rand.NewPCG(3, 4))
+		for _, tt := range codes {
+			t.Run(tt.name, func(t *testing.T) {
+				src := bitstream.NewBitWriter[uint64](0, 0)
+				for range 8 {
+					src.WriteBits(rnd.Uint64(), 64)
+				}
+				r := bitstream.NewBitReader(src.Data(), 0, 0)
+				coded := bitstream.NewBitWriter[uint64](0, 0)
+				tt.c.Encode(r, coded)
+				// isolated errors spaced well beyond the constraint length
+				flips := 0
+				for pos := 5; pos < coded.Bits(); pos += tt.gap + rnd.IntN(8) {
+					bit, _ := bitstream.NewBitReader(coded.Data(), 0, 0).ReadBitAt(pos)
+					coded.WriteBitAt(pos, !bit)
+					flips++
+				}
+				cr := bitstream.NewBitReader(coded.Data(), 0, 0)
+				cr.SetBits(coded.Bits())
+				out := bitstream.NewBitWriter[uint64](0, 0)
+				got, err := tt.c.Decode(cr, out)
+				if err != nil || got != flips {
+					t.Errorf("Decode() = %d, %v; want %d, nil", got, err, flips)
+				}
+				if out.Bits() != src.Bits() || bitstream.Compare(bitstream.NewBitReader(out.Data(), 0, 0), bitstream.NewBitReader(src.Data(), 0, 0)) != 0 {
+					t.Errorf("Decode() did not recover the input")
+				}
+			})
+		}
+	})
+
+	t.Run("Truncated", func(t *testing.T) {
+		r := bitstream.NewBitReader([]uint8{0xFF}, 0, 0)
+		r.SetBits(7)
+		w := bitstream.NewBitWriter[uint8](0, 0)
+		if _, err := CCSDS.Decode(r, w); err != io.ErrUnexpectedEOF || w.Bits() != 0 {
+			t.Errorf("Decode() of 7 bits = %v with %d bits; want io.ErrUnexpectedEOF with 0", err, w.Bits())
+		}
+	})
+}
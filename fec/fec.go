@@ -0,0 +1,27 @@
+// Package fec implements forward error correction codes on top of bitstream readers and writers.
+//
+// Encoders pull fixed-size blocks of data bits from a BitReader and write code blocks through
+// a BitWriter; decoders do the reverse, correcting what errors they can and counting the
+// blocks they corrected or could not correct in a Stats value.
+package fec
+
+// BitWriter is the interface used to emit code or data blocks.
+// It is implemented by *bitstream.BitWriter.
+type BitWriter interface {
+	WriteBits(data uint64, bits int)
+}
+
+// BitReader is the interface used to consume data or code blocks.
+// It is implemented by *bitstream.BitReader.
+type BitReader interface {
+	ReadBits(bits int) (uint64, error)
+	Pos() int
+	Bits() int
+}
+
+// Stats counts the blocks handled by a decoder.
+type Stats struct {
+	Blocks        int // code blocks decoded
+	Corrected     int // blocks in which errors were found and corrected
+	Uncorrectable int // blocks with errors that were detected but could not be corrected
+}
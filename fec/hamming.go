@@ -0,0 +1,153 @@
+package fec
+
+import (
+	"io"
+	"math/bits"
+)
+
+// Hamming is a binary Hamming code with m parity bits, correcting any single-bit error in a
+// block of 2^m-1 bits that carries 2^m-m-1 data bits. The extended form appends an overall
+// parity bit, which also detects (but cannot correct) double-bit errors.
+//
+// Within a block the bits are in the classic position order: position 1 is sent first, and
+// parity bits sit at the power-of-two positions with the data bits, most significant first,
+// in between. The overall parity bit of the extended form is sent last.
+type Hamming struct {
+	m        int
+	n        int // positions 1..n, excluding the overall parity bit
+	extended bool
+}
+
+var (
+	// Hamming74 is the Hamming(7,4) code.
+	Hamming74 = NewHamming(3, false)
+	// Hamming84 is the extended Hamming(8,4) code (SECDED), as used in teletext.
+	Hamming84 = NewHamming(3, true)
+)
+
+// NewHamming returns the Hamming code with m parity bits, extended with an overall parity bit
+// if extended is set. For example m = 3 gives Hamming(7,4) and m = 6 gives Hamming(63,57).
+//
+// Panics if m < 2 or m > 6.
+func NewHamming(m int, extended bool) *Hamming {
+	if m < 2 || m > 6 {
+		panic("fec: Hamming parity bits must be between 2 and 6")
+	}
+	return &Hamming{m: m, n: 1<<m - 1, extended: extended}
+}
+
+// CodeBits returns the number of bits in a code block.
+func (h *Hamming) CodeBits() int {
+	if h.extended {
+		return h.n + 1
+	}
+	return h.n
+}
+
+// DataBits returns the number of data bits carried by a code block.
+func (h *Hamming) DataBits() int {
+	return h.n - h.m
+}
+
+// EncodeBlock returns the code block for the low DataBits bits of data, right-aligned.
+func (h *Hamming) EncodeBlock(data uint64) uint64 {
+	k := h.DataBits()
+	var code uint64
+	var syndrome int
+	for p := 1; p <= h.n; p++ {
+		if p&(p-1) == 0 {
+			continue
+		}
+		k--
+		if data>>k&1 != 0 {
+			code |= 1 << (h.n - p)
+			syndrome ^= p
+		}
+	}
+	for j := range h.m {
+		if syndrome>>j&1 != 0 {
+			code |= 1 << (h.n - 1<<j)
+		}
+	}
+	if h.extended {
+		code = code<<1 | uint64(bits.OnesCount64(code)&1)
+	}
+	return code
+}
+
+// DecodeBlock returns the data bits of the code block code, right-aligned, after correcting
+// a single-bit error. corrected reports whether an error was corrected, and ok is false when
+// the extended code detects an uncorrectable double error, in which case data holds the
+// data bits as received. The plain code cannot detect double errors and miscorrects them.
+func (h *Hamming) DecodeBlock(code uint64) (data uint64, corrected, ok bool) {
+	parityErr := false
+	if h.extended {
+		parityErr = bits.OnesCount64(code)&1 != 0
+		code >>= 1
+	}
+	var syndrome int
+	for p := 1; p <= h.n; p++ {
+		if code>>(h.n-p)&1 != 0 {
+			syndrome ^= p
+		}
+	}
+	ok = true
+	switch {
+	case syndrome != 0 && h.extended && !parityErr:
+		ok = false
+	case syndrome != 0:
+		code ^= 1 << (h.n - syndrome)
+		corrected = true
+	case parityErr:
+		corrected = true // the overall parity bit itself was flipped
+	}
+	for p := 1; p <= h.n; p++ {
+		if p&(p-1) != 0 {
+			data = data<<1 | code>>(h.n-p)&1
+		}
+	}
+	return data, corrected, ok
+}
+
+// Encode reads the remaining bits of r in blocks of DataBits bits and writes a code block for
+// each to w. A final partial block is padded with zero bits on the right.
+// Returns the first error from r.
+func (h *Hamming) Encode(r BitReader, w BitWriter) error {
+	k := h.DataBits()
+	for r.Pos() < r.Bits() {
+		n := min(k, r.Bits()-r.Pos())
+		data, err := r.ReadBits(n)
+		if err != nil {
+			return err
+		}
+		w.WriteBits(h.EncodeBlock(data<<(k-n)), h.CodeBits())
+	}
+	return nil
+}
+
+// Decode reads the remaining bits of r in blocks of CodeBits bits, corrects them, and writes
+// the data bits of each to w, including those of uncorrectable blocks as received.
+// Returns io.ErrUnexpectedEOF if a partial block remains, after decoding the whole blocks before it.
+func (h *Hamming) Decode(r BitReader, w BitWriter) (Stats, error) {
+	var st Stats
+	n := h.CodeBits()
+	for r.Pos() < r.Bits() {
+		if r.Bits()-r.Pos() < n {
+			return st, io.ErrUnexpectedEOF
+		}
+		code, err := r.ReadBits(n)
+		if err != nil {
+			return st, err
+		}
+		data, corrected, ok := h.DecodeBlock(code)
+		st.Blocks++
+		if corrected {
+			st.Corrected++
+		}
+		if !ok {
+			st.Uncorrectable++
+		}
+		w.WriteBits(data, h.DataBits())
+	}
+	return st, nil
+}
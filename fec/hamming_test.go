@@ -0,0 +1,107 @@
+package fec
+
+import (
+	"io"
+	"testing"
+
+	"github.com/yyyoichi/bitstream-go"
+)
+
+func TestHamming(t *testing.T) {
+	t.Run("Hamming74Codewords", func(t *testing.T) {
+		// p1 p2 d1 p4 d2 d3 d4
+		tests := []struct {
+			data, code uint64
+		}{
+			{0b0000, 0b0000000},
+			{0b1011, 0b0110011},
+			{0b1000, 0b1110000},
+			{0b0001, 0b1101001},
+			{0b1111, 0b1111111},
+		}
+		for _, tt := range tests {
+			if got := Hamming74.EncodeBlock(tt.data); got != tt.code {
+				t.Errorf("EncodeBlock(%04b) = %07b; want %07b", tt.data, got, tt.code)
+			}
+		}
+	})
+
+	t.Run("SingleErrors", func(t *testing.T) {
+		for _, h := range []*Hamming{Hamming74, Hamming84, NewHamming(2, false), NewHamming(6, true), NewHamming(5, false)} {
+			for i := range uint64(16) {
+				data := i * 0x9E3779B97F4A7C15 >> (64 - h.DataBits())
+				code := h.EncodeBlock(data)
+				if got, corrected, ok := h.DecodeBlock(code); got != data || corrected || !ok {
+					t.Errorf("(%d,%d) DecodeBlock(%b) = %b, %v, %v; want %b, false, true", h.CodeBits(), h.DataBits(), code, got, corrected, ok, data)
+				}
+				for i := range h.CodeBits() {
+					if got, corrected, ok := h.DecodeBlock(code ^ 1<<i); got != data || !corrected || !ok {
+						t.Errorf("(%d,%d) DecodeBlock with bit %d flipped = %b, %v, %v; want %b, true, true", h.CodeBits(), h.DataBits(), i, got, corrected, ok, data)
+					}
+				}
+			}
+		}
+	})
+
+	t.Run("DoubleErrors", func(t *testing.T) {
+		code := Hamming84.EncodeBlock(0b1011)
+		for i := range 8 {
+			for j := i + 1; j < 8; j++ {
+				if _, _, ok := Hamming84.DecodeBlock(code ^ 1<<i ^ 1<<j); ok {
+					t.Errorf("DecodeBlock with bits %d and %d flipped ok = true; want false", i, j)
+				}
+			}
+		}
+	})
+
+	t.Run("Stream", func(t *testing.T) {
+		src := bitstream.NewBitWriter[uint8](0, 0)
+		src.Write([]byte("hamming"))
+		src.WriteBits(0b10, 2)
+		r := bitstream.NewBitReader(src.Data(), 0, 0)
+		r.SetBits(src.Bits())
+		coded := bitstream.NewBitWriter[uint16](0, 0)
+		if err := Hamming84.Encode(r, coded); err != nil {
+			t.Fatalf("Encode() error = %v; want nil", err)
+		}
+		if got, want := coded.Bits(), 15*8; got != want {
+			t.Errorf("coded Bits() = %d; want %d", got, want)
+		}
+		// one error in block 0, two in block 3, one in the parity bit of block 5
+		flip := func(pos int) {
+			bit, _ := bitstream.NewBitReader(coded.Data(), 0, 0).ReadBitAt(pos)
+			coded.WriteBitAt(pos, !bit)
+		}
+		flip(2)
+		flip(24)
+		flip(29)
+		flip(47)
+		cr := bitstream.NewBitReader(coded.Data(), 0, 0)
+		cr.SetBits(coded.Bits())
+		out := bitstream.NewBitWriter[uint8](0, 0)
+		st, err := Hamming84.Decode(cr, out)
+		if err != nil {
+			t.Fatalf("Decode() error = %v; want nil", err)
+		}
+		if want := (Stats{Blocks: 15, Corrected: 2, Uncorrectable: 1}); st != want {
+			t.Errorf("Decode() stats = %+v; want %+v", st, want)
+		}
+		got := out.Data()
+		if string(got[:1]) != "h" || string(got[2:7]) != "mming" {
+			t.Errorf("Decode() = %q; want corrected blocks of \"hamming\"", got[:7])
+		}
+		if got[7] != 0b1000_0000 {
+			t.Errorf("Decode() last byte = %08b; want 10000000", got[7])
+		}
+	})
+
+	t.Run("Truncated", func(t *testing.T) {
+		r := bitstream.NewBitReader([]uint8{0xFF, 0x0F}, 0, 0)
+		r.SetBits(12)
+		out := bitstream.NewBitWriter[uint8](0, 0)
+		st, err := Hamming84.Decode(r, out)
+		if err != io.ErrUnexpectedEOF || st.Blocks != 1 {
+			t.Errorf("Decode() = %+v, %v; want 1 block, io.ErrUnexpectedEOF", st, err)
+		}
+	})
+}
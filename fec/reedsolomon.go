@@ -0,0 +1,323 @@
+package fec
+
+import (
+	"errors"
+	"io"
+)
+
+// ErrUncorrectable is returned when a block has more errors than the code can correct.
+var ErrUncorrectable = errors.New("fec: too many errors to correct")
+
+// ReedSolomon is a systematic Reed-Solomon code over GF(2^m) with m-bit symbols.
+// A block is up to 2^m-1 symbols long: the data symbols followed by nsym parity symbols,
+// which correct up to nsym/2 symbol errors anywhere in the block. Shorter blocks are
+// shortened codes and need no padding.
+//
+// The generator polynomial has the roots α^fcr, ..., α^(fcr+nsym-1), where α is a root of the
+// field polynomial poly. QR codes and DVB RS(204,188) use m = 8, poly = 0x11D and fcr = 0.
+//
+// A ReedSolomon is safe for concurrent use.
+type ReedSolomon struct {
+	m    int
+	nsym int
+	fcr  int
+	exp  []uint16 // α^i, doubled so products need no reduction
+	log  []int
+	gen  []uint16 // generator polynomial, highest degree first, monic
+}
+
+// NewReedSolomon returns a Reed-Solomon code with m-bit symbols over the field generated by
+// poly (including its x^m term), nsym parity symbols per block and first consecutive root fcr.
+//
+// Panics if m is not between 2 and 16, poly is not a primitive polynomial of degree m,
+// or nsym is not between 1 and 2^m-2.
+func NewReedSolomon(m int, poly uint32, nsym, fcr int) *ReedSolomon {
+	if m < 2 || m > 16 {
+		panic("fec: Reed-Solomon symbol width must be between 2 and 16")
+	}
+	size := 1<<m - 1
+	if nsym < 1 || nsym >= size {
+		panic("fec: invalid number of Reed-Solomon parity symbols")
+	}
+	if poly>>m != 1 {
+		panic("fec: Reed-Solomon field polynomial does not match the symbol width")
+	}
+	rs := &ReedSolomon{m: m, nsym: nsym, fcr: fcr, exp: make([]uint16, 2*size), log: make([]int, size+1)}
+	x := uint32(1)
+	for i := range size {
+		if x == 1 && i > 0 {
+			panic("fec: Reed-Solomon field polynomial is not primitive")
+		}
+		rs.exp[i] = uint16(x)
+		rs.exp[i+size] = uint16(x)
+		rs.log[x] = i
+		x <<= 1
+		if x>>m != 0 {
+			x ^= poly
+		}
+	}
+	if x != 1 {
+		panic("fec: Reed-Solomon field polynomial is not primitive")
+	}
+	rs.gen = []uint16{1}
+	for i := range nsym {
+		root := rs.pow(fcr + i)
+		next := make([]uint16, len(rs.gen)+1)
+		for j, g := range rs.gen {
+			next[j] ^= g
+			next[j+1] ^= rs.mul(g, root)
+		}
+		rs.gen = next
+	}
+	return rs
+}
+
+// SymbolBits returns the symbol width m.
+func (rs *ReedSolomon) SymbolBits() int {
+	return rs.m
+}
+
+// ParitySymbols returns the number of parity symbols per block.
+func (rs *ReedSolomon) ParitySymbols() int {
+	return rs.nsym
+}
+
+// EncodeBlock returns the parity symbols for data, to be sent after it.
+//
+// Panics if len(data)+ParitySymbols() exceeds 2^m-1.
+func (rs *ReedSolomon) EncodeBlock(data []uint16) []uint16 {
+	if len(data)+rs.nsym > 1<<rs.m-1 {
+		panic("fec: Reed-Solomon block too long")
+	}
+	parity := make([]uint16, rs.nsym)
+	for _, d := range data {
+		f := d ^ parity[0]
+		copy(parity, parity[1:])
+		parity[rs.nsym-1] = 0
+		if f != 0 {
+			for j := range parity {
+				parity[j] ^= rs.mul(rs.gen[j+1], f)
+			}
+		}
+	}
+	return parity
+}
+
+// DecodeBlock corrects block, data symbols followed by parity symbols, in place and returns
+// the number of symbols corrected. Returns ErrUncorrectable, leaving block unchanged, if it
+// has more errors than the code can correct and they are detected.
+//
+// Panics if block is not longer than ParitySymbols() or is longer than 2^m-1.
+func (rs *ReedSolomon) DecodeBlock(block []uint16) (int, error) {
+	if len(block) <= rs.nsym || len(block) > 1<<rs.m-1 {
+		panic("fec: invalid Reed-Solomon block length")
+	}
+	synd := rs.syndromes(block)
+	if synd == nil {
+		return 0, nil
+	}
+
+	// Berlekamp-Massey: error locator Λ(x), lowest degree first
+	lambda, prev := []uint16{1}, []uint16{1}
+	l, shift, b := 0, 1, uint16(1)
+	for n := range rs.nsym {
+		d := synd[n]
+		for i := 1; i <= l && i < len(lambda); i++ {
+			d ^= rs.mul(lambda[i], synd[n-i])
+		}
+		if d == 0 {
+			shift++
+			continue
+		}
+		t := append([]uint16(nil), lambda...)
+		coef := rs.div(d, b)
+		for len(lambda) < len(prev)+shift {
+			lambda = append(lambda, 0)
+		}
+		for i, p := range prev {
+			lambda[i+shift] ^= rs.mul(coef, p)
+		}
+		if 2*l <= n {
+			l, prev, b, shift = n+1-l, t, d, 1
+		} else {
+			shift++
+		}
+	}
+	if 2*l > rs.nsym {
+		return 0, ErrUncorrectable
+	}
+	for len(lambda) < l+1 {
+		lambda = append(lambda, 0)
+	}
+	lambda = lambda[:l+1]
+
+	// Chien search over the positions of this block, then Forney's formula with
+	// Ω(x) = S(x)Λ(x) mod x^nsym for the error values.
+	omega := make([]uint16, rs.nsym)
+	for i, s := range synd {
+		for j, c := range lambda {
+			if i+j < rs.nsym {
+				omega[i+j] ^= rs.mul(s, c)
+			}
+		}
+	}
+	size := 1<<rs.m - 1
+	type fix struct {
+		at  int
+		val uint16
+	}
+	fixes := make([]fix, 0, l)
+	for at := range block {
+		p := len(block) - 1 - at // block[at] is the coefficient of x^p
+		xinv := rs.pow(size - p)
+		if rs.eval(lambda, xinv) != 0 {
+			continue
+		}
+		var deriv uint16
+		for i := 1; i < len(lambda); i += 2 {
+			deriv ^= rs.mul(lambda[i], rs.pow(rs.log[xinv]*(i-1)))
+		}
+		if deriv == 0 {
+			return 0, ErrUncorrectable
+		}
+		val := rs.div(rs.eval(omega, xinv), deriv)
+		val = rs.mul(val, rs.pow(p*(1-rs.fcr)))
+		fixes = append(fixes, fix{at, val})
+	}
+	if len(fixes) != l {
+		return 0, ErrUncorrectable
+	}
+	for _, f := range fixes {
+		block[f.at] ^= f.val
+	}
+	if rs.syndromes(block) != nil {
+		for _, f := range fixes {
+			block[f.at] ^= f.val
+		}
+		return 0, ErrUncorrectable
+	}
+	return l, nil
+}
+
+// Encode reads the remaining bits of r as m-bit symbols, in blocks of k data symbols, and
+// writes each block followed by its parity symbols to w. The last block may be shorter, and a
+// final partial symbol is padded with zero bits on the right. Returns the first error from r.
+//
+// Panics if k < 1 or k+ParitySymbols() exceeds 2^m-1.
+func (rs *ReedSolomon) Encode(r BitReader, w BitWriter, k int) error {
+	rs.checkDataSymbols(k)
+	data := make([]uint16, 0, k)
+	for r.Pos() < r.Bits() {
+		n := min(rs.m, r.Bits()-r.Pos())
+		v, err := r.ReadBits(n)
+		if err != nil {
+			return err
+		}
+		data = append(data, uint16(v<<(rs.m-n)))
+		if len(data) == k || r.Pos() >= r.Bits() {
+			for _, s := range data {
+				w.WriteBits(uint64(s), rs.m)
+			}
+			for _, s := range rs.EncodeBlock(data) {
+				w.WriteBits(uint64(s), rs.m)
+			}
+			data = data[:0]
+		}
+	}
+	return nil
+}
+
+// Decode reads blocks written by Encode with the same k, corrects them, and writes their data
+// symbols to w, including those of uncorrectable blocks as received.
+// Returns io.ErrUnexpectedEOF if the remaining bits are not whole symbols or the last block has
+// no data symbols, after decoding the blocks before it.
+//
+// Panics as Encode does.
+func (rs *ReedSolomon) Decode(r BitReader, w BitWriter, k int) (Stats, error) {
+	rs.checkDataSymbols(k)
+	var st Stats
+	block := make([]uint16, k+rs.nsym)
+	for r.Pos() < r.Bits() {
+		avail := r.Bits() - r.Pos()
+		if avail%rs.m != 0 || avail/rs.m <= rs.nsym {
+			return st, io.ErrUnexpectedEOF
+		}
+		block = block[:min(k+rs.nsym, avail/rs.m)]
+		for i := range block {
+			v, err := r.ReadBits(rs.m)
+			if err != nil {
+				return st, err
+			}
+			block[i] = uint16(v)
+		}
+		st.Blocks++
+		switch n, err := rs.DecodeBlock(block); {
+		case err != nil:
+			st.Uncorrectable++
+		case n > 0:
+			st.Corrected++
+		}
+		for _, s := range block[:len(block)-rs.nsym] {
+			w.WriteBits(uint64(s), rs.m)
+		}
+	}
+	return st, nil
+}
+
+func (rs *ReedSolomon) checkDataSymbols(k int) {
+	if k < 1 || k+rs.nsym > 1<<rs.m-1 {
+		panic("fec: invalid number of Reed-Solomon data symbols")
+	}
+}
+
+// syndromes returns the syndromes of block, or nil if they are all zero.
+func (rs *ReedSolomon) syndromes(block []uint16) []uint16 {
+	synd := make([]uint16, rs.nsym)
+	nonzero := false
+	for i := range synd {
+		x := rs.pow(rs.fcr + i)
+		var v uint16
+		for _, c := range block {
+			v = rs.mul(v, x) ^ c
+		}
+		synd[i] = v
+		nonzero = nonzero || v != 0
+	}
+	if !nonzero {
+		return nil
+	}
+	return synd
+}
+
+// eval evaluates p, lowest degree first, at x.
+func (rs *ReedSolomon) eval(p []uint16, x uint16) uint16 {
+	var v uint16
+	for i := len(p) - 1; i >= 0; i-- {
+		v = rs.mul(v, x) ^ p[i]
+	}
+	return v
+}
+
+// pow returns α^e for any integer e.
+func (rs *ReedSolomon) pow(e int) uint16 {
+	size := 1<<rs.m - 1
+	e %= size
+	if e < 0 {
+		e += size
+	}
+	return rs.exp[e]
+}
+
+func (rs *ReedSolomon) mul(a, b uint16) uint16 {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return rs.exp[rs.log[a]+rs.log[b]]
+}
+
+func (rs *ReedSolomon) div(a, b uint16) uint16 {
+	if a == 0 {
+		return 0
+	}
+	return rs.exp[rs.log[a]-rs.log[b]+1<<rs.m-1]
+}
@@ -0,0 +1,138 @@
+package fec
+
+import (
+	"io"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/yyyoichi/bitstream-go"
+)
+
+func TestReedSolomon(t *testing.T) {
+	t.Run("QRCode", func(t *testing.T) {
+		// "hello world" in a version 1-M QR code
+		data := []uint16{0x40, 0xd2, 0x75, 0x47, 0x76, 0x17, 0x32, 0x06, 0x27, 0x26, 0x96, 0xc6, 0xc6, 0x96, 0x70, 0xec}
+		want := []uint16{0xbc, 0x2a, 0x90, 0x13, 0x6b, 0xaf, 0xef, 0xfd, 0x4b, 0xe0}
+		rs := NewReedSolomon(8, 0x11D, 10, 0)
+		if got := rs.EncodeBlock(data); !slices.Equal(got, want) {
+			t.Errorf("EncodeBlock() = %x; want %x", got, want)
+		}
+	})
+
+	t.Run("Correction", func(t *testing.T) {
+		tests := []struct {
+			m    int
+			poly uint32
+			nsym int
+			fcr  int
+			k    int
+		}{
+			{8, 0x11D, 10, 0, 16},
+			{8, 0x11D, 16, 0, 188},
+			{8, 0x187, 32, 112, 223},
+			{4, 0x13, 4, 1, 11},
+			{12, 0x1053, 8, 0, 50},
+		}
+		rnd := rand.New(rand.NewPCG(1, 2))
+		for _, tt := range tests {
+			rs := NewReedSolomon(tt.m, tt.poly, tt.nsym, tt.fcr)
+			for range 20 {
+				data := make([]uint16, tt.k)
+				for i := range data {
+					data[i] = uint16(rnd.IntN(1 << tt.m))
+				}
+				block := append(slices.Clone(data), rs.EncodeBlock(data)...)
+				orig := slices.Clone(block)
+				nerr := rnd.IntN(tt.nsym/2 + 1)
+				for _, at := range rnd.Perm(len(block))[:nerr] {
+					block[at] ^= uint16(1 + rnd.IntN(1<<tt.m-1))
+				}
+				n, err := rs.DecodeBlock(block)
+				if err != nil || n != nerr || !slices.Equal(block, orig) {
+					t.Errorf("GF(2^%d) nsym %d: DecodeBlock() with %d errors = %d, %v", tt.m, tt.nsym, nerr, n, err)
+				}
+			}
+		}
+	})
+
+	t.Run("TooManyErrors", func(t *testing.T) {
+		rs := NewReedSolomon(8, 0x11D, 4, 0)
+		data := []byte("reed-solomon")
+		block := make([]uint16, 0, len(data)+4)
+		for _, b := range data {
+			block = append(block, uint16(b))
+		}
+		block = append(block, rs.EncodeBlock(block)...)
+		block[0] ^= 1
+		block[3] ^= 2
+		block[7] ^= 4
+		received := slices.Clone(block)
+		if _, err := rs.DecodeBlock(block); err != ErrUncorrectable {
+			t.Errorf("DecodeBlock() with 3 errors error = %v; want ErrUncorrectable", err)
+		}
+		if !slices.Equal(block, received) {
+			t.Errorf("DecodeBlock() modified an uncorrectable block")
+		}
+	})
+
+	t.Run("Stream", func(t *testing.T) {
+		rs := NewReedSolomon(5, 0x25, 6, 0)
+		src := bitstream.NewBitWriter[uint8](0, 0)
+		src.Write([]byte("bit symbols straight from the stream"))
+		src.WriteBits(0b101, 3)
+		r := bitstream.NewBitReader(src.Data(), 0, 0)
+		r.SetBits(src.Bits())
+		coded := bitstream.NewBitWriter[uint32](0, 0)
+		if err := rs.Encode(r, coded, 20); err != nil {
+			t.Fatalf("Encode() error = %v; want nil", err)
+		}
+		// 291 bits = 59 symbols: blocks of 20, 20 and 19 data symbols
+		if got, want := coded.Bits(), (59+3*6)*5; got != want {
+			t.Errorf("coded Bits() = %d; want %d", got, want)
+		}
+		for _, pos := range []int{0, 7, 13, 200, 201, 202, 203, 204, 205, 206, 300, 310, 320, 330} {
+			bit, _ := bitstream.NewBitReader(coded.Data(), 0, 0).ReadBitAt(pos)
+			coded.WriteBitAt(pos, !bit)
+		}
+		cr := bitstream.NewBitReader(coded.Data(), 0, 0)
+		cr.SetBits(coded.Bits())
+		out := bitstream.NewBitWriter[uint8](0, 0)
+		st, err := rs.Decode(cr, out, 20)
+		if err != nil {
+			t.Fatalf("Decode() error = %v; want nil", err)
+		}
+		if want := (Stats{Blocks: 3, Corrected: 2, Uncorrectable: 1}); st != want {
+			t.Errorf("Decode() stats = %+v; want %+v", st, want)
+		}
+		or := bitstream.NewBitReader(out.Data(), 0, 0)
+		or.SetBits(out.Bits())
+		if !bitstream.EqualRange(or, bitstream.NewBitReader(src.Data(), 0, 0), 0, 0, 200) {
+			t.Errorf("Decode() did not restore the first two blocks")
+		}
+		if out.Bits() != 59*5 {
+			t.Errorf("decoded Bits() = %d; want %d", out.Bits(), 59*5)
+		}
+	})
+
+	t.Run("Truncated", func(t *testing.T) {
+		rs := NewReedSolomon(4, 0x13, 2, 0)
+		for _, bits := range []int{8, 10} {
+			r := bitstream.NewBitReader([]uint8{0x12, 0x34}, 0, 0)
+			r.SetBits(bits)
+			out := bitstream.NewBitWriter[uint8](0, 0)
+			if _, err := rs.Decode(r, out, 4); err != io.ErrUnexpectedEOF {
+				t.Errorf("Decode() of %d bits error = %v; want io.ErrUnexpectedEOF", bits, err)
+			}
+		}
+	})
+
+	t.Run("InvalidPolynomial", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("NewReedSolomon() with a reducible polynomial did not panic")
+			}
+		}()
+		NewReedSolomon(4, 0x15, 2, 0)
+	})
+}
@@ -0,0 +1,88 @@
+package bitstream
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+)
+
+// Field describes one named field recorded by a FieldRecorder.
+type Field struct {
+	Name   string `json:"name"`
+	Offset int    `json:"offset"` // Bit position of the first bit
+	Bits   int    `json:"bits"`   // Width in bits
+	Value  uint64 `json:"value"`
+}
+
+// FieldRecorder wraps a BitReader and records the name, position, width and value of
+// every field read through it, turning a parser into a protocol dissector that can emit
+// a layout map of the stream.
+//
+//	f := bitstream.NewFieldRecorder(r)
+//	f.Field("version", 3)
+//	f.FieldFunc("length", (*bitstream.BitReader[uint8]).ReadUE)
+//	f.WriteLayout(os.Stdout)
+type FieldRecorder[T Unsigned] struct {
+	r      *BitReader[T]
+	fields []Field
+}
+
+// NewFieldRecorder returns a FieldRecorder reading from r at its current position.
+func NewFieldRecorder[T Unsigned](r *BitReader[T]) *FieldRecorder[T] {
+	return &FieldRecorder[T]{r: r}
+}
+
+// Reader returns the underlying BitReader. Bits read from it directly are not recorded.
+func (f *FieldRecorder[T]) Reader() *BitReader[T] {
+	return f.r
+}
+
+// Field reads a bits-wide field and records it under name.
+// Errors are reported as for ReadBits; nothing is recorded on error.
+func (f *FieldRecorder[T]) Field(name string, bits int) (uint64, error) {
+	return f.FieldFunc(name, func(r *BitReader[T]) (uint64, error) {
+		return r.ReadBits(bits)
+	})
+}
+
+// FieldFunc calls read to read one field, such as an Exp-Golomb code, and records it
+// under name with the value read and the bits read consumed. Nothing is recorded if read
+// returns an error.
+func (f *FieldRecorder[T]) FieldFunc(name string, read func(r *BitReader[T]) (uint64, error)) (uint64, error) {
+	start := f.r.pos
+	v, err := read(f.r)
+	if err != nil {
+		return v, err
+	}
+	f.fields = append(f.fields, Field{Name: name, Offset: start, Bits: f.r.pos - start, Value: v})
+	return v, nil
+}
+
+// Fields returns the fields recorded so far, in the order they were read.
+func (f *FieldRecorder[T]) Fields() []Field {
+	return f.fields
+}
+
+// MarshalJSON implements json.Marshaler, encoding the recorded fields as an array of
+// {"name", "offset", "bits", "value"} objects.
+func (f *FieldRecorder[T]) MarshalJSON() ([]byte, error) {
+	if f.fields == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(f.fields)
+}
+
+// WriteLayout writes the recorded fields to w as an aligned text table with the offset,
+// width, name and value of each field, the value also in binary:
+//
+//	OFFSET  BITS  NAME     VALUE
+//	0       3     version  5 (101)
+func (f *FieldRecorder[T]) WriteLayout(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "OFFSET\tBITS\tNAME\tVALUE")
+	for _, fd := range f.fields {
+		fmt.Fprintf(tw, "%d\t%d\t%s\t%d (%0*b)\n", fd.Offset, fd.Bits, fd.Name, fd.Value, max(fd.Bits, 1), fd.Value)
+	}
+	return tw.Flush()
+}
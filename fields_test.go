@@ -0,0 +1,60 @@
+package bitstream
+
+import (
+	"encoding/json"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestFieldRecorder(t *testing.T) {
+	reader := NewBitReader([]uint8{0b10100100, 0b11000000}, 0, 0)
+	f := NewFieldRecorder(reader)
+	f.Field("version", 3)
+	f.FieldFunc("length", (*BitReader[uint8]).ReadUE)
+	f.Reader().Skip(1)
+	f.Field("flag", 1)
+	if _, err := f.Field("payload", 8); err != io.ErrUnexpectedEOF {
+		t.Errorf("Field() past the end = %v; want io.ErrUnexpectedEOF", err)
+	}
+
+	t.Run("Fields", func(t *testing.T) {
+		want := []Field{
+			{Name: "version", Offset: 0, Bits: 3, Value: 0b101},
+			{Name: "length", Offset: 3, Bits: 5, Value: 3},
+			{Name: "flag", Offset: 9, Bits: 1, Value: 1},
+		}
+		if got := f.Fields(); !slices.Equal(got, want) {
+			t.Errorf("Fields() = %v; want %v", got, want)
+		}
+	})
+
+	t.Run("MarshalJSON", func(t *testing.T) {
+		b, err := json.Marshal(f)
+		if err != nil {
+			t.Fatalf("json.Marshal() returned error: %v", err)
+		}
+		want := `[{"name":"version","offset":0,"bits":3,"value":5},{"name":"length","offset":3,"bits":5,"value":3},{"name":"flag","offset":9,"bits":1,"value":1}]`
+		if string(b) != want {
+			t.Errorf("json.Marshal() = %s; want %s", b, want)
+		}
+		if b, _ := json.Marshal(NewFieldRecorder(reader)); string(b) != "[]" {
+			t.Errorf("json.Marshal() of an empty recorder = %s; want []", b)
+		}
+	})
+
+	t.Run("WriteLayout", func(t *testing.T) {
+		var sb strings.Builder
+		if err := f.WriteLayout(&sb); err != nil {
+			t.Fatalf("WriteLayout() returned error: %v", err)
+		}
+		want := "OFFSET  BITS  NAME     VALUE\n" +
+			"0       3     version  5 (101)\n" +
+			"3       5     length   3 (00011)\n" +
+			"9       1     flag     1 (1)\n"
+		if sb.String() != want {
+			t.Errorf("WriteLayout() wrote\n%s\nwant\n%s", sb.String(), want)
+		}
+	})
+}
@@ -0,0 +1,84 @@
+package bitstream
+
+// Fill selects the bits a BitWriter writes where the data itself has none: the padding
+// bits of each element, the bits added by AlignTo and AlignToByte, the unused end of the
+// last element, and gaps left by writing beyond the end with WriteBitsAt.
+type Fill int
+
+const (
+	// FillZeros fills with 0 bits, the default.
+	FillZeros Fill = iota
+	// FillOnes fills with 1 bits, as fax (T.4) EOL padding and erased flash require.
+	FillOnes
+	// FillAlternating fills with the pattern 1010..., aligned so that an element whose
+	// bits are all fill reads as 0xAA... (the first bit of each element is 1).
+	FillAlternating
+)
+
+// SetFill sets the bits used for padding and alignment from now on, and rewrites the
+// padding bits of the elements already written and the unused bits after the last valid
+// bit to match.
+//
+// Panics if f is not one of the Fill constants.
+func (w *BitWriter[T]) SetFill(f Fill) {
+	if f < FillZeros || f > FillAlternating {
+		panic("bitstream: invalid fill")
+	}
+	w.lock()
+	defer w.unlock()
+	w.fill = f
+	if len(w.data) == 0 {
+		return
+	}
+	valid := (w.msb<<1 - 1) >> w.rp << w.rp
+	e := fillElem[T](w.fill)
+	for i := range w.data {
+		w.data[i] = w.data[i]&valid | e&^valid
+	}
+	w.fillTail()
+}
+
+// fillTail overwrites the unused bits after the last valid bit with fill.
+func (w *BitWriter[T]) fillTail() {
+	end := len(w.data) * w.s
+	for pos := w.bits; pos < end; pos += 64 {
+		k := min(64, end-pos)
+		w.writeBitsAt(pos, k, w.fillBits(pos, k))
+	}
+}
+
+// Fill returns the fill set by SetFill.
+func (w *BitWriter[T]) Fill() Fill {
+	w.lock()
+	defer w.unlock()
+	return w.fill
+}
+
+// fillElem returns an element whose bits are all fill f.
+func fillElem[T Unsigned](f Fill) T {
+	switch f {
+	case FillOnes:
+		return ^T(0)
+	case FillAlternating:
+		pattern := uint64(0xAAAAAAAAAAAAAAAA)
+		return T(pattern)
+	}
+	return 0
+}
+
+// fillBits returns the fill for the bits bits at stream position pos, right-aligned,
+// for 1 <= bits <= 64.
+func (w *BitWriter[T]) fillBits(pos, bits int) uint64 {
+	switch w.fill {
+	case FillOnes:
+		return ^uint64(0) >> (64 - bits)
+	case FillAlternating:
+		var v uint64
+		for i := range bits {
+			// bit 0 of each element, counted from the most significant, is 1
+			v = v<<1 | uint64(^(w.lp+(pos+i)%w.s)&1)
+		}
+		return v
+	}
+	return 0
+}
@@ -0,0 +1,53 @@
+package bitstream
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSetFill(t *testing.T) {
+	t.Run("Ones", func(t *testing.T) {
+		writer := NewBitWriter[uint8](1, 1)
+		writer.SetFill(FillOnes)
+		writer.WriteBits(0b000, 3)
+		writer.AlignTo(4)
+		writer.WriteBits(0b01, 2)
+		// 1 000 1 01 1: padding, 3 bits, alignment fill, 2 bits, padding
+		if got, want := writer.Data(), []uint8{0b1000_1011}; !slices.Equal(got, want) {
+			t.Errorf("Data() = %08b; want %08b", got, want)
+		}
+		if writer.Bits() != 6 || writer.Fill() != FillOnes {
+			t.Errorf("Bits(), Fill() = %d, %v; want 6, FillOnes", writer.Bits(), writer.Fill())
+		}
+		reader := NewBitReader(writer.Data(), 1, 1)
+		if v, _ := reader.ReadBits(6); v != 0b000_1_01 {
+			t.Errorf("ReadBits(6) = %06b; want 000101", v)
+		}
+	})
+
+	t.Run("Alternating", func(t *testing.T) {
+		writer := NewBitWriter[uint16](0, 0)
+		writer.SetFill(FillAlternating)
+		writer.WriteBits(0b11, 2)
+		writer.AlignToByte()
+		writer.WriteBitsAt(24, 4, 0)
+		// 11 101010 (alignment) 10101010 (gap) | 10101010 (gap) 0000 1010 (unused end)
+		if got, want := writer.Data(), []uint16{0xEAAA, 0xAA0A}; !slices.Equal(got, want) {
+			t.Errorf("Data() = %04x; want %04x", got, want)
+		}
+	})
+
+	t.Run("Existing", func(t *testing.T) {
+		writer := NewBitWriter[uint8](0, 2)
+		writer.WriteBits(0b1, 1)
+		writer.SetFill(FillOnes)
+		if got := writer.Data(); got[0] != 0b1111_1111 {
+			t.Errorf("Data() after SetFill = %08b; want 11111111", got[0])
+		}
+		writer.WriteBits(0, 6)
+		writer.SetFill(FillZeros)
+		if got := writer.Data(); got[0] != 0b1000_0000 || len(got) != 2 || got[1] != 0 {
+			t.Errorf("Data() after SetFill(FillZeros) = %08b; want [10000000 00000000]", got)
+		}
+	})
+}
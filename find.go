@@ -0,0 +1,57 @@
+package bitstream
+
+import "io"
+
+// Find returns the position of the first occurrence of the low patternBits bits of pattern
+// at or after from, at any bit alignment, such as a sync word or a start code.
+// It does not move the cursor.
+// Returns -1 and io.EOF if the pattern does not occur in the valid bits,
+// and -1 and ErrNegativePosition for a negative from.
+//
+// Panics if patternBits < 1 or patternBits > 64.
+func (r *BitReader[T]) Find(pattern uint64, patternBits int, from int) (int, error) {
+	if patternBits < 1 || patternBits > 64 {
+		panic("bitstream: pattern length must be between 1 and 64")
+	}
+	if from < 0 {
+		return -1, ErrNegativePosition
+	}
+	if from+patternBits > r.bits {
+		return -1, io.EOF
+	}
+	mask := ^uint64(0) >> (64 - patternBits)
+	pattern &= mask
+	win := r.bitsAt(from, patternBits)
+	if win == pattern {
+		return from, nil
+	}
+	// Shift the following bits into the window one at a time, loading them 64 at a time.
+	for next := from + patternBits; next < r.bits; {
+		k := min(64, r.bits-next)
+		chunk := r.bitsAt(next, k)
+		for i := k - 1; i >= 0; i-- {
+			win = (win<<1 | chunk>>i&1) & mask
+			if win == pattern {
+				return next + k - i - patternBits, nil
+			}
+		}
+		next += k
+	}
+	return -1, io.EOF
+}
+
+// Resync advances the cursor to just after the next occurrence of the low bits bits of
+// syncWord at or after the current position, and returns the number of bits skipped
+// before the marker. This recovers from corrupted data by resuming at the next frame.
+// Returns 0 and io.EOF if no marker follows; the cursor is not moved in that case.
+//
+// Panics if bits < 1 or bits > 64.
+func (r *BitReader[T]) Resync(syncWord uint64, bits int) (int, error) {
+	pos, err := r.Find(syncWord, bits, r.pos)
+	if err != nil {
+		return 0, err
+	}
+	skipped := pos - r.pos
+	r.pos = pos + bits
+	return skipped, nil
+}
@@ -0,0 +1,109 @@
+package bitstream
+
+import (
+	"io"
+	"testing"
+)
+
+func TestFind(t *testing.T) {
+	t.Run("StartCode", func(t *testing.T) {
+		// an MPEG start code 0x000001 placed 3 bits into the stream
+		writer := NewBitWriter[uint8](0, 0)
+		writer.WriteBits(0b111, 3)
+		writer.WriteBits(0x000001, 24)
+		writer.WriteBits(0xB3, 8)
+		writer.WriteBits(0x000001, 24)
+		reader := NewBitReader(writer.Data(), 0, 0)
+		reader.SetBits(writer.Bits())
+
+		pos, err := reader.Find(0x000001, 24, 0)
+		if pos != 3 || err != nil {
+			t.Errorf("Find(0x000001, 24, 0) = %d, %v; want 3, nil", pos, err)
+		}
+		pos, err = reader.Find(0x000001, 24, 4)
+		if pos != 35 || err != nil {
+			t.Errorf("Find(0x000001, 24, 4) = %d, %v; want 35, nil", pos, err)
+		}
+		pos, err = reader.Find(0x000001, 24, 36)
+		if pos != -1 || err != io.EOF {
+			t.Errorf("Find(0x000001, 24, 36) = %d, %v; want -1, io.EOF", pos, err)
+		}
+		if reader.Pos() != 0 {
+			t.Errorf("Pos() = %d; want 0", reader.Pos())
+		}
+	})
+
+	t.Run("Padded", func(t *testing.T) {
+		writer := NewBitWriter[uint16](2, 3)
+		for range 20 {
+			writer.WriteBits(0, 7)
+		}
+		writer.WriteBits(0b1011, 4)
+		writer.WriteBits(0, 64)
+		writer.WriteBits(0, 36)
+		reader := NewBitReader(writer.Data(), 2, 3)
+		for _, n := range []int{4, 20, 64} {
+			pattern := uint64(0b1011) << (n - 4)
+			if pos, err := reader.Find(pattern, n, 0); pos != 140 || err != nil {
+				t.Errorf("Find(%d bits) = %d, %v; want 140, nil", n, pos, err)
+			}
+		}
+	})
+
+	t.Run("Long", func(t *testing.T) {
+		data := make([]uint64, 40)
+		data[37] = 0x0000_0000_0000_0F00
+		reader := NewBitReader(data, 0, 0)
+		if pos, err := reader.Find(0xF, 4, 0); pos != 37*64+52 || err != nil {
+			t.Errorf("Find(0xF, 4, 0) = %d, %v; want %d, nil", pos, err, 37*64+52)
+		}
+		if pos, err := reader.Find(0xF, 4, 37*64+53); pos != -1 || err != io.EOF {
+			t.Errorf("Find past match = %d, %v; want -1, io.EOF", pos, err)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		reader := NewBitReader([]uint8{0xFF}, 0, 0)
+		if pos, err := reader.Find(1, 1, -1); pos != -1 || err != ErrNegativePosition {
+			t.Errorf("Find(-1) = %d, %v; want -1, ErrNegativePosition", pos, err)
+		}
+		if pos, err := reader.Find(0x1FF, 9, 0); pos != -1 || err != io.EOF {
+			t.Errorf("Find(9 bits) = %d, %v; want -1, io.EOF", pos, err)
+		}
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("expected panic for pattern length 0")
+			}
+		}()
+		reader.Find(0, 0, 0)
+	})
+}
+
+func TestResync(t *testing.T) {
+	const sync = 0b1111_1111_1110 // 12-bit frame sync
+	writer := NewBitWriter[uint32](0, 0)
+	writer.WriteBits(sync, 12)
+	writer.WriteBits(0xAB, 8)
+	writer.WriteBits(0b0101101, 7) // garbage
+	writer.WriteBits(sync, 12)
+	writer.WriteBits(0xCD, 8)
+	reader := NewBitReader(writer.Data(), 0, 0)
+	reader.SetBits(writer.Bits())
+
+	skipped, err := reader.Resync(sync, 12)
+	if skipped != 0 || err != nil || reader.Pos() != 12 {
+		t.Errorf("Resync() = %d, %v at %d; want 0, nil at 12", skipped, err, reader.Pos())
+	}
+	reader.Skip(2) // lose sync inside the payload
+	skipped, err = reader.Resync(sync, 12)
+	if skipped != 13 || err != nil || reader.Pos() != 39 {
+		t.Errorf("Resync() = %d, %v at %d; want 13, nil at 39", skipped, err, reader.Pos())
+	}
+	if v, _ := reader.ReadBits(8); v != 0xCD {
+		t.Errorf("ReadBits(8) after Resync() = %#x; want 0xcd", v)
+	}
+	skipped, err = reader.Resync(sync, 12)
+	if skipped != 0 || err != io.EOF || reader.Pos() != 47 {
+		t.Errorf("Resync() at end = %d, %v at %d; want 0, io.EOF at 47", skipped, err, reader.Pos())
+	}
+}
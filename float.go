@@ -0,0 +1,124 @@
+package bitstream
+
+import "math"
+
+// ReadFloat32 reads 32 bits at the cursor as an IEEE-754 single-precision value and advances.
+// Errors are reported as for ReadBits.
+func (r *BitReader[T]) ReadFloat32() (float32, error) {
+	v, err := r.ReadBits(32)
+	return math.Float32frombits(uint32(v)), err
+}
+
+// ReadFloat64 reads 64 bits at the cursor as an IEEE-754 double-precision value and advances.
+// Errors are reported as for ReadBits.
+func (r *BitReader[T]) ReadFloat64() (float64, error) {
+	v, err := r.ReadBits(64)
+	return math.Float64frombits(v), err
+}
+
+// ReadMinifloat reads a reduced-precision IEEE-754 style float made of signBits (0 or 1)
+// sign bits, expBits exponent bits and mantBits mantissa bits, such as (1, 5, 10) for
+// half precision or (1, 8, 7) for bfloat16. The exponent bias is 2^(expBits-1)-1,
+// a zero exponent field encodes subnormals and an all-ones field encodes infinities and NaN.
+// Panics unless signBits is 0 or 1, expBits is 2..11 and mantBits is 0..52.
+// Errors are reported as for ReadBits.
+func (r *BitReader[T]) ReadMinifloat(signBits, expBits, mantBits int) (float64, error) {
+	checkMinifloat(signBits, expBits, mantBits)
+	v, err := r.ReadBits(signBits + expBits + mantBits)
+	if err != nil {
+		return 0, err
+	}
+	return decodeMinifloat(v, expBits, mantBits), nil
+}
+
+// WriteFloat32 writes the 32 bits of v in IEEE-754 single-precision format.
+func (w *BitWriter[T]) WriteFloat32(v float32) {
+	w.WriteBits(uint64(math.Float32bits(v)), 32)
+}
+
+// WriteFloat64 writes the 64 bits of v in IEEE-754 double-precision format.
+func (w *BitWriter[T]) WriteFloat64(v float64) {
+	w.WriteBits(math.Float64bits(v), 64)
+}
+
+// WriteMinifloat writes v in the reduced-precision format described for ReadMinifloat,
+// rounding to nearest even. Values too large for the format become infinity, NaN becomes
+// a quiet NaN (infinity when mantBits is 0), and negative values become zero when signBits is 0.
+// Panics on invalid widths as ReadMinifloat does.
+func (w *BitWriter[T]) WriteMinifloat(v float64, signBits, expBits, mantBits int) {
+	checkMinifloat(signBits, expBits, mantBits)
+	u := encodeMinifloat(v, expBits, mantBits)
+	if signBits == 0 {
+		if math.Signbit(v) && !math.IsNaN(v) {
+			u = 0
+		}
+	} else if math.Signbit(v) {
+		u |= 1 << (expBits + mantBits)
+	}
+	w.WriteBits(u, signBits+expBits+mantBits)
+}
+
+func checkMinifloat(signBits, expBits, mantBits int) {
+	if signBits < 0 || signBits > 1 || expBits < 2 || expBits > 11 || mantBits < 0 || mantBits > 52 {
+		panic("bitstream: invalid minifloat format")
+	}
+}
+
+// decodeMinifloat converts the exponent and mantissa fields in the low bits of v,
+// with the sign bit above them if present.
+func decodeMinifloat(v uint64, expBits, mantBits int) float64 {
+	maxExp := uint64(1)<<expBits - 1
+	bias := int(maxExp >> 1)
+	m := v & (1<<mantBits - 1)
+	e := v >> mantBits & maxExp
+	neg := v>>(expBits+mantBits)&1 != 0
+	var f float64
+	switch e {
+	case maxExp:
+		if m != 0 {
+			return math.NaN()
+		}
+		f = math.Inf(1)
+	case 0:
+		f = math.Ldexp(float64(m), 1-bias-mantBits)
+	default:
+		f = math.Ldexp(float64(1<<mantBits|m), int(e)-bias-mantBits)
+	}
+	if neg {
+		f = -f
+	}
+	return f
+}
+
+// encodeMinifloat returns the exponent and mantissa fields for the magnitude of v.
+func encodeMinifloat(v float64, expBits, mantBits int) uint64 {
+	maxExp := uint64(1)<<expBits - 1
+	bias := int(maxExp >> 1)
+	inf := maxExp << mantBits
+	switch {
+	case math.IsNaN(v):
+		if mantBits == 0 {
+			return inf
+		}
+		return inf | 1<<(mantBits-1)
+	case math.IsInf(v, 0):
+		return inf
+	}
+	a := math.Abs(v)
+	if a == 0 {
+		return 0
+	}
+	_, exp := math.Frexp(a)
+	e := exp - 1 + bias
+	if e < 1 {
+		// Subnormal: a multiple of the smallest step; rounding up to 1<<mantBits
+		// carries into the exponent field as the smallest normal.
+		return uint64(math.RoundToEven(math.Ldexp(a, bias-1+mantBits)))
+	}
+	m := uint64(math.RoundToEven(math.Ldexp(a, mantBits-(exp-1))))
+	u := uint64(e)<<mantBits + m - 1<<mantBits
+	if u >= inf {
+		return inf
+	}
+	return u
+}
@@ -0,0 +1,125 @@
+package bitstream
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFloat(t *testing.T) {
+	t.Run("Float32And64", func(t *testing.T) {
+		writer := NewBitWriter[uint16](0, 0)
+		writer.WriteBits(1, 3)
+		writer.WriteFloat32(-1.5)
+		writer.WriteFloat64(math.Pi)
+		writer.WriteFloat64(math.Inf(-1))
+		reader := NewBitReader(writer.Data(), 0, 0)
+		reader.SetBits(3 + 32 + 64 + 64)
+		reader.Skip(3)
+		if v, err := reader.ReadFloat32(); v != -1.5 || err != nil {
+			t.Errorf("ReadFloat32() = %v, %v; want -1.5, nil", v, err)
+		}
+		if v, err := reader.ReadFloat64(); v != math.Pi || err != nil {
+			t.Errorf("ReadFloat64() = %v, %v; want Pi, nil", v, err)
+		}
+		if v, err := reader.ReadFloat64(); !math.IsInf(v, -1) || err != nil {
+			t.Errorf("ReadFloat64() = %v, %v; want -Inf, nil", v, err)
+		}
+		if _, err := reader.ReadFloat32(); err == nil {
+			t.Error("ReadFloat32() at end error = nil; want non-nil")
+		}
+	})
+
+	t.Run("Half", func(t *testing.T) {
+		tests := []struct {
+			v    float64
+			bits uint64
+			back float64
+		}{
+			{1, 0x3C00, 1},
+			{-2, 0xC000, -2},
+			{0, 0x0000, 0},
+			{math.Copysign(0, -1), 0x8000, 0},
+			{65504, 0x7BFF, 65504},
+			{65520, 0x7C00, math.Inf(1)},
+			{math.Inf(-1), 0xFC00, math.Inf(-1)},
+			{math.Ldexp(1, -24), 0x0001, math.Ldexp(1, -24)},
+			{math.Ldexp(1, -26), 0x0000, 0},
+			{math.Ldexp(1023, -24), 0x03FF, math.Ldexp(1023, -24)},
+			{math.Ldexp(1, -14), 0x0400, math.Ldexp(1, -14)},
+			{1 + math.Ldexp(1, -11), 0x3C00, 1},
+			{1 + math.Ldexp(3, -11), 0x3C02, 1 + math.Ldexp(1, -9)},
+			{0.1, 0x2E66, 0.0999755859375},
+		}
+		for _, tt := range tests {
+			writer := NewBitWriter[uint8](0, 0)
+			writer.WriteMinifloat(tt.v, 1, 5, 10)
+			reader := NewBitReader(writer.Data(), 0, 0)
+			if got, _ := reader.PeekBits(16); got != tt.bits {
+				t.Errorf("WriteMinifloat(%v) = %#04x; want %#04x", tt.v, got, tt.bits)
+			}
+			if got, err := reader.ReadMinifloat(1, 5, 10); got != tt.back || err != nil {
+				t.Errorf("ReadMinifloat() of %#04x = %v, %v; want %v, nil", tt.bits, got, err, tt.back)
+			}
+		}
+	})
+
+	t.Run("NaN", func(t *testing.T) {
+		writer := NewBitWriter[uint8](0, 0)
+		writer.WriteMinifloat(math.NaN(), 1, 5, 10)
+		reader := NewBitReader(writer.Data(), 0, 0)
+		if got, _ := reader.PeekBits(16); got&0x7E00 != 0x7E00 {
+			t.Errorf("WriteMinifloat(NaN) = %#04x; want quiet NaN", got)
+		}
+		if got, _ := reader.ReadMinifloat(1, 5, 10); !math.IsNaN(got) {
+			t.Errorf("ReadMinifloat() = %v; want NaN", got)
+		}
+	})
+
+	t.Run("BFloat16", func(t *testing.T) {
+		for _, v := range []float64{1, -3.140625, 1e30} {
+			writer := NewBitWriter[uint8](0, 0)
+			writer.WriteMinifloat(v, 1, 8, 7)
+			reader := NewBitReader(writer.Data(), 0, 0)
+			want := uint64(math.Float32bits(float32(v))+0x7FFF+(math.Float32bits(float32(v))>>16&1)) >> 16
+			if got, _ := reader.PeekBits(16); got != want {
+				t.Errorf("WriteMinifloat(%v) = %#04x; want %#04x", v, got, want)
+			}
+		}
+	})
+
+	t.Run("Float64Layout", func(t *testing.T) {
+		for _, v := range []float64{math.Pi, -1e-310, math.MaxFloat64, math.SmallestNonzeroFloat64} {
+			writer := NewBitWriter[uint64](0, 0)
+			writer.WriteMinifloat(v, 1, 11, 52)
+			if got := writer.Data()[0]; got != math.Float64bits(v) {
+				t.Errorf("WriteMinifloat(%v) = %#x; want %#x", v, got, math.Float64bits(v))
+			}
+			reader := NewBitReader(writer.Data(), 0, 0)
+			if got, _ := reader.ReadMinifloat(1, 11, 52); got != v {
+				t.Errorf("ReadMinifloat() = %v; want %v", got, v)
+			}
+		}
+	})
+
+	t.Run("Unsigned", func(t *testing.T) {
+		writer := NewBitWriter[uint8](0, 0)
+		writer.WriteMinifloat(-5, 0, 4, 4)
+		writer.WriteMinifloat(3.25, 0, 4, 4)
+		reader := NewBitReader(writer.Data(), 0, 0)
+		if got, _ := reader.ReadMinifloat(0, 4, 4); got != 0 {
+			t.Errorf("ReadMinifloat() = %v; want 0", got)
+		}
+		if got, _ := reader.ReadMinifloat(0, 4, 4); got != 3.25 {
+			t.Errorf("ReadMinifloat() = %v; want 3.25", got)
+		}
+	})
+
+	t.Run("InvalidFormat", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("WriteMinifloat() with 12 exponent bits did not panic")
+			}
+		}()
+		NewBitWriter[uint8](0, 0).WriteMinifloat(1, 1, 12, 4)
+	})
+}
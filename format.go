@@ -0,0 +1,51 @@
+package bitstream
+
+import (
+	"fmt"
+	"io"
+)
+
+// Format implements fmt.Formatter.
+//
+// The %v verb shows the cursor position and a window of bits around it, with a caret
+// in front of the bit at Pos(), e.g.
+//
+//	pos 12/40: 10101100 1110^0011 11010010...
+//
+// The window spans 16 bits on each side of the cursor; the precision sets another span
+// (%.4v), and the '+' flag (%+v) shows the whole stream. Elided bits are marked with "...".
+// Bits are grouped by 8 counting from the start of the stream.
+// The %s verb prints String().
+func (r *BitReader[T]) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 's':
+		io.WriteString(f, r.String())
+	case 'v':
+		from, to := 0, r.bits
+		if !f.Flag('+') {
+			span, ok := f.Precision()
+			if !ok {
+				span = 16
+			}
+			from, to = max(r.pos-span, 0), min(r.pos+span, r.bits)
+		}
+		buf := fmt.Appendf(nil, "pos %d/%d: ", r.pos, r.bits)
+		if from > 0 {
+			buf = append(buf, "..."...)
+		}
+		buf = r.appendBits(buf, from, min(r.pos, to), 8)
+		if r.pos > from && r.pos%8 == 0 {
+			buf = append(buf, ' ')
+		}
+		buf = append(buf, '^')
+		if r.pos < to {
+			buf = r.appendBits(buf, r.pos, to, 8)
+		}
+		if to < r.bits {
+			buf = append(buf, "..."...)
+		}
+		f.Write(buf)
+	default:
+		fmt.Fprintf(f, "%%!%c(*bitstream.BitReader)", verb)
+	}
+}
@@ -0,0 +1,45 @@
+package bitstream
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestFormat(t *testing.T) {
+	reader := NewBitReader([]uint8{0b10101100, 0b11100011, 0b11010010, 0b00001111, 0b01010101}, 0, 0)
+	reader.Seek(12)
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"%v", "pos 12/40: 10101100 1110^0011 11010010 0000..."},
+		{"%.4v", "pos 12/40: ...1110^0011..."},
+		{"%.5v", "pos 12/40: ...0 1110^0011 1..."},
+		{"%+v", "pos 12/40: 10101100 1110^0011 11010010 00001111 01010101"},
+		{"%s", "10101100 11100011 11010010 00001111 01010101"},
+		{"%d", "%!d(*bitstream.BitReader)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			if got := fmt.Sprintf(tt.format, reader); got != tt.want {
+				t.Errorf("Sprintf(%q) = %q; want %q", tt.format, got, tt.want)
+			}
+		})
+	}
+
+	t.Run("boundaries", func(t *testing.T) {
+		reader.Seek(8)
+		if got, want := fmt.Sprintf("%.8v", reader), "pos 8/40: 10101100 ^11100011..."; got != want {
+			t.Errorf("Sprintf(%%.8v) = %q; want %q", got, want)
+		}
+		reader.Seek(0)
+		if got, want := fmt.Sprintf("%.4v", reader), "pos 0/40: ^1010..."; got != want {
+			t.Errorf("Sprintf(%%.4v) = %q; want %q", got, want)
+		}
+		reader.Seek(40)
+		if got, want := fmt.Sprintf("%.4v", reader), "pos 40/40: ...0101 ^"; got != want {
+			t.Errorf("Sprintf(%%.4v) = %q; want %q", got, want)
+		}
+	})
+}
@@ -0,0 +1,105 @@
+package bitstream
+
+import "io"
+
+// FrameFormat describes a simple framing protocol: each frame is the sync word, the payload
+// length in bits, the payload, and a CRC of the length field and payload.
+// A zero CRC model (Width 0) leaves the CRC out.
+type FrameFormat struct {
+	Sync       uint64   // Sync word marking the start of a frame
+	SyncBits   int      // Width of the sync word, 1 to 64
+	LengthBits int      // Width of the payload length field, 1 to 64
+	CRC        CRCModel // CRC over the length field and payload
+}
+
+func (f FrameFormat) check() {
+	if f.SyncBits < 1 || f.SyncBits > 64 || f.LengthBits < 1 || f.LengthBits > 64 || f.CRC.Width < 0 || f.CRC.Width > 64 {
+		panic("bitstream: invalid frame format")
+	}
+}
+
+// FrameWriter writes frames in a FrameFormat to a BitWriter.
+type FrameWriter[T Unsigned] struct {
+	w *BitWriter[T]
+	f FrameFormat
+}
+
+// NewFrameWriter returns a FrameWriter appending frames to w.
+//
+// Panics if a width in f is out of range.
+func NewFrameWriter[T Unsigned](w *BitWriter[T], f FrameFormat) *FrameWriter[T] {
+	f.check()
+	return &FrameWriter[T]{w: w, f: f}
+}
+
+// WriteFrame writes the remaining bits of payload, from its cursor, as one frame and advances
+// the cursor to the end. The frame is written under the writer's lock, so frames from several
+// goroutines do not interleave.
+// Returns ErrOverflow, writing nothing, if the payload length does not fit in the length field,
+// and ErrBudgetExceeded, writing nothing and leaving the cursor, if the frame would exceed the
+// limit set by NewBitWriterLimit.
+func (fw *FrameWriter[T]) WriteFrame(payload *BitReader[T]) error {
+	n := max(payload.bits-payload.pos, 0)
+	if fw.f.LengthBits < 64 && uint64(n)>>fw.f.LengthBits != 0 {
+		return ErrOverflow
+	}
+	w := fw.w
+	w.lock()
+	defer w.unlock()
+	if !w.reserveBits(fw.f.SyncBits + fw.f.LengthBits + n + fw.f.CRC.Width) {
+		return w.err
+	}
+	w.grow(fw.f.SyncBits + fw.f.LengthBits + n + fw.f.CRC.Width)
+	w.writeBits(fw.f.Sync, fw.f.SyncBits)
+	start := w.bits
+	w.writeBits(uint64(n), fw.f.LengthBits)
+	appendBits64(w, payload, payload.pos, payload.pos+n)
+	payload.pos += n
+	if fw.f.CRC.Width > 0 {
+		r := w.reader()
+		w.writeBits(r.CRC(fw.f.CRC, start, w.bits), fw.f.CRC.Width)
+	}
+	return nil
+}
+
+// FrameReader finds and validates frames in a FrameFormat from a BitReader.
+type FrameReader[T Unsigned] struct {
+	r *BitReader[T]
+	f FrameFormat
+}
+
+// NewFrameReader returns a FrameReader reading frames from the cursor of r.
+//
+// Panics if a width in f is out of range.
+func NewFrameReader[T Unsigned](r *BitReader[T], f FrameFormat) *FrameReader[T] {
+	f.check()
+	return &FrameReader[T]{r: r, f: f}
+}
+
+// Next skips to the next sync word and returns the payload of the frame after it as a reader
+// sharing the underlying data, leaving the cursor after the frame.
+// Returns io.EOF if no further sync word is found. If the frame is truncated (io.ErrUnexpectedEOF)
+// or its CRC does not match (ErrChecksum), the cursor is left just after the sync word,
+// so the next call resynchronizes on the following sync word.
+func (fr *FrameReader[T]) Next() (*BitReader[T], error) {
+	r, f := fr.r, fr.f
+	if _, err := r.Resync(f.Sync, f.SyncBits); err != nil {
+		return nil, err
+	}
+	start := r.pos
+	n, err := r.PeekBits(f.LengthBits)
+	if err != nil {
+		return nil, io.ErrUnexpectedEOF
+	}
+	avail := uint64(r.bits - start - f.LengthBits)
+	if n > avail || avail-n < uint64(f.CRC.Width) {
+		return nil, io.ErrUnexpectedEOF
+	}
+	from := start + f.LengthBits
+	to := from + int(n)
+	if f.CRC.Width > 0 && r.bitsAt(to, f.CRC.Width) != r.CRC(f.CRC, start, to) {
+		return nil, ErrChecksum
+	}
+	r.pos = to + f.CRC.Width
+	return r.Slice(from, to), nil
+}
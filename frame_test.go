@@ -0,0 +1,102 @@
+package bitstream
+
+import (
+	"io"
+	"testing"
+)
+
+func TestFrame(t *testing.T) {
+	format := FrameFormat{Sync: 0x7E7E, SyncBits: 16, LengthBits: 12, CRC: CRC16CCITT}
+	payloads := []string{"first", "", "third frame"}
+
+	writeFrames := func() *BitWriter[uint8] {
+		w := NewBitWriter[uint8](0, 0)
+		fw := NewFrameWriter(w, format)
+		w.WriteBits(0b101, 3) // leading noise
+		for _, p := range payloads {
+			pr := NewBitReader([]uint8(p), 0, 0)
+			if err := fw.WriteFrame(pr); err != nil {
+				t.Fatalf("WriteFrame(%q) error = %v; want nil", p, err)
+			}
+			if pr.Pos() != pr.Bits() {
+				t.Errorf("payload cursor = %d; want %d", pr.Pos(), pr.Bits())
+			}
+		}
+		return w
+	}
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		w := writeFrames()
+		if got, want := w.Bits(), 3+3*(16+12+16)+8*(5+0+11); got != want {
+			t.Errorf("Bits() = %d; want %d", got, want)
+		}
+		r := NewBitReader(w.Data(), 0, 0)
+		r.SetBits(w.Bits())
+		fr := NewFrameReader(r, format)
+		for _, want := range payloads {
+			p, err := fr.Next()
+			if err != nil {
+				t.Fatalf("Next() error = %v; want nil", err)
+			}
+			if got := string(p.ExtractBytes(0, p.Bits())); got != want {
+				t.Errorf("Next() payload = %q; want %q", got, want)
+			}
+		}
+		if _, err := fr.Next(); err != io.EOF {
+			t.Errorf("Next() at end error = %v; want io.EOF", err)
+		}
+	})
+
+	t.Run("Corrupted", func(t *testing.T) {
+		w := writeFrames()
+		// flip a payload bit of the first frame
+		pos := 3 + 16 + 12 + 9
+		bit, _ := NewBitReader(w.Data(), 0, 0).ReadBitAt(pos)
+		w.WriteBitAt(pos, !bit)
+		r := NewBitReader(w.Data(), 0, 0)
+		r.SetBits(w.Bits())
+		fr := NewFrameReader(r, format)
+		if _, err := fr.Next(); err != ErrChecksum || r.Pos() != 3+16 {
+			t.Errorf("Next() = %v at %d; want ErrChecksum at %d", err, r.Pos(), 3+16)
+		}
+		for _, want := range payloads[1:] {
+			p, err := fr.Next()
+			if err != nil || string(p.ExtractBytes(0, p.Bits())) != want {
+				t.Errorf("Next() after resync = %v; want %q", err, want)
+			}
+		}
+	})
+
+	t.Run("Truncated", func(t *testing.T) {
+		w := writeFrames()
+		r := NewBitReader(w.Data(), 0, 0)
+		r.SetBits(3 + 16 + 12 + 20)
+		fr := NewFrameReader(r, format)
+		if _, err := fr.Next(); err != io.ErrUnexpectedEOF {
+			t.Errorf("Next() on truncated frame error = %v; want io.ErrUnexpectedEOF", err)
+		}
+	})
+
+	t.Run("NoCRC", func(t *testing.T) {
+		f := FrameFormat{Sync: 0b1011, SyncBits: 4, LengthBits: 3}
+		w := NewBitWriter[uint16](1, 1)
+		fw := NewFrameWriter(w, f)
+		pr := NewBitReader([]uint16{0b1100_0000_0000_0000}, 0, 0)
+		pr.SetBits(5)
+		fw.WriteFrame(pr)
+		if got := w.String(); got != "10111011 1000" {
+			t.Errorf("WriteFrame() = %q; want \"10111011 1000\"", got)
+		}
+		pr.Seek(0)
+		pr.SetBits(8)
+		if err := fw.WriteFrame(pr); err != ErrOverflow || w.Bits() != 12 {
+			t.Errorf("WriteFrame() of 8 bits = %v with %d bits; want ErrOverflow with 12", err, w.Bits())
+		}
+		r := NewBitReader(w.Data(), 1, 1)
+		r.SetBits(w.Bits())
+		p, err := NewFrameReader(r, f).Next()
+		if err != nil || p.String() != "11000" {
+			t.Errorf("Next() = %v, %v; want 11000, nil", p, err)
+		}
+	})
+}
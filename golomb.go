@@ -0,0 +1,248 @@
+package bitstream
+
+import (
+	"io"
+	"math"
+	"math/bits"
+)
+
+// ReadUE reads an unsigned exponential-Golomb code (ue(v) in H.264/H.265) at the current
+// position and advances the cursor.
+// Returns io.EOF if no valid bits remain, io.ErrUnexpectedEOF if the code is truncated,
+// and ErrOverflow if the code has more than 63 leading zero bits.
+// The cursor is not moved on error.
+func (r *BitReader[T]) ReadUE() (uint64, error) {
+	start := r.pos
+	v, err := r.readUE()
+	if err != nil {
+		return 0, r.rewind(start, err)
+	}
+	return v, nil
+}
+
+// ReadSE reads a signed exponential-Golomb code (se(v) in H.264/H.265) at the current
+// position and advances the cursor.
+// Codes 0, 1, 2, 3, 4, ... map to values 0, 1, -1, 2, -2, ...
+// Errors are reported as for ReadUE.
+func (r *BitReader[T]) ReadSE() (int64, error) {
+	k, err := r.ReadUE()
+	if err != nil {
+		return 0, err
+	}
+	v := int64(k>>1) + int64(k&1)
+	if k&1 == 0 {
+		v = -v
+	}
+	return v, nil
+}
+
+// ReadRice reads a Rice code with parameter k at the current position and advances the cursor.
+// The code is the quotient v>>k in unary (that many zero bits followed by a one bit),
+// followed by the low k bits of v, as used by FLAC and Shorten residuals.
+// Returns io.EOF if no valid bits remain, io.ErrUnexpectedEOF if the code is truncated,
+// and ErrOverflow if the decoded value does not fit in 64 bits.
+// The cursor is not moved on error.
+//
+// Panics if k < 0 or k > 64.
+func (r *BitReader[T]) ReadRice(k int) (uint64, error) {
+	if k < 0 || k > 64 {
+		panic("bitstream: Rice parameter must be between 0 and 64")
+	}
+	start := r.pos
+	q, err := r.readUnary()
+	if err != nil {
+		return 0, r.rewind(start, err)
+	}
+	low, err := r.ReadBits(k)
+	if err != nil {
+		return 0, r.rewind(start, err)
+	}
+	if q > math.MaxUint64>>k {
+		return 0, r.rewind(start, ErrOverflow)
+	}
+	return q<<k | low, nil
+}
+
+// ReadGolomb reads a Golomb code with parameter m at the current position and advances the cursor.
+// The code is the quotient v/m in unary (that many zero bits followed by a one bit),
+// followed by the remainder v%m in truncated binary.
+// When m is a power of two this is the Rice code with k = log2(m).
+// Errors are reported as for ReadRice.
+//
+// Panics if m is 0.
+func (r *BitReader[T]) ReadGolomb(m uint64) (uint64, error) {
+	if m == 0 {
+		panic("bitstream: Golomb parameter must be positive")
+	}
+	start := r.pos
+	q, err := r.readUnary()
+	if err != nil {
+		return 0, r.rewind(start, err)
+	}
+	rem, err := r.readTruncated(m)
+	if err != nil {
+		return 0, r.rewind(start, err)
+	}
+	if q > (math.MaxUint64-rem)/m {
+		return 0, r.rewind(start, ErrOverflow)
+	}
+	return q*m + rem, nil
+}
+
+func (r *BitReader[T]) readUE() (uint64, error) {
+	lz := r.LeadingZeros()
+	if lz > 63 {
+		return 0, ErrOverflow
+	}
+	if r.pos+lz >= r.bits {
+		return 0, io.EOF
+	}
+	r.pos += lz + 1
+	v, err := r.ReadBits(lz)
+	if err != nil {
+		return 0, io.ErrUnexpectedEOF
+	}
+	return 1<<lz - 1 + v, nil
+}
+
+// readUnary counts zero bits up to and including the terminating one bit.
+func (r *BitReader[T]) readUnary() (uint64, error) {
+	q := r.LeadingZeros()
+	if r.pos+q >= r.bits {
+		return 0, io.EOF
+	}
+	r.pos += q + 1
+	return uint64(q), nil
+}
+
+// readTruncated reads a value in [0, m) encoded in truncated binary.
+func (r *BitReader[T]) readTruncated(m uint64) (uint64, error) {
+	b := bits.Len64(m - 1)
+	if b == 0 {
+		return 0, nil
+	}
+	u := uint64(1)<<b - m
+	x, err := r.ReadBits(b - 1)
+	if err != nil {
+		return 0, err
+	}
+	if x < u {
+		return x, nil
+	}
+	bit, err := r.ReadBit()
+	if err != nil {
+		return 0, err
+	}
+	x <<= 1
+	if bit {
+		x |= 1
+	}
+	return x - u, nil
+}
+
+// rewind moves the cursor back to start after a failed read.
+// An io.EOF hit after start is reported as io.ErrUnexpectedEOF.
+func (r *BitReader[T]) rewind(start int, err error) error {
+	r.pos = start
+	if start < r.bits {
+		return eofAsUnexpected(err)
+	}
+	return err
+}
+
+// WriteUE writes v as an unsigned exponential-Golomb code (ue(v) in H.264/H.265).
+// The code consists of n zero bits followed by the (n+1)-bit binary value of v+1.
+//
+// Panics if v is math.MaxUint64, which has no 64-bit exponential-Golomb representation.
+func (w *BitWriter[T]) WriteUE(v uint64) {
+	if v == math.MaxUint64 {
+		panic("bitstream: value out of range for exp-Golomb code")
+	}
+	w.lock()
+	defer w.unlock()
+	w.writeUE(v)
+}
+
+// WriteSE writes v as a signed exponential-Golomb code (se(v) in H.264/H.265).
+// Values 0, 1, -1, 2, -2, ... are written as codes 0, 1, 2, 3, 4, ...
+//
+// Panics if v is math.MinInt64, which has no 64-bit exponential-Golomb representation.
+func (w *BitWriter[T]) WriteSE(v int64) {
+	if v == math.MinInt64 {
+		panic("bitstream: value out of range for exp-Golomb code")
+	}
+	k := uint64(v)<<1 - 1
+	if v <= 0 {
+		k = uint64(-v) << 1
+	}
+	w.lock()
+	defer w.unlock()
+	w.writeUE(k)
+}
+
+// WriteRice writes v as a Rice code with parameter k:
+// the quotient v>>k in unary (that many zero bits followed by a one bit),
+// followed by the low k bits of v.
+//
+// Panics if k < 0 or k > 64.
+func (w *BitWriter[T]) WriteRice(k int, v uint64) {
+	if k < 0 || k > 64 {
+		panic("bitstream: Rice parameter must be between 0 and 64")
+	}
+	w.lock()
+	defer w.unlock()
+	if !w.reserveBits(riceLen(k, v)) {
+		return
+	}
+	var q uint64
+	if k < 64 {
+		q = v >> k
+	}
+	w.writeUnary(q)
+	w.writeBits(v, k)
+}
+
+// WriteGolomb writes v as a Golomb code with parameter m:
+// the quotient v/m in unary (that many zero bits followed by a one bit),
+// followed by the remainder v%m in truncated binary.
+//
+// Panics if m is 0.
+func (w *BitWriter[T]) WriteGolomb(m uint64, v uint64) {
+	if m == 0 {
+		panic("bitstream: Golomb parameter must be positive")
+	}
+	w.lock()
+	defer w.unlock()
+	if !w.reserveBits(golombLen(m, v)) {
+		return
+	}
+	w.writeUnary(v / m)
+	rem := v % m
+	b := bits.Len64(m - 1)
+	if b == 0 {
+		return
+	}
+	if u := uint64(1)<<b - m; rem < u {
+		w.writeBits(rem, b-1)
+	} else {
+		w.writeBits(rem+u, b)
+	}
+}
+
+func (w *BitWriter[T]) writeUE(v uint64) {
+	if !w.reserveBits(ueLen(v)) {
+		return
+	}
+	n := bits.Len64(v + 1)
+	w.writeBits(0, n-1)
+	w.writeBits(v+1, n)
+}
+
+// writeUnary writes q zero bits followed by a one bit.
+func (w *BitWriter[T]) writeUnary(q uint64) {
+	for ; q >= 64; q -= 64 {
+		w.writeBits(0, 64)
+	}
+	w.writeBits(0, int(q))
+	w.writeBits(1, 1)
+}
@@ -0,0 +1,201 @@
+package bitstream
+
+import (
+	"io"
+	"math"
+	"testing"
+)
+
+func TestExpGolomb(t *testing.T) {
+	t.Run("WriteUE", func(t *testing.T) {
+		tests := []struct {
+			v    uint64
+			code uint64
+			bits int
+		}{
+			{0, 0b1, 1},
+			{1, 0b010, 3},
+			{2, 0b011, 3},
+			{3, 0b00100, 5},
+			{6, 0b00111, 5},
+			{7, 0b0001000, 7},
+			{math.MaxUint64 - 1, math.MaxUint64, 127},
+		}
+		for _, tt := range tests {
+			writer := NewBitWriter[uint64](0, 0)
+			writer.WriteUE(tt.v)
+			if writer.Bits() != tt.bits {
+				t.Errorf("WriteUE(%d) wrote %d bits; want %d", tt.v, writer.Bits(), tt.bits)
+			}
+			reader := NewBitReader(writer.Data(), 0, 0)
+			reader.SetBits(writer.Bits())
+			reader.Skip(max(0, tt.bits-64))
+			if got, _ := reader.ReadBits(min(tt.bits, 64)); got != tt.code {
+				t.Errorf("WriteUE(%d) code = %b; want %b", tt.v, got, tt.code)
+			}
+		}
+	})
+
+	t.Run("ReadUE_ReadSE_roundTrip", func(t *testing.T) {
+		uvalues := []uint64{0, 1, 2, 3, 100, 1<<32 + 5, math.MaxUint64 - 1}
+		svalues := []int64{0, 1, -1, 2, -2, 1000, -1000, math.MaxInt64, math.MinInt64 + 1}
+		writer := NewBitWriter[uint16](1, 3)
+		for _, v := range uvalues {
+			writer.WriteUE(v)
+		}
+		for _, v := range svalues {
+			writer.WriteSE(v)
+		}
+		reader := NewBitReader(writer.Data(), 1, 3)
+		reader.SetBits(writer.Bits())
+		for _, want := range uvalues {
+			got, err := reader.ReadUE()
+			if err != nil || got != want {
+				t.Errorf("ReadUE() = %d, %v; want %d, nil", got, err, want)
+			}
+		}
+		for _, want := range svalues {
+			got, err := reader.ReadSE()
+			if err != nil || got != want {
+				t.Errorf("ReadSE() = %d, %v; want %d, nil", got, err, want)
+			}
+		}
+		if _, err := reader.ReadUE(); err != io.EOF {
+			t.Errorf("ReadUE() at end should return io.EOF, got %v", err)
+		}
+	})
+
+	t.Run("ReadUE_errors", func(t *testing.T) {
+		// truncated: 0001 followed by only two info bits
+		reader := NewBitReader([]uint8{0b00010100}, 0, 0)
+		reader.SetBits(6)
+		if _, err := reader.ReadUE(); err != io.ErrUnexpectedEOF {
+			t.Errorf("ReadUE() on truncated code should return io.ErrUnexpectedEOF, got %v", err)
+		}
+		if reader.Pos() != 0 {
+			t.Errorf("Pos() after failed ReadUE() = %d; want 0", reader.Pos())
+		}
+		reader = NewBitReader([]uint8{0, 0}, 0, 0)
+		if _, err := reader.ReadUE(); err != io.ErrUnexpectedEOF {
+			t.Errorf("ReadUE() on zero bits should return io.ErrUnexpectedEOF, got %v", err)
+		}
+		reader = NewBitReader(make([]uint8, 16), 0, 0)
+		if _, err := reader.ReadUE(); err != ErrOverflow {
+			t.Errorf("ReadUE() with 64 leading zeros should return ErrOverflow, got %v", err)
+		}
+	})
+
+	t.Run("WriteUE_panic", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("expected panic for math.MaxUint64")
+			}
+		}()
+		NewBitWriter[uint8](0, 0).WriteUE(math.MaxUint64)
+	})
+}
+
+func TestGolomb(t *testing.T) {
+	t.Run("WriteRice", func(t *testing.T) {
+		tests := []struct {
+			k    int
+			v    uint64
+			code uint64
+			bits int
+		}{
+			{0, 0, 0b1, 1},
+			{0, 3, 0b0001, 4},
+			{2, 0, 0b100, 3},
+			{2, 5, 0b0101, 4},
+			{2, 11, 0b00111, 5},
+			{4, 15, 0b11111, 5},
+		}
+		for _, tt := range tests {
+			writer := NewBitWriter[uint8](0, 0)
+			writer.WriteRice(tt.k, tt.v)
+			if writer.Bits() != tt.bits {
+				t.Errorf("WriteRice(%d, %d) wrote %d bits; want %d", tt.k, tt.v, writer.Bits(), tt.bits)
+			}
+			reader := NewBitReader(writer.Data(), 0, 0)
+			if got, _ := reader.ReadBits(tt.bits); got != tt.code {
+				t.Errorf("WriteRice(%d, %d) code = %b; want %b", tt.k, tt.v, got, tt.code)
+			}
+		}
+	})
+
+	t.Run("WriteGolomb", func(t *testing.T) {
+		// m = 5: b = 3, u = 3, remainders 0..2 use 2 bits, 3..4 use 3 bits (110, 111)
+		tests := []struct {
+			m    uint64
+			v    uint64
+			code uint64
+			bits int
+		}{
+			{5, 0, 0b100, 3},
+			{5, 2, 0b110, 3},
+			{5, 3, 0b1110, 4},
+			{5, 4, 0b1111, 4},
+			{5, 7, 0b0110, 4},
+			{1, 2, 0b001, 3},
+			{4, 6, 0b0110, 4},
+		}
+		for _, tt := range tests {
+			writer := NewBitWriter[uint8](0, 0)
+			writer.WriteGolomb(tt.m, tt.v)
+			if writer.Bits() != tt.bits {
+				t.Errorf("WriteGolomb(%d, %d) wrote %d bits; want %d", tt.m, tt.v, writer.Bits(), tt.bits)
+			}
+			reader := NewBitReader(writer.Data(), 0, 0)
+			if got, _ := reader.ReadBits(tt.bits); got != tt.code {
+				t.Errorf("WriteGolomb(%d, %d) code = %b; want %b", tt.m, tt.v, got, tt.code)
+			}
+		}
+	})
+
+	t.Run("roundTrip", func(t *testing.T) {
+		values := []uint64{0, 1, 7, 8, 100, 1000, 12345}
+		writer := NewBitWriter[uint32](3, 0)
+		for _, v := range values {
+			writer.WriteRice(3, v)
+			writer.WriteGolomb(10, v)
+		}
+		writer.WriteRice(64, math.MaxUint64)
+		writer.WriteGolomb(math.MaxUint64, math.MaxUint64-1)
+		reader := NewBitReader(writer.Data(), 3, 0)
+		reader.SetBits(writer.Bits())
+		for _, want := range values {
+			if got, err := reader.ReadRice(3); err != nil || got != want {
+				t.Errorf("ReadRice(3) = %d, %v; want %d, nil", got, err, want)
+			}
+			if got, err := reader.ReadGolomb(10); err != nil || got != want {
+				t.Errorf("ReadGolomb(10) = %d, %v; want %d, nil", got, err, want)
+			}
+		}
+		if got, err := reader.ReadRice(64); err != nil || got != math.MaxUint64 {
+			t.Errorf("ReadRice(64) = %d, %v; want %d, nil", got, err, uint64(math.MaxUint64))
+		}
+		if got, err := reader.ReadGolomb(math.MaxUint64); err != nil || got != math.MaxUint64-1 {
+			t.Errorf("ReadGolomb(MaxUint64) = %d, %v; want %d, nil", got, err, uint64(math.MaxUint64-1))
+		}
+		if _, err := reader.ReadRice(3); err != io.EOF {
+			t.Errorf("ReadRice(3) at end should return io.EOF, got %v", err)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		// unary terminator present but low bits truncated
+		reader := NewBitReader([]uint8{0b01000000}, 0, 0)
+		reader.SetBits(3)
+		if _, err := reader.ReadRice(4); err != io.ErrUnexpectedEOF {
+			t.Errorf("ReadRice(4) on truncated code should return io.ErrUnexpectedEOF, got %v", err)
+		}
+		if reader.Pos() != 0 {
+			t.Errorf("Pos() after failed ReadRice(4) = %d; want 0", reader.Pos())
+		}
+		// quotient 2 with k = 63 overflows
+		reader = NewBitReader(append([]uint8{0b00100000}, make([]uint8, 8)...), 0, 0)
+		if _, err := reader.ReadRice(63); err != ErrOverflow {
+			t.Errorf("ReadRice(63) with quotient 2 should return ErrOverflow, got %v", err)
+		}
+	})
+}
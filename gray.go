@@ -0,0 +1,21 @@
+package bitstream
+
+// ReadGray reads a bits-wide reflected binary Gray code at the cursor and returns it
+// converted to plain binary, as produced by rotary encoders and some ADCs.
+// Errors are reported as for ReadBits.
+func (r *BitReader[T]) ReadGray(bits int) (uint64, error) {
+	g, err := r.ReadBits(bits)
+	for s := 1; s < 64; s <<= 1 {
+		g ^= g >> s
+	}
+	return g, err
+}
+
+// WriteGray writes the low bits of data as a bits-wide reflected binary Gray code,
+// so that consecutive values differ in exactly one bit.
+func (w *BitWriter[T]) WriteGray(data uint64, bits int) {
+	if 0 < bits && bits < 64 {
+		data &= 1<<bits - 1
+	}
+	w.WriteBits(data^data>>1, bits)
+}
@@ -0,0 +1,55 @@
+package bitstream
+
+import (
+	"math/bits"
+	"testing"
+)
+
+func TestGray(t *testing.T) {
+	t.Run("Table", func(t *testing.T) {
+		codes := []uint64{0b000, 0b001, 0b011, 0b010, 0b110, 0b111, 0b101, 0b100}
+		writer := NewBitWriter[uint8](0, 0)
+		for v := range uint64(8) {
+			writer.WriteGray(v, 3)
+		}
+		reader := NewBitReader(writer.Data(), 0, 0)
+		for v, code := range codes {
+			if got, _ := reader.PeekBits(3); got != code {
+				t.Errorf("WriteGray(%d, 3) = %03b; want %03b", v, got, code)
+			}
+			if got, err := reader.ReadGray(3); got != uint64(v) || err != nil {
+				t.Errorf("ReadGray(3) = %d, %v; want %d, nil", got, err, v)
+			}
+		}
+	})
+
+	t.Run("Adjacent", func(t *testing.T) {
+		writer := NewBitWriter[uint64](0, 0)
+		for _, v := range []uint64{1<<40 - 1, 1 << 40, 1<<63 - 1, 1 << 63} {
+			writer.WriteGray(v, 64)
+		}
+		data := writer.Data()
+		if d := bits.OnesCount64(data[0] ^ data[1]); d != 1 {
+			t.Errorf("codes for 2^40-1 and 2^40 differ in %d bits; want 1", d)
+		}
+		if d := bits.OnesCount64(data[2] ^ data[3]); d != 1 {
+			t.Errorf("codes for 2^63-1 and 2^63 differ in %d bits; want 1", d)
+		}
+		reader := NewBitReader(data, 0, 0)
+		for _, want := range []uint64{1<<40 - 1, 1 << 40, 1<<63 - 1, 1 << 63} {
+			if got, _ := reader.ReadGray(64); got != want {
+				t.Errorf("ReadGray(64) = %#x; want %#x", got, want)
+			}
+		}
+	})
+
+	t.Run("HighBitsIgnored", func(t *testing.T) {
+		writer := NewBitWriter[uint8](0, 0)
+		writer.WriteGray(0xF5, 4)
+		reader := NewBitReader(writer.Data(), 0, 0)
+		reader.SetBits(4)
+		if got, _ := reader.ReadGray(4); got != 5 {
+			t.Errorf("ReadGray(4) = %d; want 5", got)
+		}
+	})
+}
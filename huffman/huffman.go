@@ -0,0 +1,334 @@
+// Package huffman implements canonical Huffman coding on top of bitstream readers and writers.
+//
+// An Encoder is built from symbol frequencies (or from code lengths) and writes codes
+// through anything with a WriteBits method, such as *bitstream.BitWriter.
+// A Decoder is built from the same code lengths and decodes symbols using a lookup table
+// indexed by PeekBits, falling back to a canonical bit-by-bit walk for long codes.
+package huffman
+
+import (
+	"container/heap"
+	"errors"
+	"io"
+	"sort"
+)
+
+// MaxCodeLength is the longest code length supported by Encoder and Decoder.
+const MaxCodeLength = 32
+
+const tableBits = 9
+
+var (
+	// ErrNoSymbols is returned when building a code with no used symbols.
+	ErrNoSymbols = errors.New("huffman: no symbols with nonzero frequency")
+	// ErrInvalidLengths is returned when code lengths do not describe a valid prefix code.
+	ErrInvalidLengths = errors.New("huffman: invalid code lengths")
+	// ErrInvalidSymbol is returned when encoding a symbol that has no code.
+	ErrInvalidSymbol = errors.New("huffman: symbol has no code")
+	// ErrInvalidCode is returned when the input does not match any code.
+	ErrInvalidCode = errors.New("huffman: invalid code")
+)
+
+// BitWriter is the interface used by Encoder to emit codes.
+// It is implemented by *bitstream.BitWriter.
+type BitWriter interface {
+	WriteBits(data uint64, bits int)
+}
+
+// BitReader is the interface used by Decoder to consume codes.
+// It is implemented by *bitstream.BitReader.
+type BitReader interface {
+	PeekBits(bits int) (uint64, error)
+	Skip(n int) error
+	Pos() int
+	Bits() int
+}
+
+// Encoder writes symbols as canonical Huffman codes.
+type Encoder struct {
+	lengths []uint8
+	codes   []uint32
+}
+
+// NewEncoder builds a length-limited canonical Huffman code from symbol frequencies.
+// freqs[i] is the frequency of symbol i; symbols with zero frequency get no code.
+// No code is longer than maxLen bits.
+// Returns ErrNoSymbols if every frequency is zero, and ErrInvalidLengths if maxLen is
+// out of range or too small to give every used symbol a code.
+func NewEncoder(freqs []uint64, maxLen int) (*Encoder, error) {
+	lengths, err := buildLengths(freqs, maxLen)
+	if err != nil {
+		return nil, err
+	}
+	return NewEncoderLengths(lengths)
+}
+
+// NewEncoderLengths builds a canonical Huffman encoder from code lengths,
+// for example lengths transmitted in a DEFLATE block header.
+// lengths[i] is the code length of symbol i, or 0 if the symbol is unused.
+// Returns ErrInvalidLengths if the lengths over-subscribe the code space.
+func NewEncoderLengths(lengths []uint8) (*Encoder, error) {
+	codes, err := canonicalCodes(lengths)
+	if err != nil {
+		return nil, err
+	}
+	return &Encoder{lengths: append([]uint8(nil), lengths...), codes: codes}, nil
+}
+
+// Lengths returns the code length of every symbol, suitable for NewDecoder.
+func (e *Encoder) Lengths() []uint8 {
+	return e.lengths
+}
+
+// Code returns the code of sym, right-aligned, and its length in bits.
+// The length is 0 for symbols without a code.
+func (e *Encoder) Code(sym int) (code uint64, length int) {
+	if sym < 0 || sym >= len(e.lengths) {
+		return 0, 0
+	}
+	return uint64(e.codes[sym]), int(e.lengths[sym])
+}
+
+// Encode writes the code of sym to w.
+// Returns ErrInvalidSymbol if sym has no code.
+func (e *Encoder) Encode(w BitWriter, sym int) error {
+	code, length := e.Code(sym)
+	if length == 0 {
+		return ErrInvalidSymbol
+	}
+	w.WriteBits(code, length)
+	return nil
+}
+
+// Decoder reads canonical Huffman codes.
+type Decoder struct {
+	table   []entry  // lookup table indexed by the next tableBits bits
+	bits    int      // number of bits used to index table
+	count   []int    // count[l] is the number of codes of length l
+	symbols []uint32 // symbols sorted by code
+	maxLen  int
+}
+
+type entry struct {
+	sym    uint32
+	length uint8 // 0 if the code is longer than the table index
+}
+
+// NewDecoder builds a canonical Huffman decoder from code lengths.
+// lengths[i] is the code length of symbol i, or 0 if the symbol is unused.
+// Incomplete codes are allowed; reading an unassigned code returns ErrInvalidCode.
+// Returns ErrInvalidLengths if the lengths over-subscribe the code space,
+// and ErrNoSymbols if no symbol has a code.
+func NewDecoder(lengths []uint8) (*Decoder, error) {
+	codes, err := canonicalCodes(lengths)
+	if err != nil {
+		return nil, err
+	}
+	d := &Decoder{count: make([]int, MaxCodeLength+1)}
+	for sym, l := range lengths {
+		if l > 0 {
+			d.count[l]++
+			d.symbols = append(d.symbols, uint32(sym))
+			d.maxLen = max(d.maxLen, int(l))
+		}
+	}
+	if len(d.symbols) == 0 {
+		return nil, ErrNoSymbols
+	}
+	// canonical order: by length, then by symbol
+	sort.SliceStable(d.symbols, func(i, j int) bool {
+		return lengths[d.symbols[i]] < lengths[d.symbols[j]]
+	})
+
+	d.bits = min(d.maxLen, tableBits)
+	d.table = make([]entry, 1<<d.bits)
+	for sym, l := range lengths {
+		if l == 0 || int(l) > d.bits {
+			continue
+		}
+		shift := d.bits - int(l)
+		start := int(codes[sym]) << shift
+		for i := range 1 << shift {
+			d.table[start+i] = entry{sym: uint32(sym), length: l}
+		}
+	}
+	return d, nil
+}
+
+// Decode reads one code from r and returns its symbol.
+// Returns io.EOF if r has no bits left, io.ErrUnexpectedEOF if the input ends
+// in the middle of a code, and ErrInvalidCode if the bits match no code.
+// The cursor of r is not moved on error.
+func (d *Decoder) Decode(r BitReader) (int, error) {
+	avail := r.Bits() - r.Pos()
+	if avail <= 0 {
+		return 0, io.EOF
+	}
+	n := min(d.bits, avail)
+	v, err := r.PeekBits(n)
+	if err != nil {
+		return 0, err
+	}
+	if e := d.table[v<<(d.bits-n)]; e.length > 0 {
+		if int(e.length) > n {
+			return 0, io.ErrUnexpectedEOF
+		}
+		r.Skip(int(e.length))
+		return int(e.sym), nil
+	}
+
+	// canonical decoding for codes longer than the table index
+	n = min(d.maxLen, avail)
+	v, err = r.PeekBits(n)
+	if err != nil {
+		return 0, err
+	}
+	code, first, index := 0, 0, 0
+	for l := 1; l <= n; l++ {
+		code |= int(v>>(n-l)) & 1
+		count := d.count[l]
+		if code-first < count {
+			r.Skip(l)
+			return int(d.symbols[index+code-first]), nil
+		}
+		index += count
+		first = (first + count) << 1
+		code <<= 1
+	}
+	if n < d.maxLen {
+		return 0, io.ErrUnexpectedEOF
+	}
+	return 0, ErrInvalidCode
+}
+
+// canonicalCodes assigns canonical codes to lengths as described in RFC 1951 section 3.2.2.
+func canonicalCodes(lengths []uint8) ([]uint32, error) {
+	var count [MaxCodeLength + 1]int
+	for _, l := range lengths {
+		if l > MaxCodeLength {
+			return nil, ErrInvalidLengths
+		}
+		count[l]++
+	}
+	count[0] = 0
+	// reject over-subscribed codes
+	left := 1
+	for l := 1; l <= MaxCodeLength; l++ {
+		left <<= 1
+		left -= count[l]
+		if left < 0 {
+			return nil, ErrInvalidLengths
+		}
+	}
+	var next [MaxCodeLength + 1]uint32
+	code := uint32(0)
+	for l := 1; l <= MaxCodeLength; l++ {
+		code = (code + uint32(count[l-1])) << 1
+		next[l] = code
+	}
+	codes := make([]uint32, len(lengths))
+	for sym, l := range lengths {
+		if l > 0 {
+			codes[sym] = next[l]
+			next[l]++
+		}
+	}
+	return codes, nil
+}
+
+// buildLengths computes Huffman code lengths no longer than maxLen.
+// When the optimal tree is too deep, frequencies are halved and the tree rebuilt,
+// which converges to a balanced tree.
+func buildLengths(freqs []uint64, maxLen int) ([]uint8, error) {
+	if maxLen < 1 || maxLen > MaxCodeLength {
+		return nil, ErrInvalidLengths
+	}
+	used := 0
+	for _, f := range freqs {
+		if f > 0 {
+			used++
+		}
+	}
+	if used == 0 {
+		return nil, ErrNoSymbols
+	}
+	if uint64(used) > uint64(1)<<maxLen {
+		return nil, ErrInvalidLengths
+	}
+	lengths := make([]uint8, len(freqs))
+	if used == 1 {
+		for i, f := range freqs {
+			if f > 0 {
+				lengths[i] = 1
+			}
+		}
+		return lengths, nil
+	}
+
+	f := append([]uint64(nil), freqs...)
+	for {
+		deepest := treeLengths(f, lengths)
+		if deepest <= maxLen {
+			return lengths, nil
+		}
+		for i := range f {
+			if f[i] > 0 {
+				f[i] = f[i]>>1 | 1
+			}
+		}
+	}
+}
+
+type node struct {
+	freq        uint64
+	sym         int // -1 for internal nodes
+	left, right *node
+}
+
+type nodeHeap []*node
+
+func (h nodeHeap) Len() int { return len(h) }
+func (h nodeHeap) Less(i, j int) bool {
+	if h[i].freq != h[j].freq {
+		return h[i].freq < h[j].freq
+	}
+	return h[i].sym < h[j].sym
+}
+func (h nodeHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+func (h *nodeHeap) Push(x any)   { *h = append(*h, x.(*node)) }
+func (h *nodeHeap) Pop() any {
+	old := *h
+	n := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return n
+}
+
+// treeLengths builds a Huffman tree over the nonzero frequencies, stores each
+// symbol's depth in lengths, and returns the maximum depth.
+func treeLengths(freqs []uint64, lengths []uint8) int {
+	h := nodeHeap{}
+	for i, f := range freqs {
+		if f > 0 {
+			h = append(h, &node{freq: f, sym: i})
+		}
+	}
+	heap.Init(&h)
+	for h.Len() > 1 {
+		a := heap.Pop(&h).(*node)
+		b := heap.Pop(&h).(*node)
+		heap.Push(&h, &node{freq: a.freq + b.freq, sym: -1, left: a, right: b})
+	}
+	deepest := 0
+	var walk func(n *node, depth int)
+	walk = func(n *node, depth int) {
+		if n.sym >= 0 {
+			lengths[n.sym] = uint8(min(depth, 255))
+			deepest = max(deepest, depth)
+			return
+		}
+		walk(n.left, depth+1)
+		walk(n.right, depth+1)
+	}
+	walk(h[0], 0)
+	return deepest
+}
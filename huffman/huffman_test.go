@@ -0,0 +1,162 @@
+package huffman
+
+import (
+	"io"
+	"testing"
+
+	"github.com/yyyoichi/bitstream-go"
+)
+
+func TestEncoder(t *testing.T) {
+	t.Run("canonicalCodes", func(t *testing.T) {
+		// RFC 1951 section 3.2.2 example: A-H with lengths (3, 3, 3, 3, 3, 2, 4, 4)
+		enc, err := NewEncoderLengths([]uint8{3, 3, 3, 3, 3, 2, 4, 4})
+		if err != nil {
+			t.Fatalf("NewEncoderLengths returned error: %v", err)
+		}
+		want := []struct {
+			code   uint64
+			length int
+		}{
+			{0b010, 3}, {0b011, 3}, {0b100, 3}, {0b101, 3},
+			{0b110, 3}, {0b00, 2}, {0b1110, 4}, {0b1111, 4},
+		}
+		for sym, w := range want {
+			code, length := enc.Code(sym)
+			if code != w.code || length != w.length {
+				t.Errorf("Code(%d) = %b/%d; want %b/%d", sym, code, length, w.code, w.length)
+			}
+		}
+	})
+
+	t.Run("NewEncoder", func(t *testing.T) {
+		enc, err := NewEncoder([]uint64{45, 13, 12, 16, 9, 5, 0}, 15)
+		if err != nil {
+			t.Fatalf("NewEncoder returned error: %v", err)
+		}
+		want := []uint8{1, 3, 3, 3, 4, 4, 0}
+		for sym, l := range enc.Lengths() {
+			if l != want[sym] {
+				t.Errorf("Lengths()[%d] = %d; want %d", sym, l, want[sym])
+			}
+		}
+		if err := enc.Encode(bitstream.NewBitWriter[uint8](0, 0), 6); err != ErrInvalidSymbol {
+			t.Errorf("Encode of unused symbol should return ErrInvalidSymbol, got %v", err)
+		}
+	})
+
+	t.Run("NewEncoder_lengthLimit", func(t *testing.T) {
+		// Fibonacci frequencies produce a maximally skewed tree.
+		freqs := []uint64{1, 1, 2, 3, 5, 8, 13, 21, 34, 55, 89, 144, 233, 377, 610, 987}
+		enc, err := NewEncoder(freqs, 7)
+		if err != nil {
+			t.Fatalf("NewEncoder returned error: %v", err)
+		}
+		kraft := 0.0
+		for sym, l := range enc.Lengths() {
+			if l == 0 || l > 7 {
+				t.Errorf("Lengths()[%d] = %d; want 1..7", sym, l)
+			}
+			kraft += 1 / float64(uint(1)<<l)
+		}
+		if kraft > 1 {
+			t.Errorf("Kraft sum = %v; want <= 1", kraft)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		if _, err := NewEncoder([]uint64{0, 0}, 15); err != ErrNoSymbols {
+			t.Errorf("NewEncoder with zero frequencies should return ErrNoSymbols, got %v", err)
+		}
+		if _, err := NewEncoder([]uint64{1, 1, 1, 1, 1}, 2); err != ErrInvalidLengths {
+			t.Errorf("NewEncoder with 5 symbols and maxLen 2 should return ErrInvalidLengths, got %v", err)
+		}
+		if _, err := NewEncoderLengths([]uint8{1, 1, 1}); err != ErrInvalidLengths {
+			t.Errorf("NewEncoderLengths with over-subscribed lengths should return ErrInvalidLengths, got %v", err)
+		}
+	})
+}
+
+func TestDecoder(t *testing.T) {
+	t.Run("roundTrip", func(t *testing.T) {
+		text := "this is an example of a huffman tree, encoded and decoded through a bit stream"
+		freqs := make([]uint64, 256)
+		for i := range len(text) {
+			freqs[text[i]]++
+		}
+		enc, err := NewEncoder(freqs, 12)
+		if err != nil {
+			t.Fatalf("NewEncoder returned error: %v", err)
+		}
+		dec, err := NewDecoder(enc.Lengths())
+		if err != nil {
+			t.Fatalf("NewDecoder returned error: %v", err)
+		}
+
+		writer := bitstream.NewBitWriter[uint16](3, 1)
+		for i := range len(text) {
+			enc.Encode(writer, int(text[i]))
+		}
+		reader := bitstream.NewBitReader(writer.Data(), 3, 1)
+		reader.SetBits(writer.Bits())
+		for i := range len(text) {
+			sym, err := dec.Decode(reader)
+			if err != nil || sym != int(text[i]) {
+				t.Fatalf("Decode() at %d = %q, %v; want %q, nil", i, sym, err, text[i])
+			}
+		}
+		if _, err := dec.Decode(reader); err != io.EOF {
+			t.Errorf("Decode() at end should return io.EOF, got %v", err)
+		}
+	})
+
+	t.Run("longCodes", func(t *testing.T) {
+		// Fibonacci frequencies give codes up to 15 bits, longer than the lookup table.
+		freqs := []uint64{1, 1, 2, 3, 5, 8, 13, 21, 34, 55, 89, 144, 233, 377, 610, 987}
+		enc, err := NewEncoder(freqs, 15)
+		if err != nil {
+			t.Fatalf("NewEncoder returned error: %v", err)
+		}
+		if _, length := enc.Code(0); length != 15 {
+			t.Fatalf("Code(0) length = %d; want 15", length)
+		}
+		dec, err := NewDecoder(enc.Lengths())
+		if err != nil {
+			t.Fatalf("NewDecoder returned error: %v", err)
+		}
+		writer := bitstream.NewBitWriter[uint64](0, 0)
+		for sym := range freqs {
+			enc.Encode(writer, sym)
+		}
+		reader := bitstream.NewBitReader(writer.Data(), 0, 0)
+		reader.SetBits(writer.Bits())
+		for want := range freqs {
+			if sym, err := dec.Decode(reader); err != nil || sym != want {
+				t.Errorf("Decode() = %d, %v; want %d, nil", sym, err, want)
+			}
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		// incomplete code: only 0 and 10 are assigned
+		dec, err := NewDecoder([]uint8{1, 2})
+		if err != nil {
+			t.Fatalf("NewDecoder returned error: %v", err)
+		}
+		reader := bitstream.NewBitReader([]uint8{0b11000000}, 0, 0)
+		if _, err := dec.Decode(reader); err != ErrInvalidCode {
+			t.Errorf("Decode() of unassigned code should return ErrInvalidCode, got %v", err)
+		}
+		reader.SetBits(1)
+		reader.Seek(0)
+		if _, err := dec.Decode(reader); err != io.ErrUnexpectedEOF {
+			t.Errorf("Decode() of truncated code should return io.ErrUnexpectedEOF, got %v", err)
+		}
+		if reader.Pos() != 0 {
+			t.Errorf("Pos() after failed Decode() = %d; want 0", reader.Pos())
+		}
+		if _, err := NewDecoder([]uint8{0, 0}); err != ErrNoSymbols {
+			t.Errorf("NewDecoder with no codes should return ErrNoSymbols, got %v", err)
+		}
+	})
+}
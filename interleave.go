@@ -0,0 +1,72 @@
+package bitstream
+
+// Interleave returns a new stream that takes width-bit symbols from each of rs in turn:
+// the first symbol of rs[0], the first of rs[1], ..., then the second of rs[0], and so on,
+// as in bit interleavers for channel coding or packed-pixel image planes. If the stream length
+// is not a multiple of width, the last symbol of each stream is shortened to what remains.
+// The result uses the padding of rs[0]; cursors are not moved, and the result's cursor starts at 0.
+// Returns ErrLengthMismatch if the readers have different numbers of valid bits.
+//
+// Panics if rs is empty or width < 1 or width > 64.
+func Interleave[T Unsigned](width int, rs ...*BitReader[T]) (*BitReader[T], error) {
+	if width < 1 || width > 64 {
+		panic("bitstream: symbol width must be between 1 and 64")
+	}
+	if len(rs) == 0 {
+		panic("bitstream: Interleave of no streams")
+	}
+	n := rs[0].bits
+	for _, r := range rs[1:] {
+		if r.bits != n {
+			return nil, ErrLengthMismatch
+		}
+	}
+	w := NewUnsyncBitWriter[T](rs[0].lp, rs[0].rp)
+	w.grow(n * len(rs))
+	for pos := 0; pos < n; pos += width {
+		k := min(width, n-pos)
+		for _, r := range rs {
+			w.writeBits(r.bitsAt(pos, k), k)
+		}
+	}
+	out := NewBitReader(w.data, rs[0].lp, rs[0].rp)
+	out.bits = w.bits
+	return out, nil
+}
+
+// Deinterleave splits the valid bits of r into n streams, undoing Interleave with the same
+// width: symbol i of the input goes to stream i mod n. The streams use the padding of r;
+// its cursor is not moved. Returns ErrLengthMismatch if the valid bits of r are not a multiple of n.
+//
+// Panics if n < 1 or width < 1 or width > 64.
+func Deinterleave[T Unsigned](r *BitReader[T], n, width int) ([]*BitReader[T], error) {
+	if width < 1 || width > 64 {
+		panic("bitstream: symbol width must be between 1 and 64")
+	}
+	if n < 1 {
+		panic("bitstream: Deinterleave into no streams")
+	}
+	if r.bits%n != 0 {
+		return nil, ErrLengthMismatch
+	}
+	size := r.bits / n
+	ws := make([]*BitWriter[T], n)
+	for i := range ws {
+		ws[i] = NewUnsyncBitWriter[T](r.lp, r.rp)
+		ws[i].grow(size)
+	}
+	pos := 0
+	for at := 0; at < size; at += width {
+		k := min(width, size-at)
+		for _, w := range ws {
+			w.writeBits(r.bitsAt(pos, k), k)
+			pos += k
+		}
+	}
+	out := make([]*BitReader[T], n)
+	for i, w := range ws {
+		out[i] = NewBitReader(w.data, r.lp, r.rp)
+		out[i].bits = size
+	}
+	return out, nil
+}
@@ -0,0 +1,67 @@
+package bitstream
+
+import "testing"
+
+func TestInterleave(t *testing.T) {
+	t.Run("Bits", func(t *testing.T) {
+		a := NewBitReader([]uint8{0b1111_0000}, 0, 0)
+		b := NewBitReader([]uint8{0b1010_1010}, 0, 0)
+		got, err := Interleave(1, a, b)
+		if err != nil {
+			t.Fatalf("Interleave() error = %v; want nil", err)
+		}
+		if s := got.String(); s != "11101110 01000100" {
+			t.Errorf("Interleave(1, a, b) = %q; want \"11101110 01000100\"", s)
+		}
+		parts, err := Deinterleave(got, 2, 1)
+		if err != nil {
+			t.Fatalf("Deinterleave() error = %v; want nil", err)
+		}
+		if !EqualRange(parts[0], a, 0, 0, 8) || !EqualRange(parts[1], b, 0, 0, 8) {
+			t.Errorf("Deinterleave() = %v, %v; want %v, %v", parts[0], parts[1], a, b)
+		}
+	})
+
+	t.Run("Symbols", func(t *testing.T) {
+		// three planes of 10 bits in 4-bit symbols: 4 + 4 + 2
+		planes := make([]*BitReader[uint16], 3)
+		for i := range planes {
+			w := NewBitWriter[uint16](1, 1)
+			w.WriteBits(uint64(0x155+i*0x7F)&0x3FF, 10)
+			planes[i] = NewBitReader(w.Data(), 1, 1)
+			planes[i].SetBits(10)
+		}
+		got, err := Interleave(4, planes...)
+		if err != nil {
+			t.Fatalf("Interleave() error = %v; want nil", err)
+		}
+		if got.Bits() != 30 {
+			t.Errorf("Bits() = %d; want 30", got.Bits())
+		}
+		for i, p := range planes {
+			for j, at := range []int{0, 4, 8} {
+				k := min(4, 10-at)
+				if !EqualRange(got, p, j*12+i*k, at, k) {
+					t.Errorf("symbol %d of plane %d not at %d", j, i, j*12+i*k)
+				}
+			}
+		}
+		parts, _ := Deinterleave(got, 3, 4)
+		for i, p := range parts {
+			if Compare(p, planes[i]) != 0 {
+				t.Errorf("Deinterleave()[%d] = %v; want %v", i, p, planes[i])
+			}
+		}
+	})
+
+	t.Run("LengthMismatch", func(t *testing.T) {
+		a := NewBitReader([]uint8{0xFF}, 0, 0)
+		b := NewBitReader([]uint8{0xFF, 0x00}, 0, 0)
+		if _, err := Interleave(1, a, b); err != ErrLengthMismatch {
+			t.Errorf("Interleave() error = %v; want ErrLengthMismatch", err)
+		}
+		if _, err := Deinterleave(b, 3, 1); err != ErrLengthMismatch {
+			t.Errorf("Deinterleave(16 bits, 3) error = %v; want ErrLengthMismatch", err)
+		}
+	})
+}
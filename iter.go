@@ -0,0 +1,64 @@
+package bitstream
+
+import "iter"
+
+// Values returns an iterator over the remaining bits, starting at the current position.
+// The cursor advances as bits are yielded, so breaking out of the loop leaves it just
+// after the last bit received.
+//
+//	for bit := range r.Values() {
+//		...
+//	}
+func (r *BitReader[T]) Values() iter.Seq[bool] {
+	return func(yield func(bool) bool) {
+		for r.pos < r.bits {
+			bit := r.readBitAt(r.pos)
+			r.pos++
+			if !yield(bit) {
+				return
+			}
+		}
+	}
+}
+
+// Chunks returns an iterator over the remaining bits in width-bit chunks, starting at the
+// current position. Each chunk is right-aligned (LSB-aligned). If the remaining bits are not
+// a multiple of width, the last chunk is padded with zero bits on the right, as with Read64R.
+// The cursor advances as chunks are yielded.
+//
+// Panics if width < 1 or width > 64.
+func (r *BitReader[T]) Chunks(width int) iter.Seq[uint64] {
+	if width < 1 || width > 64 {
+		panic("bitstream: chunk width must be between 1 and 64")
+	}
+	return func(yield func(uint64) bool) {
+		for r.pos < r.bits {
+			k := min(width, r.bits-r.pos)
+			v := r.bitsAt(r.pos, k) << (width - k)
+			r.pos += k
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Runs returns an iterator over the remaining bits as runs of identical bits, starting at the
+// current position. Each step yields the bit value and the length of its run; consecutive runs
+// always alternate in value. The cursor advances past each run as it is yielded.
+//
+//	for bit, n := range r.Runs() {
+//		...
+//	}
+func (r *BitReader[T]) Runs() iter.Seq2[bool, int] {
+	return func(yield func(bool, int) bool) {
+		for r.pos < r.bits {
+			bit := r.readBitAt(r.pos)
+			n := r.run(bit)
+			r.pos += n
+			if !yield(bit, n) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package bitstream
+
+import "testing"
+
+func TestIter(t *testing.T) {
+	t.Run("Values", func(t *testing.T) {
+		reader := NewBitReader([]uint8{0b10101100, 0b11100011}, 1, 1)
+		reader.Skip(2)
+		want := []bool{false, true, true, false, true, true, false, false, false, true}
+		var got []bool
+		for bit := range reader.Values() {
+			got = append(got, bit)
+		}
+		if len(got) != len(want) {
+			t.Fatalf("Values() yielded %d bits; want %d", len(got), len(want))
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("Values()[%d] = %v; want %v", i, got[i], want[i])
+			}
+		}
+		if reader.Pos() != 12 {
+			t.Errorf("Pos() after Values() = %d; want 12", reader.Pos())
+		}
+	})
+
+	t.Run("Values_break", func(t *testing.T) {
+		reader := NewBitReader([]uint8{0b00010000}, 0, 0)
+		for bit := range reader.Values() {
+			if bit {
+				break
+			}
+		}
+		if reader.Pos() != 4 {
+			t.Errorf("Pos() after break = %d; want 4", reader.Pos())
+		}
+	})
+
+	t.Run("Chunks", func(t *testing.T) {
+		reader := NewBitReader([]uint16{0b1010110011100011, 0b1100001111100000}, 0, 0)
+		reader.SetBits(30)
+		want := []uint64{0b101011001110, 0b001111000011, 0b111000000000}
+		var got []uint64
+		for chunk := range reader.Chunks(12) {
+			got = append(got, chunk)
+		}
+		if len(got) != len(want) {
+			t.Fatalf("Chunks(12) yielded %d chunks; want %d", len(got), len(want))
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("Chunks(12)[%d] = %012b; want %012b", i, got[i], want[i])
+			}
+		}
+		if reader.Pos() != 30 {
+			t.Errorf("Pos() after Chunks() = %d; want 30", reader.Pos())
+		}
+	})
+
+	t.Run("Chunks_panic", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("expected panic for width 65")
+			}
+		}()
+		NewBitReader([]uint8{0}, 0, 0).Chunks(65)
+	})
+
+	t.Run("Runs", func(t *testing.T) {
+		reader := NewBitReader([]uint16{0b0000111111111111, 0b1111000000000001}, 4, 0)
+		reader.Skip(1)
+		type run struct {
+			bit bool
+			n   int
+		}
+		want := []run{{true, 11}, {false, 11}, {true, 1}}
+		var got []run
+		for bit, n := range reader.Runs() {
+			got = append(got, run{bit, n})
+		}
+		if len(got) != len(want) {
+			t.Fatalf("Runs() yielded %d runs; want %d", len(got), len(want))
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("Runs()[%d] = %v; want %v", i, got[i], want[i])
+			}
+		}
+		if reader.Pos() != 24 {
+			t.Errorf("Pos() after Runs() = %d; want 24", reader.Pos())
+		}
+	})
+
+	t.Run("Runs_break", func(t *testing.T) {
+		reader := NewBitReader([]uint8{0b11100100}, 0, 0)
+		for bit := range reader.Runs() {
+			if !bit {
+				break
+			}
+		}
+		if reader.Pos() != 5 {
+			t.Errorf("Pos() after break = %d; want 5", reader.Pos())
+		}
+	})
+}
@@ -0,0 +1,66 @@
+package bitstream
+
+// JPEG entropy-coded segments (ITU T.81 F.1.2.3) escape every 0xFF data byte as 0xFF 0x00,
+// so that a 0xFF followed by anything else is a marker: a restart marker RSTn (0xD0-0xD7)
+// inside the scan, or the marker that ends it, possibly preceded by 0xFF fill bytes.
+
+// NewBitReaderJPEG creates a reader over the JPEG entropy-coded segment at the start of data,
+// reading each stuffed 0xFF 0x00 pair as a single 0xFF byte. The segment ends at the first
+// marker; end is its offset in data (the offset of its first 0xFF), or len(data) if there is
+// none, and marker is the marker code, or 0. To continue after a restart marker, create a new
+// reader from data[end+2:].
+func NewBitReaderJPEG(data []byte) (r *BitReader[uint8], end int, marker byte) {
+	end, marker = FindJPEGMarker(data, 0)
+	seg := make([]uint8, 0, end)
+	for i := 0; i < end; i++ {
+		seg = append(seg, data[i])
+		if data[i] == 0xFF {
+			i++ // the stuffed 0x00
+		}
+	}
+	return NewBitReader(seg, 0, 0), end, marker
+}
+
+// FindJPEGMarker returns the offset and code of the first marker at or after from,
+// skipping stuffed 0xFF 0x00 pairs. Fill bytes before a marker belong to it, so the offset
+// is that of the first 0xFF. Returns len(data) and 0 if there is no marker, including when
+// data ends in a lone 0xFF.
+func FindJPEGMarker(data []byte, from int) (offset int, marker byte) {
+	for i := max(from, 0); i < len(data)-1; i++ {
+		if data[i] != 0xFF {
+			continue
+		}
+		if data[i+1] == 0x00 {
+			i++
+			continue
+		}
+		j := i + 1
+		for j < len(data) && data[j] == 0xFF {
+			j++
+		}
+		if j == len(data) {
+			break
+		}
+		return i, data[j]
+	}
+	return len(data), 0
+}
+
+// BytesJPEG returns the written bits as a JPEG entropy-coded segment: packed into bytes,
+// with a final partial byte padded with 1 bits as T.81 requires, and a 0x00 stuffed after
+// every 0xFF byte. Markers are written to the output by the caller.
+func (w *BitWriter[T]) BytesJPEG() []byte {
+	w.lock()
+	defer w.unlock()
+	r := w.reader()
+	p := make([]byte, 0, (r.bits+7)/8)
+	for pos := 0; pos < r.bits; pos += 8 {
+		k := min(8, r.bits-pos)
+		b := byte(r.bitsAt(pos, k)<<(8-k)) | byte(0xFF>>k)
+		p = append(p, b)
+		if b == 0xFF {
+			p = append(p, 0x00)
+		}
+	}
+	return p
+}
@@ -0,0 +1,71 @@
+package bitstream
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+func TestJPEG(t *testing.T) {
+	t.Run("Reader", func(t *testing.T) {
+		data := []byte{0x12, 0xFF, 0x00, 0x34, 0xFF, 0xFF, 0xD0, 0x56, 0xFF, 0xD9}
+		reader, end, marker := NewBitReaderJPEG(data)
+		if end != 4 || marker != 0xD0 {
+			t.Errorf("NewBitReaderJPEG() end, marker = %d, %#x; want 4, 0xd0", end, marker)
+		}
+		if reader.Bits() != 24 {
+			t.Errorf("Bits() = %d; want 24", reader.Bits())
+		}
+		if v, _ := reader.ReadBits(24); v != 0x12FF34 {
+			t.Errorf("ReadBits(24) = %#x; want 0x12ff34", v)
+		}
+		if _, err := reader.ReadBits(1); err != io.EOF {
+			t.Errorf("ReadBits(1) at marker error = %v; want io.EOF", err)
+		}
+
+		reader, end, marker = NewBitReaderJPEG(data[end+3:])
+		if end != 1 || marker != 0xD9 || reader.Bits() != 8 {
+			t.Errorf("after restart: end, marker, bits = %d, %#x, %d; want 1, 0xd9, 8", end, marker, reader.Bits())
+		}
+	})
+
+	t.Run("FindJPEGMarker", func(t *testing.T) {
+		tests := []struct {
+			data   []byte
+			from   int
+			offset int
+			marker byte
+		}{
+			{[]byte{0xFF, 0x00, 0xFF, 0xD8}, 0, 2, 0xD8},
+			{[]byte{0xFF, 0xD8, 0xFF, 0xD9}, 1, 2, 0xD9},
+			{[]byte{0x00, 0xFF, 0x00}, 0, 3, 0},
+			{[]byte{0x00, 0xFF}, 0, 2, 0},
+			{[]byte{0xFF, 0xFF, 0xFF}, 0, 3, 0},
+		}
+		for _, tt := range tests {
+			offset, marker := FindJPEGMarker(tt.data, tt.from)
+			if offset != tt.offset || marker != tt.marker {
+				t.Errorf("FindJPEGMarker(%x, %d) = %d, %#x; want %d, %#x", tt.data, tt.from, offset, marker, tt.offset, tt.marker)
+			}
+		}
+	})
+
+	t.Run("BytesJPEG", func(t *testing.T) {
+		writer := NewBitWriter[uint16](0, 0)
+		writer.WriteBits(0xFF, 8)
+		writer.WriteBits(0x1, 4)
+		writer.WriteBits(0x7F, 7)
+		got := writer.BytesJPEG()
+		// 11111111 0001 1111111 -> FF 1F FF after padding with 1s
+		if want := []byte{0xFF, 0x00, 0x1F, 0xFF, 0x00}; !bytes.Equal(got, want) {
+			t.Errorf("BytesJPEG() = %x; want %x", got, want)
+		}
+		reader, end, _ := NewBitReaderJPEG(append(got, 0xFF, 0xD9))
+		if end != len(got) {
+			t.Errorf("end = %d; want %d", end, len(got))
+		}
+		if v, _ := reader.ReadBits(19); v != 0xFF<<11|0x1<<7|0x7F {
+			t.Errorf("ReadBits(19) = %#x; want %#x", v, 0xFF<<11|0x1<<7|0x7F)
+		}
+	})
+}
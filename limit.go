@@ -0,0 +1,85 @@
+package bitstream
+
+// LimitedReader reads from a BitReader but stops with io.EOF after a fixed number of bits,
+// like io.LimitedReader, for payloads whose length in bits is declared by a header.
+// Reads advance the cursor of the underlying reader, so after the payload has been parsed
+// (or skipped with Skip(Remaining())) the reader is positioned on what follows it.
+// A LimitedReader is not safe for concurrent use.
+type LimitedReader[T Unsigned] struct {
+	r *BitReader[T]
+	n int // bits remaining
+}
+
+// LimitBitReader returns a LimitedReader that reads at most n bits from the cursor of r.
+// A negative n is treated as 0.
+func LimitBitReader[T Unsigned](r *BitReader[T], n int) *LimitedReader[T] {
+	return &LimitedReader[T]{r: r, n: max(n, 0)}
+}
+
+// Remaining returns the number of bits that may still be read, which may be more than
+// the underlying reader holds.
+func (l *LimitedReader[T]) Remaining() int {
+	return l.n
+}
+
+// Do calls fn with a reader over the bits within the limit, starting at 0, then advances
+// the underlying cursor and reduces the limit by as far as fn moved that reader's cursor,
+// so any BitReader method such as ReadUE can be used without reading past the limit.
+// Returns the error of fn.
+func (l *LimitedReader[T]) Do(fn func(r *BitReader[T]) error) error {
+	from := min(l.r.pos, l.r.bits)
+	s := l.r.Slice(from, from+min(l.n, l.r.bits-from))
+	err := fn(s)
+	n := min(max(s.pos, 0), s.bits)
+	l.r.pos += n
+	l.n -= n
+	return err
+}
+
+// ReadBit reads one bit as BitReader.ReadBit does, returning io.EOF at the limit.
+func (l *LimitedReader[T]) ReadBit() (bit bool, err error) {
+	err = l.Do(func(r *BitReader[T]) error {
+		bit, err = r.ReadBit()
+		return err
+	})
+	return bit, err
+}
+
+// ReadBits reads bits bits as BitReader.ReadBits does, returning io.EOF at the limit and
+// io.ErrUnexpectedEOF if the read would cross it.
+//
+// Panics if bits > 64.
+func (l *LimitedReader[T]) ReadBits(bits int) (v uint64, err error) {
+	err = l.Do(func(r *BitReader[T]) error {
+		v, err = r.ReadBits(bits)
+		return err
+	})
+	return v, err
+}
+
+// PeekBits reads bits bits without moving the cursor, with the errors of ReadBits.
+//
+// Panics if bits > 64.
+func (l *LimitedReader[T]) PeekBits(bits int) (v uint64, err error) {
+	err = l.Do(func(r *BitReader[T]) error {
+		v, err = r.PeekBits(bits)
+		return err
+	})
+	return v, err
+}
+
+// Skip advances the cursor by n bits as BitReader.Skip does, stopping at the limit with io.EOF.
+func (l *LimitedReader[T]) Skip(n int) error {
+	return l.Do(func(r *BitReader[T]) error {
+		return r.Skip(n)
+	})
+}
+
+// Read implements io.Reader like BitReader.Read, treating the limit as the end of the stream.
+func (l *LimitedReader[T]) Read(p []byte) (n int, err error) {
+	err = l.Do(func(r *BitReader[T]) error {
+		n, err = r.Read(p)
+		return err
+	})
+	return n, err
+}
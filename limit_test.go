@@ -0,0 +1,62 @@
+package bitstream
+
+import (
+	"io"
+	"testing"
+)
+
+func TestLimitBitReader(t *testing.T) {
+	writer := NewBitWriter[uint16](0, 0)
+	writer.WriteBits(0xA, 4) // header
+	writer.WriteUE(3)        // payload: 00100
+	writer.WriteBits(0x5, 3) // payload: 101
+	writer.WriteBits(0x3, 2) // payload: 11
+	writer.WriteBits(0xC, 4) // trailer
+	reader := NewBitReader(writer.Data(), 0, 0)
+	reader.SetBits(writer.Bits())
+	reader.Skip(4)
+
+	l := LimitBitReader(reader, 10)
+	var ue uint64
+	l.Do(func(r *BitReader[uint16]) (err error) {
+		ue, err = r.ReadUE()
+		return err
+	})
+	if ue != 3 || l.Remaining() != 5 || reader.Pos() != 9 {
+		t.Errorf("ReadUE() = %d with %d remaining at %d; want 3 with 5 at 9", ue, l.Remaining(), reader.Pos())
+	}
+	if v, _ := l.PeekBits(3); v != 0x5 || l.Remaining() != 5 {
+		t.Errorf("PeekBits(3) = %d with %d remaining; want 5 with 5", v, l.Remaining())
+	}
+	if _, err := l.ReadBits(6); err != io.ErrUnexpectedEOF || l.Remaining() != 5 {
+		t.Errorf("ReadBits(6) error = %v with %d remaining; want io.ErrUnexpectedEOF with 5", err, l.Remaining())
+	}
+	if bit, _ := l.ReadBit(); !bit {
+		t.Errorf("ReadBit() = false; want true")
+	}
+	p := make([]byte, 4)
+	if n, err := l.Read(p); n != 1 || err != nil || p[0] != 0b0111_0000 {
+		t.Errorf("Read() = %d, %v, %08b; want 1, nil, 01110000", n, err, p[0])
+	}
+	if _, err := l.ReadBits(1); err != io.EOF {
+		t.Errorf("ReadBits(1) at limit error = %v; want io.EOF", err)
+	}
+	if v, _ := reader.ReadBits(4); v != 0xC {
+		t.Errorf("ReadBits(4) after payload = %#x; want 0xc", v)
+	}
+
+	t.Run("SkipPastEnd", func(t *testing.T) {
+		reader.Seek(14)
+		l := LimitBitReader(reader, 100)
+		if err := l.Skip(10); err != io.EOF || reader.Pos() != reader.Bits() {
+			t.Errorf("Skip(10) = %v at %d; want io.EOF at %d", err, reader.Pos(), reader.Bits())
+		}
+		if l.Remaining() != 96 {
+			t.Errorf("Remaining() = %d; want 96", l.Remaining())
+		}
+		reader.Seek(100)
+		if _, err := LimitBitReader(reader, 5).ReadBit(); err != io.EOF {
+			t.Errorf("ReadBit() past the end error = %v; want io.EOF", err)
+		}
+	})
+}
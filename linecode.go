@@ -0,0 +1,100 @@
+package bitstream
+
+import "io"
+
+// NRZIEncode converts the remaining bits of src to NRZI line levels appended to dst.
+// The line starts low (0); with zeroToggles false each 1 toggles the level and each 0 keeps it
+// (NRZ-M, as in HDLC links), and with zeroToggles true each 0 toggles it, as in USB.
+// The cursor of src is advanced to the end of its valid bits.
+func NRZIEncode[T, U Unsigned](src *BitReader[T], dst *BitWriter[U], zeroToggles bool) {
+	var level, z uint64
+	if zeroToggles {
+		z = 1
+	}
+	transform(src, dst, func(b uint64) uint64 {
+		level ^= b&1 ^ z
+		return level
+	})
+}
+
+// NRZIDecode reverses NRZIEncode with the same convention, reading line levels from the
+// cursor of src and appending the decoded bits to dst. The line is assumed to start low.
+func NRZIDecode[T, U Unsigned](src *BitReader[T], dst *BitWriter[U], zeroToggles bool) {
+	var prev, z uint64
+	if zeroToggles {
+		z = 1
+	}
+	transform(src, dst, func(b uint64) uint64 {
+		b &= 1
+		out := b ^ prev ^ z
+		prev = b
+		return out
+	})
+}
+
+// ManchesterConvention selects the pair of half-bit symbols used for each bit.
+type ManchesterConvention int
+
+const (
+	ManchesterIEEE   ManchesterConvention = iota // IEEE 802.3: 0 is 10 (high to low), 1 is 01
+	ManchesterThomas                             // G. E. Thomas: 0 is 01, 1 is 10
+)
+
+// ManchesterEncode appends each remaining bit of src to dst as two half-bit symbols
+// in convention c, doubling the length. The cursor of src is advanced to the end of its valid bits.
+func ManchesterEncode[T, U Unsigned](src *BitReader[T], dst *BitWriter[U], c ManchesterConvention) {
+	one := manchesterOne(c)
+	dst.lock()
+	defer dst.unlock()
+	dst.grow(2 * max(src.bits-src.pos, 0))
+	for src.pos < src.bits {
+		k := min(32, src.bits-src.pos)
+		in := src.bitsAt(src.pos, k)
+		var out uint64
+		for i := k - 1; i >= 0; i-- {
+			sym := one
+			if in>>i&1 == 0 {
+				sym ^= 0b11
+			}
+			out = out<<2 | sym
+		}
+		dst.writeBits(out, 2*k)
+		src.pos += k
+	}
+}
+
+// ManchesterDecode reverses ManchesterEncode with the same convention, reading symbol pairs
+// from the cursor of src and appending the decoded bits to dst.
+// Returns ErrInvalidFormat on a pair without a mid-bit transition (00 or 11), and
+// io.ErrUnexpectedEOF if a single half-bit remains; the bits before it have been written to dst
+// and the cursor of src is left on the offending pair.
+func ManchesterDecode[T, U Unsigned](src *BitReader[T], dst *BitWriter[U], c ManchesterConvention) error {
+	one := manchesterOne(c)
+	dst.lock()
+	defer dst.unlock()
+	dst.grow(max(src.bits-src.pos, 0) / 2)
+	for src.pos < src.bits {
+		if src.pos+2 > src.bits {
+			return io.ErrUnexpectedEOF
+		}
+		pair := src.bitsAt(src.pos, 2)
+		if pair == 0b00 || pair == 0b11 {
+			return ErrInvalidFormat
+		}
+		var b uint64
+		if pair == one {
+			b = 1
+		}
+		dst.writeBits(b, 1)
+		src.pos += 2
+	}
+	return nil
+}
+
+// manchesterOne returns the symbol pair for a 1 bit; a 0 bit is its complement.
+func manchesterOne(c ManchesterConvention) uint64 {
+	if c == ManchesterThomas {
+		return 0b10
+	}
+	return 0b01
+}
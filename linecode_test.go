@@ -0,0 +1,93 @@
+package bitstream
+
+import (
+	"io"
+	"testing"
+)
+
+func TestNRZI(t *testing.T) {
+	tests := []struct {
+		name        string
+		in          string
+		zeroToggles bool
+		levels      string
+	}{
+		{"NRZM", "10110001", false, "11011110"},
+		{"USB", "10110001", true, "01110100"},
+		{"Empty", "", false, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writer := NewBitWriter[uint8](0, 0)
+			NRZIEncode(bitsReader(tt.in), writer, tt.zeroToggles)
+			if got := bitsString(writer); got != tt.levels {
+				t.Errorf("NRZIEncode(%s) = %s; want %s", tt.in, got, tt.levels)
+			}
+			out := NewBitWriter[uint16](0, 0)
+			NRZIDecode(bitsReader(tt.levels), out, tt.zeroToggles)
+			if got := bitsString(out); got != tt.in {
+				t.Errorf("NRZIDecode(%s) = %s; want %s", tt.levels, got, tt.in)
+			}
+		})
+	}
+}
+
+func TestManchester(t *testing.T) {
+	t.Run("Encode", func(t *testing.T) {
+		tests := []struct {
+			name string
+			c    ManchesterConvention
+			want string
+		}{
+			{"IEEE", ManchesterIEEE, "011001011010"},
+			{"Thomas", ManchesterThomas, "100110100101"},
+		}
+		for _, tt := range tests {
+			writer := NewBitWriter[uint8](0, 0)
+			ManchesterEncode(bitsReader("101100"), writer, tt.c)
+			if got := bitsString(writer); got != tt.want {
+				t.Errorf("ManchesterEncode(101100, %s) = %s; want %s", tt.name, got, tt.want)
+			}
+			out := NewBitWriter[uint8](0, 0)
+			if err := ManchesterDecode(bitsReader(tt.want), out, tt.c); err != nil {
+				t.Fatalf("ManchesterDecode() error = %v; want nil", err)
+			}
+			if got := bitsString(out); got != "101100" {
+				t.Errorf("ManchesterDecode(%s, %s) = %s; want 101100", tt.want, tt.name, got)
+			}
+		}
+	})
+
+	t.Run("Long", func(t *testing.T) {
+		writer := NewBitWriter[uint64](0, 0)
+		writer.WriteBits(0xDEADBEEFCAFEF00D, 64)
+		writer.WriteBits(0x5, 7)
+		src := NewBitReader(writer.Data(), 0, 0)
+		src.SetBits(71)
+		encoded := NewBitWriter[uint32](3, 0)
+		ManchesterEncode(src, encoded, ManchesterIEEE)
+		if encoded.Bits() != 142 {
+			t.Errorf("Bits() = %d; want 142", encoded.Bits())
+		}
+		r := NewBitReader(encoded.Data(), 3, 0)
+		r.SetBits(encoded.Bits())
+		out := NewBitWriter[uint64](0, 0)
+		ManchesterDecode(r, out, ManchesterIEEE)
+		if got := bitsString(out); got != bitsString(writer) {
+			t.Errorf("ManchesterDecode(ManchesterEncode(x)) = %s; want %s", got, bitsString(writer))
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		src := bitsReader("011011")
+		out := NewBitWriter[uint8](0, 0)
+		if err := ManchesterDecode(src, out, ManchesterIEEE); err != ErrInvalidFormat || src.Pos() != 4 {
+			t.Errorf("ManchesterDecode(011011) = %v at %d; want ErrInvalidFormat at 4", err, src.Pos())
+		}
+		src = bitsReader("01100")
+		out = NewBitWriter[uint8](0, 0)
+		if err := ManchesterDecode(src, out, ManchesterIEEE); err != io.ErrUnexpectedEOF || bitsString(out) != "10" {
+			t.Errorf("ManchesterDecode(01100) = %v with %s; want io.ErrUnexpectedEOF with 10", err, bitsString(out))
+		}
+	})
+}
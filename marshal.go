@@ -0,0 +1,215 @@
+package bitstream
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Marshal packs the fields of the struct v (or a pointer to one) into bytes, MSB first,
+// in field order. Fields are described by a bits struct tag:
+//
+//	type Header struct {
+//		Version uint8 `bits:"3"`
+//		Flag    bool  `bits:"1"`
+//		Delta   int8  `bits:"5,signed"`
+//		Coeffs  [4]uint16 `bits:"12"` // each element takes 12 bits
+//	}
+//
+// Supported field types are bool, the integer types and arrays of them.
+// Integer fields take the tagged number of bits (1 to 64); the signed option stores an int
+// field in two's complement. Bool fields take one bit and need no tag.
+// Nested struct fields are packed recursively. Other fields without a tag, and fields
+// tagged bits:"-", are skipped. The last byte is padded with zero bits.
+//
+// An error is returned if v is not a struct, a tag is invalid, or a value does not fit
+// in its field.
+func Marshal(v any) ([]byte, error) {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("bitstream: Marshal of non-struct type %T", v)
+	}
+	w := NewBitWriter[uint8](0, 0)
+	if err := marshalStruct(w, rv); err != nil {
+		return nil, err
+	}
+	return w.Data(), nil
+}
+
+// Unmarshal unpacks data written by Marshal into the struct pointed to by v,
+// using the same bits struct tags. Signed fields are sign-extended.
+// Returns an error wrapping io.ErrUnexpectedEOF if data is too short.
+func Unmarshal(data []byte, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("bitstream: Unmarshal requires a non-nil struct pointer, got %T", v)
+	}
+	return unmarshalStruct(NewBitReader(data, 0, 0), rv.Elem())
+}
+
+// bitField is a parsed bits struct tag.
+type bitField struct {
+	name   string
+	bits   int
+	signed bool
+}
+
+// parseField reads the bits tag of f. ok is false if the field is skipped.
+func parseField(f reflect.StructField) (field bitField, ok bool, err error) {
+	field.name = f.Name
+	tag, tagged := f.Tag.Lookup("bits")
+	if tag == "-" || !f.IsExported() {
+		return field, false, nil
+	}
+	elem := f.Type
+	if elem.Kind() == reflect.Array {
+		elem = elem.Elem()
+	}
+	if !tagged {
+		switch elem.Kind() {
+		case reflect.Bool:
+			field.bits = 1
+			return field, true, nil
+		case reflect.Struct:
+			return field, true, nil
+		}
+		return field, false, nil
+	}
+	size, opts, _ := strings.Cut(tag, ",")
+	field.bits, err = strconv.Atoi(size)
+	if err != nil || field.bits < 1 || field.bits > 64 {
+		return field, false, fmt.Errorf("bitstream: field %s: invalid bits tag %q", f.Name, tag)
+	}
+	switch opts {
+	case "":
+	case "signed":
+		field.signed = true
+	default:
+		return field, false, fmt.Errorf("bitstream: field %s: unknown bits tag option %q", f.Name, opts)
+	}
+	switch elem.Kind() {
+	case reflect.Bool:
+		if field.bits != 1 || field.signed {
+			return field, false, fmt.Errorf("bitstream: field %s: bool fields take exactly 1 bit", f.Name)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if field.bits > elem.Bits() {
+			return field, false, fmt.Errorf("bitstream: field %s: %d bits do not fit in %s", f.Name, field.bits, elem)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if field.bits > elem.Bits() || field.signed {
+			return field, false, fmt.Errorf("bitstream: field %s: invalid bits tag %q for %s", f.Name, tag, elem)
+		}
+	default:
+		return field, false, fmt.Errorf("bitstream: field %s: unsupported type %s", f.Name, f.Type)
+	}
+	return field, true, nil
+}
+
+func marshalStruct(w *BitWriter[uint8], rv reflect.Value) error {
+	t := rv.Type()
+	for i := range t.NumField() {
+		field, ok, err := parseField(t.Field(i))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		fv := rv.Field(i)
+		if fv.Kind() != reflect.Array {
+			if err := marshalValue(w, field, fv); err != nil {
+				return err
+			}
+			continue
+		}
+		for j := range fv.Len() {
+			if err := marshalValue(w, field, fv.Index(j)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func marshalValue(w *BitWriter[uint8], field bitField, v reflect.Value) error {
+	switch v.Kind() {
+	case reflect.Struct:
+		return marshalStruct(w, v)
+	case reflect.Bool:
+		w.WriteBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n := v.Int()
+		if field.signed {
+			if shift := 64 - field.bits; n<<shift>>shift != n {
+				return fmt.Errorf("bitstream: field %s: value %d does not fit in %d signed bits", field.name, n, field.bits)
+			}
+		} else if n < 0 || field.bits < 64 && n>>field.bits != 0 {
+			return fmt.Errorf("bitstream: field %s: value %d does not fit in %d bits", field.name, n, field.bits)
+		}
+		w.WriteBits(uint64(n), field.bits)
+	default:
+		n := v.Uint()
+		if field.bits < 64 && n>>field.bits != 0 {
+			return fmt.Errorf("bitstream: field %s: value %d does not fit in %d bits", field.name, n, field.bits)
+		}
+		w.WriteBits(n, field.bits)
+	}
+	return nil
+}
+
+func unmarshalStruct(r *BitReader[uint8], rv reflect.Value) error {
+	t := rv.Type()
+	for i := range t.NumField() {
+		field, ok, err := parseField(t.Field(i))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		fv := rv.Field(i)
+		if fv.Kind() != reflect.Array {
+			if err := unmarshalValue(r, field, fv); err != nil {
+				return err
+			}
+			continue
+		}
+		for j := range fv.Len() {
+			if err := unmarshalValue(r, field, fv.Index(j)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func unmarshalValue(r *BitReader[uint8], field bitField, v reflect.Value) error {
+	if v.Kind() == reflect.Struct {
+		return unmarshalStruct(r, v)
+	}
+	n, err := r.ReadBits(field.bits)
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return fmt.Errorf("bitstream: field %s: %w", field.name, err)
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		v.SetBool(n != 0)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if field.signed {
+			shift := 64 - field.bits
+			v.SetInt(int64(n<<shift) >> shift)
+		} else {
+			v.SetInt(int64(n))
+		}
+	default:
+		v.SetUint(n)
+	}
+	return nil
+}
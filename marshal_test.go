@@ -0,0 +1,114 @@
+package bitstream
+
+import (
+	"errors"
+	"io"
+	"slices"
+	"testing"
+)
+
+type testPoint struct {
+	X int16 `bits:"6,signed"`
+	Y int16 `bits:"6,signed"`
+}
+
+type testPacket struct {
+	Version uint8 `bits:"3"`
+	Flag    bool
+	Delta   int8 `bits:"5,signed"`
+	Skipped int
+	Ignored uint8 `bits:"-"`
+	Origin  testPoint
+	Coeffs  [3]uint16 `bits:"12"`
+	Length  uint64    `bits:"64"`
+}
+
+func TestMarshal(t *testing.T) {
+	t.Run("Bits", func(t *testing.T) {
+		got, err := Marshal(struct {
+			A uint8 `bits:"3"`
+			B bool
+			C int8  `bits:"4,signed"`
+			D uint8 `bits:"2"`
+		}{A: 5, B: true, C: -2, D: 3})
+		if err != nil {
+			t.Fatalf("Marshal() error = %v", err)
+		}
+		if want := []byte{0b101_1_1110, 0b11000000}; !slices.Equal(got, want) {
+			t.Errorf("Marshal() = %08b; want %08b", got, want)
+		}
+	})
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		in := testPacket{
+			Version: 6,
+			Flag:    true,
+			Delta:   -16,
+			Skipped: 42,
+			Ignored: 7,
+			Origin:  testPoint{X: -32, Y: 31},
+			Coeffs:  [3]uint16{0xFFF, 0, 0xABC},
+			Length:  1<<64 - 1,
+		}
+		data, err := Marshal(&in)
+		if err != nil {
+			t.Fatalf("Marshal() error = %v", err)
+		}
+		if len(data) != (3+1+5+12+36+64+7)/8 {
+			t.Errorf("len(Marshal()) = %d; want %d", len(data), (3+1+5+12+36+64+7)/8)
+		}
+		var out testPacket
+		if err := Unmarshal(data, &out); err != nil {
+			t.Fatalf("Unmarshal() error = %v", err)
+		}
+		in.Skipped, in.Ignored = 0, 0
+		if out != in {
+			t.Errorf("Unmarshal() = %+v; want %+v", out, in)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		tests := map[string]any{
+			"not a struct": 42,
+			"overflow": struct {
+				A uint8 `bits:"3"`
+			}{8},
+			"signed overflow": struct {
+				A int8 `bits:"3,signed"`
+			}{-5},
+			"negative unsigned": struct {
+				A int8 `bits:"3"`
+			}{-1},
+			"too wide": struct {
+				A uint8 `bits:"9"`
+			}{},
+			"bad tag": struct {
+				A uint8 `bits:"x"`
+			}{},
+			"bad option": struct {
+				A int8 `bits:"3,packed"`
+			}{},
+			"signed uint": struct {
+				A uint8 `bits:"3,signed"`
+			}{},
+			"unsupported": struct {
+				A string `bits:"8"`
+			}{},
+		}
+		for name, v := range tests {
+			if _, err := Marshal(v); err == nil {
+				t.Errorf("%s: Marshal() error = nil; want error", name)
+			}
+		}
+	})
+
+	t.Run("Unmarshal_errors", func(t *testing.T) {
+		var p testPacket
+		if err := Unmarshal([]byte{0xFF}, &p); !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("Unmarshal() error = %v; want io.ErrUnexpectedEOF", err)
+		}
+		if err := Unmarshal([]byte{0xFF}, p); err == nil {
+			t.Error("Unmarshal(non-pointer) error = nil; want error")
+		}
+	})
+}
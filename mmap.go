@@ -0,0 +1,46 @@
+package bitstream
+
+import "math"
+
+// FileBitReader is a BitReader over the bytes of a file, most significant bit first.
+// On Unix systems the file is memory-mapped, so multi-gigabyte bit-indexed files
+// (succinct structures, FM-indexes) are paged in on demand rather than loaded into memory;
+// elsewhere the file is read into memory.
+//
+// Bit offsets into the file can be given as int64 with Pos64, Seek64, ReadBitsAt64 and
+// SeekBit, so index code can use file-sized offsets on every platform; files of any
+// practical size can be read on 64-bit platforms. Close releases the mapping; the reader
+// and any readers derived from it with Slice or Clone must not be used afterwards.
+type FileBitReader struct {
+	*BitReader[uint8]
+	data  []byte
+	unmap func([]byte) error
+}
+
+// NewBitReaderFile opens the file at path and returns a reader over all of its bits.
+// Returns the error from opening or mapping the file, or ErrOverflow if the file has
+// more bits than an int can count.
+func NewBitReaderFile(path string) (*FileBitReader, error) {
+	data, unmap, err := mapFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if uint64(len(data)) > math.MaxInt/8 {
+		if unmap != nil {
+			unmap(data)
+		}
+		return nil, ErrOverflow
+	}
+	return &FileBitReader{BitReader: NewBitReader(data, 0, 0), data: data, unmap: unmap}, nil
+}
+
+// Close releases the file mapping. It is safe to call more than once.
+func (f *FileBitReader) Close() error {
+	data, unmap := f.data, f.unmap
+	f.data, f.unmap = nil, nil
+	f.BitReader.Reset(nil, 0, 0)
+	if unmap == nil || len(data) == 0 {
+		return nil
+	}
+	return unmap(data)
+}
@@ -0,0 +1,11 @@
+//go:build !unix
+
+package bitstream
+
+import "os"
+
+// mapFile reads the file at path into memory; there is no mapping to release.
+func mapFile(path string) ([]byte, func([]byte) error, error) {
+	data, err := os.ReadFile(path)
+	return data, nil, err
+}
@@ -0,0 +1,72 @@
+package bitstream
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBitReaderFile(t *testing.T) {
+	t.Run("Read", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "bits")
+		data := make([]byte, 1<<16)
+		for i := range data {
+			data[i] = byte(i * 31)
+		}
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		f, err := NewBitReaderFile(path)
+		if err != nil {
+			t.Fatalf("NewBitReaderFile() error = %v; want nil", err)
+		}
+		if got := f.Bits(); got != 8<<16 {
+			t.Errorf("Bits() = %d; want %d", got, 8<<16)
+		}
+		if v, err := f.ReadBits(12); v != 0x001 || err != nil {
+			t.Errorf("ReadBits(12) = %#x, %v; want 0x1, nil", v, err)
+		}
+		pos := 8*40000 + 4
+		if v, _ := f.ReadBitsAt(pos, 8); v != uint64(data[40000]&0xF)<<4|uint64(data[40001]>>4) {
+			t.Errorf("ReadBitsAt(%d, 8) = %#x; want %#x", pos, v, data[40000]&0xF<<4|data[40001]>>4)
+		}
+		if v, _ := f.ReadBitsAt64(int64(pos), 8); v != uint64(data[40000]&0xF)<<4|uint64(data[40001]>>4) {
+			t.Errorf("ReadBitsAt64(%d, 8) = %#x; want %#x", pos, v, data[40000]&0xF<<4|data[40001]>>4)
+		}
+		if f.Bits64() != 8<<16 {
+			t.Errorf("Bits64() = %d; want %d", f.Bits64(), 8<<16)
+		}
+		s := f.Slice(8*100, 8*102)
+		if v, _ := s.ReadBits(16); v != uint64(data[100])<<8|uint64(data[101]) {
+			t.Errorf("Slice().ReadBits(16) = %#x", v)
+		}
+		if err := f.Close(); err != nil {
+			t.Errorf("Close() error = %v; want nil", err)
+		}
+		if err := f.Close(); err != nil {
+			t.Errorf("second Close() error = %v; want nil", err)
+		}
+		if f.Bits() != 0 {
+			t.Errorf("Bits() after Close = %d; want 0", f.Bits())
+		}
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "empty")
+		os.WriteFile(path, nil, 0o644)
+		f, err := NewBitReaderFile(path)
+		if err != nil {
+			t.Fatalf("NewBitReaderFile() error = %v; want nil", err)
+		}
+		defer f.Close()
+		if f.Bits() != 0 {
+			t.Errorf("Bits() = %d; want 0", f.Bits())
+		}
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		if _, err := NewBitReaderFile(filepath.Join(t.TempDir(), "missing")); !os.IsNotExist(err) {
+			t.Errorf("NewBitReaderFile() error = %v; want not exist", err)
+		}
+	})
+}
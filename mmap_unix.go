@@ -0,0 +1,33 @@
+//go:build unix
+
+package bitstream
+
+import (
+	"os"
+	"syscall"
+)
+
+// mapFile maps the file at path read-only and returns its bytes with the function that unmaps them.
+func mapFile(path string) ([]byte, func([]byte) error, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, nil, err
+	}
+	size := fi.Size()
+	if size == 0 {
+		return nil, nil, nil
+	}
+	if int64(int(size)) != size {
+		return nil, nil, ErrOverflow
+	}
+	data, err := syscall.Mmap(int(f.Fd()), 0, int(size), syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, nil, &os.PathError{Op: "mmap", Path: path, Err: err}
+	}
+	return data, syscall.Munmap, nil
+}
@@ -0,0 +1,120 @@
+package bitstream
+
+import "io"
+
+// EncodeMorton2 interleaves the bits of x and y into a Z-order (Morton) code:
+// bit i of x becomes bit 2i and bit i of y becomes bit 2i+1.
+// Nearby points get nearby codes, which suits spatial indexes and texture swizzling.
+func EncodeMorton2(x, y uint32) uint64 {
+	return spread2(x) | spread2(y)<<1
+}
+
+// DecodeMorton2 returns the coordinates interleaved in a code from EncodeMorton2.
+func DecodeMorton2(m uint64) (x, y uint32) {
+	return compact2(m), compact2(m >> 1)
+}
+
+// EncodeMorton3 interleaves the low 21 bits of x, y and z into a Z-order (Morton) code:
+// bit i of x, y and z becomes bit 3i, 3i+1 and 3i+2. Higher bits are ignored.
+func EncodeMorton3(x, y, z uint32) uint64 {
+	return spread3(x) | spread3(y)<<1 | spread3(z)<<2
+}
+
+// DecodeMorton3 returns the coordinates interleaved in a code from EncodeMorton3.
+func DecodeMorton3(m uint64) (x, y, z uint32) {
+	return compact3(m), compact3(m >> 1), compact3(m >> 2)
+}
+
+// WriteMorton writes the low bits bits of each coordinate interleaved in Z-order, most
+// significant level first, with the last coordinate taking the top bit of each level.
+// For two or three coordinates this writes the same bits as WriteBits of EncodeMorton2
+// or EncodeMorton3, but the code may be longer than 64 bits.
+//
+// Panics if bits < 0 or bits > 64, or if there are more than 64 coordinates.
+func (w *BitWriter[T]) WriteMorton(bits int, coords ...uint64) {
+	if bits < 0 || bits > 64 || len(coords) > 64 {
+		panic("bitstream: invalid Morton code size")
+	}
+	w.lock()
+	defer w.unlock()
+	w.grow(bits * len(coords))
+	for i := bits - 1; i >= 0; i-- {
+		var level uint64
+		for j := len(coords) - 1; j >= 0; j-- {
+			level = level<<1 | coords[j]>>i&1
+		}
+		w.writeBits(level, len(coords))
+	}
+}
+
+// ReadMorton reads a Z-order code written by WriteMorton with the same bits and number of
+// coordinates, storing the coordinates in coords, and advances the cursor.
+// Returns io.EOF if no valid bits remain or io.ErrUnexpectedEOF if the code is truncated;
+// the cursor and coords are not modified on error.
+//
+// Panics as WriteMorton does.
+func (r *BitReader[T]) ReadMorton(bits int, coords []uint64) error {
+	if bits < 0 || bits > 64 || len(coords) > 64 {
+		panic("bitstream: invalid Morton code size")
+	}
+	n := len(coords)
+	if _, err := r.PeekBits(min(1, bits*n)); err != nil {
+		return err
+	}
+	if r.pos+bits*n > r.bits {
+		return io.ErrUnexpectedEOF
+	}
+	clear(coords)
+	for range bits {
+		level := r.bitsAt(r.pos, n)
+		r.pos += n
+		for j := range coords {
+			coords[j] = coords[j]<<1 | level>>j&1
+		}
+	}
+	return nil
+}
+
+// spread2 inserts a zero bit above each bit of v.
+func spread2(v uint32) uint64 {
+	x := uint64(v)
+	x = (x | x<<16) & 0x0000FFFF0000FFFF
+	x = (x | x<<8) & 0x00FF00FF00FF00FF
+	x = (x | x<<4) & 0x0F0F0F0F0F0F0F0F
+	x = (x | x<<2) & 0x3333333333333333
+	x = (x | x<<1) & 0x5555555555555555
+	return x
+}
+
+// compact2 gathers the even bits of x, undoing spread2.
+func compact2(x uint64) uint32 {
+	x &= 0x5555555555555555
+	x = (x | x>>1) & 0x3333333333333333
+	x = (x | x>>2) & 0x0F0F0F0F0F0F0F0F
+	x = (x | x>>4) & 0x00FF00FF00FF00FF
+	x = (x | x>>8) & 0x0000FFFF0000FFFF
+	x = (x | x>>16) & 0x00000000FFFFFFFF
+	return uint32(x)
+}
+
+// spread3 inserts two zero bits above each of the low 21 bits of v.
+func spread3(v uint32) uint64 {
+	x := uint64(v) & 0x1FFFFF
+	x = (x | x<<32) & 0x1F00000000FFFF
+	x = (x | x<<16) & 0x1F0000FF0000FF
+	x = (x | x<<8) & 0x100F00F00F00F00F
+	x = (x | x<<4) & 0x10C30C30C30C30C3
+	x = (x | x<<2) & 0x1249249249249249
+	return x
+}
+
+// compact3 gathers every third bit of x, undoing spread3.
+func compact3(x uint64) uint32 {
+	x &= 0x1249249249249249
+	x = (x | x>>2) & 0x10C30C30C30C30C3
+	x = (x | x>>4) & 0x100F00F00F00F00F
+	x = (x | x>>8) & 0x1F0000FF0000FF
+	x = (x | x>>16) & 0x1F00000000FFFF
+	x = (x | x>>32) & 0x1FFFFF
+	return uint32(x)
+}
@@ -0,0 +1,73 @@
+package bitstream
+
+import (
+	"io"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestMorton(t *testing.T) {
+	t.Run("Morton2", func(t *testing.T) {
+		if got := EncodeMorton2(0b101, 0b011); got != 0b011011 {
+			t.Errorf("EncodeMorton2(5, 3) = %06b; want 011011", got)
+		}
+		if got := EncodeMorton2(1<<32-1, 0); got != 0x5555555555555555 {
+			t.Errorf("EncodeMorton2(max, 0) = %#x; want 0x5555555555555555", got)
+		}
+		for range 100 {
+			x, y := rand.Uint32(), rand.Uint32()
+			if gx, gy := DecodeMorton2(EncodeMorton2(x, y)); gx != x || gy != y {
+				t.Errorf("DecodeMorton2(EncodeMorton2(%d, %d)) = %d, %d", x, y, gx, gy)
+			}
+		}
+	})
+
+	t.Run("Morton3", func(t *testing.T) {
+		if got := EncodeMorton3(0b11, 0b01, 0b10); got != 0b101011 {
+			t.Errorf("EncodeMorton3(3, 1, 2) = %06b; want 101011", got)
+		}
+		if got := EncodeMorton3(0, 0, 1<<21-1); got != 0x4924924924924924 {
+			t.Errorf("EncodeMorton3(0, 0, max) = %#x; want 0x4924924924924924", got)
+		}
+		if got := EncodeMorton3(1<<21, 0, 0); got != 0 {
+			t.Errorf("EncodeMorton3(1<<21, 0, 0) = %#x; want 0", got)
+		}
+		for range 100 {
+			x, y, z := rand.Uint32()>>11, rand.Uint32()>>11, rand.Uint32()>>11
+			if gx, gy, gz := DecodeMorton3(EncodeMorton3(x, y, z)); gx != x || gy != y || gz != z {
+				t.Errorf("DecodeMorton3(EncodeMorton3(%d, %d, %d)) = %d, %d, %d", x, y, z, gx, gy, gz)
+			}
+		}
+	})
+
+	t.Run("Stream", func(t *testing.T) {
+		writer := NewBitWriter[uint16](2, 0)
+		writer.WriteMorton(10, 0x2AB, 0x155)
+		writer.WriteMorton(21, 0x1FFFFF, 12345, 0)
+		writer.WriteMorton(40, 1<<39, 1, 3, 1<<40-1)
+		reader := NewBitReader(writer.Data(), 2, 0)
+		reader.SetBits(writer.Bits())
+		if got, _ := reader.ReadBits(20); got != EncodeMorton2(0x2AB, 0x155) {
+			t.Errorf("WriteMorton(10, x, y) = %#x; want %#x", got, EncodeMorton2(0x2AB, 0x155))
+		}
+		if got, _ := reader.PeekBits(63); got != EncodeMorton3(0x1FFFFF, 12345, 0) {
+			t.Errorf("WriteMorton(21, x, y, z) = %#x; want %#x", got, EncodeMorton3(0x1FFFFF, 12345, 0))
+		}
+		coords := make([]uint64, 3)
+		if err := reader.ReadMorton(21, coords); err != nil || !slices.Equal(coords, []uint64{0x1FFFFF, 12345, 0}) {
+			t.Errorf("ReadMorton(21) = %v, %v; want [2097151 12345 0], nil", coords, err)
+		}
+		coords = make([]uint64, 4)
+		if err := reader.ReadMorton(40, coords); err != nil || !slices.Equal(coords, []uint64{1 << 39, 1, 3, 1<<40 - 1}) {
+			t.Errorf("ReadMorton(40) = %v, %v", coords, err)
+		}
+		if err := reader.ReadMorton(1, coords); err != io.EOF {
+			t.Errorf("ReadMorton() at end error = %v; want io.EOF", err)
+		}
+		reader.Seek(reader.Bits() - 3)
+		if err := reader.ReadMorton(1, coords); err != io.ErrUnexpectedEOF || coords[0] != 1<<39 {
+			t.Errorf("ReadMorton() on truncated code = %v, %v; want io.ErrUnexpectedEOF with coords unchanged", err, coords)
+		}
+	})
+}
@@ -0,0 +1,150 @@
+package bitstream
+
+import (
+	"io"
+	"sort"
+)
+
+// Source is the read-only view of a bit stream shared by every *BitReader[T], whatever its
+// element type and padding, so that streams of different types can be combined.
+// It can only be implemented by the types of this package.
+type Source interface {
+	// Bits returns the number of valid bits.
+	Bits() int
+	// Pos returns the cursor.
+	Pos() int
+	// bitsAt returns bits bits at pos, right-aligned, for a range within the valid bits.
+	bitsAt(pos, bits int) uint64
+}
+
+// MultiReader presents several streams as one contiguous bit sequence with its own cursor,
+// like io.MultiReader. The streams are not copied and their cursors are not moved.
+// A MultiReader is not safe for concurrent use.
+type MultiReader struct {
+	srcs   []Source
+	starts []int // first bit of each source
+	offs   []int // offs[i] is the position of source i in the sequence; the last entry is the length
+	pos    int
+}
+
+// MultiBitReader returns a MultiReader over the remaining bits of each source, from its
+// cursor at the time of the call to its end, in order. Its cursor starts at 0.
+func MultiBitReader(srcs ...Source) *MultiReader {
+	m := &MultiReader{offs: []int{0}}
+	for _, s := range srcs {
+		n := s.Bits() - s.Pos()
+		if n <= 0 {
+			continue
+		}
+		m.srcs = append(m.srcs, s)
+		m.starts = append(m.starts, s.Pos())
+		m.offs = append(m.offs, m.offs[len(m.offs)-1]+n)
+	}
+	return m
+}
+
+func (m *MultiReader) bitsAt(pos, bits int) uint64 {
+	i := sort.SearchInts(m.offs, pos+1) - 1
+	var v uint64
+	for bits > 0 {
+		k := min(bits, m.offs[i+1]-pos)
+		v = v<<k | m.srcs[i].bitsAt(m.starts[i]+pos-m.offs[i], k)
+		pos += k
+		bits -= k
+		i++
+	}
+	return v
+}
+
+// Bits returns the total number of bits in the sequence.
+func (m *MultiReader) Bits() int {
+	return m.offs[len(m.offs)-1]
+}
+
+// Pos returns the current read position (cursor).
+func (m *MultiReader) Pos() int {
+	return m.pos
+}
+
+// Seek sets the read position (cursor), as BitReader.Seek does.
+// Returns ErrNegativePosition for negative positions.
+func (m *MultiReader) Seek(pos int) error {
+	if pos < 0 {
+		return ErrNegativePosition
+	}
+	m.pos = pos
+	return nil
+}
+
+// ReadBit reads one bit and advances the cursor, with the errors of BitReader.ReadBit.
+func (m *MultiReader) ReadBit() (bool, error) {
+	if m.pos >= m.Bits() {
+		return false, io.EOF
+	}
+	bit := m.bitsAt(m.pos, 1) != 0
+	m.pos++
+	return bit, nil
+}
+
+// ReadBits reads bits bits and advances the cursor, with the errors of BitReader.ReadBits.
+// A read may span several streams.
+//
+// Panics if bits > 64.
+func (m *MultiReader) ReadBits(bits int) (uint64, error) {
+	v, err := m.PeekBits(bits)
+	if err != nil {
+		return 0, err
+	}
+	m.pos += max(bits, 0)
+	return v, nil
+}
+
+// PeekBits reads bits bits without moving the cursor, with the errors of BitReader.PeekBits.
+//
+// Panics if bits > 64.
+func (m *MultiReader) PeekBits(bits int) (uint64, error) {
+	if bits > 64 {
+		panic(&WidthError{Op: "read", Width: bits, Max: 64})
+	}
+	if bits <= 0 {
+		return 0, nil
+	}
+	if m.pos >= m.Bits() {
+		return 0, io.EOF
+	}
+	if bits > m.Bits()-m.pos {
+		return 0, io.ErrUnexpectedEOF
+	}
+	return m.bitsAt(m.pos, bits), nil
+}
+
+// Skip advances the cursor by n bits, as BitReader.Skip does.
+func (m *MultiReader) Skip(n int) error {
+	if n < 0 {
+		return ErrNegativePosition
+	}
+	if n > m.Bits()-m.pos {
+		m.pos = max(m.pos, m.Bits())
+		return io.EOF
+	}
+	m.pos += n
+	return nil
+}
+
+// Read implements io.Reader like BitReader.Read, packing the remaining bits MSB-first
+// into bytes.
+func (m *MultiReader) Read(p []byte) (n int, err error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	if m.pos >= m.Bits() {
+		return 0, io.EOF
+	}
+	for n < len(p) && m.pos < m.Bits() {
+		k := min(8, m.Bits()-m.pos)
+		p[n] = byte(m.bitsAt(m.pos, k) << (8 - k))
+		m.pos += k
+		n++
+	}
+	return n, nil
+}
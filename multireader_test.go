@@ -0,0 +1,55 @@
+package bitstream
+
+import (
+	"io"
+	"testing"
+)
+
+func TestMultiBitReader(t *testing.T) {
+	a := NewBitReader([]uint8{0b1010_0000}, 0, 0)
+	a.SetBits(4)
+	b := NewBitReader([]uint16{0x0FFF}, 4, 0) // 12 valid bits, all ones
+	b.Skip(2)
+	c := NewBitReader([]uint64{0x8000_0000_0000_0001}, 0, 0)
+	empty := NewBitReader([]uint32{}, 0, 0)
+
+	m := MultiBitReader(a, empty, b, c)
+	if m.Bits() != 4+10+64 {
+		t.Fatalf("Bits() = %d; want 78", m.Bits())
+	}
+	if v, _ := m.ReadBits(6); v != 0b1010_11 {
+		t.Errorf("ReadBits(6) = %b; want 101011", v)
+	}
+	// spans b and c
+	if v, _ := m.ReadBits(10); v != 0b11111111_10 {
+		t.Errorf("ReadBits(10) = %b; want 1111111110", v)
+	}
+	if a.Pos() != 0 || b.Pos() != 2 {
+		t.Errorf("source cursors moved to %d, %d; want 0, 2", a.Pos(), b.Pos())
+	}
+	m.Seek(3)
+	if v, _ := m.PeekBits(64); v != 0b0_1111111111_1<<52 {
+		t.Errorf("PeekBits(64) = %#x; want %#x", v, uint64(0b0_1111111111_1)<<52)
+	}
+	if bit, _ := m.ReadBit(); bit {
+		t.Errorf("ReadBit() = true; want false")
+	}
+	if err := m.Skip(100); err != io.EOF || m.Pos() != m.Bits() {
+		t.Errorf("Skip(100) = %v at %d; want io.EOF at %d", err, m.Pos(), m.Bits())
+	}
+	m.Seek(m.Bits() - 1)
+	if _, err := m.ReadBits(2); err != io.ErrUnexpectedEOF {
+		t.Errorf("ReadBits(2) error = %v; want io.ErrUnexpectedEOF", err)
+	}
+
+	m.Seek(0)
+	p, _ := io.ReadAll(m)
+	if len(p) != 10 || p[0] != 0b1010_1111 || p[9] != 0b0000_0100 {
+		t.Errorf("ReadAll() = %x; want 10 bytes from af to 04", p)
+	}
+
+	nested := MultiBitReader(m, a)
+	if nested.Bits() != 4 {
+		t.Errorf("Bits() of nested reader at end of m = %d; want 4", nested.Bits())
+	}
+}
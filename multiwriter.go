@@ -0,0 +1,77 @@
+package bitstream
+
+import (
+	"fmt"
+	"sync"
+)
+
+// MultiWriter assembles one stream from sections that are encoded independently,
+// for example by separate goroutines. Sections are created in stream order with
+// Section, written concurrently, and stitched together in that order by Merge.
+//
+//	m := bitstream.NewMultiWriter[uint64](0, 0)
+//	for i := range blocks {
+//		w := m.Section(budget)
+//		go func() { encode(w, blocks[i]); wg.Done() }()
+//	}
+//	wg.Wait()
+//	out, err := m.Merge()
+type MultiWriter[T Unsigned] struct {
+	mu       sync.Mutex
+	lp, rp   int
+	sections []section[T]
+}
+
+type section[T Unsigned] struct {
+	w      *BitWriter[T]
+	budget int
+}
+
+// NewMultiWriter creates a MultiWriter whose sections and merged output use the given padding.
+//
+// Panics if leftPadd + rightPadd >= element bit size.
+func NewMultiWriter[T Unsigned](leftPadd, rightPadd int) *MultiWriter[T] {
+	NewBitWriter[T](leftPadd, rightPadd) // validate the padding up front
+	return &MultiWriter[T]{lp: leftPadd, rp: rightPadd}
+}
+
+// Section appends a new section to the stream and returns the writer for it.
+// budget is the maximum number of bits the section may hold; its storage is preallocated
+// for that many bits. Each section writer is an ordinary BitWriter and is safe to use from
+// its own goroutine while other sections are being written.
+//
+// Panics if budget is negative.
+func (m *MultiWriter[T]) Section(budget int) *BitWriter[T] {
+	if budget < 0 {
+		panic("bitstream: negative section budget")
+	}
+	w := NewBitWriter[T](m.lp, m.rp)
+	w.Grow(budget)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sections = append(m.sections, section[T]{w: w, budget: budget})
+	return w
+}
+
+// Merge returns a new writer holding the bits of every section in the order the sections
+// were created. It must be called after all sections are complete; the sections are not modified.
+// Returns an error wrapping ErrBudgetExceeded, naming the section index, if a section
+// holds more bits than its budget.
+func (m *MultiWriter[T]) Merge() (*BitWriter[T], error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	total := 0
+	for i, s := range m.sections {
+		bits := s.w.Bits()
+		if bits > s.budget {
+			return nil, fmt.Errorf("section %d: %d bits, budget %d: %w", i, bits, s.budget, ErrBudgetExceeded)
+		}
+		total += bits
+	}
+	out := NewBitWriter[T](m.lp, m.rp)
+	out.Grow(total)
+	for _, s := range m.sections {
+		out.Append(s.w)
+	}
+	return out, nil
+}
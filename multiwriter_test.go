@@ -0,0 +1,58 @@
+package bitstream
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestMultiWriter(t *testing.T) {
+	t.Run("Merge", func(t *testing.T) {
+		m := NewMultiWriter[uint16](1, 2)
+		var wg sync.WaitGroup
+		for i := range 16 {
+			w := m.Section(64)
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for range i + 1 {
+					w.WriteBits(uint64(i), 4)
+				}
+			}()
+		}
+		wg.Wait()
+		out, err := m.Merge()
+		if err != nil {
+			t.Fatalf("Merge() error = %v", err)
+		}
+		if out.Bits() != 4*136 {
+			t.Fatalf("Bits() = %d; want %d", out.Bits(), 4*136)
+		}
+		r := NewBitReader(out.Data(), 1, 2)
+		for i := range 16 {
+			for range i + 1 {
+				if v, _ := r.ReadBits(4); v != uint64(i) {
+					t.Fatalf("ReadBits(4) at %d = %d; want %d", r.Pos()-4, v, i)
+				}
+			}
+		}
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		m := NewMultiWriter[uint8](0, 0)
+		m.Section(0)
+		out, err := m.Merge()
+		if err != nil || out.Bits() != 0 {
+			t.Errorf("Merge() = %d bits, %v; want 0 bits, nil", out.Bits(), err)
+		}
+	})
+
+	t.Run("BudgetExceeded", func(t *testing.T) {
+		m := NewMultiWriter[uint8](0, 0)
+		m.Section(8).WriteBits(0xFF, 8)
+		m.Section(4).WriteBits(0x1F, 5)
+		if _, err := m.Merge(); !errors.Is(err, ErrBudgetExceeded) {
+			t.Errorf("Merge() error = %v; want ErrBudgetExceeded", err)
+		}
+	})
+}
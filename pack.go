@@ -0,0 +1,250 @@
+package bitstream
+
+import "io"
+
+// PackUints writes each of values in exactly width bits, one after another, as a
+// columnar store would pack an integer array whose maximum needs width bits.
+// Returns ErrOverflow, writing nothing, if a value does not fit in width bits, and
+// ErrBudgetExceeded, writing nothing, if the fields would exceed the limit set by
+// NewBitWriterLimit.
+//
+// Panics if width < 1 or width > 64.
+func (w *BitWriter[T]) PackUints(values []uint64, width int) error {
+	if width < 1 || width > 64 {
+		panic("bitstream: pack width must be between 1 and 64")
+	}
+	if width < 64 {
+		for _, v := range values {
+			if v>>width != 0 {
+				return ErrOverflow
+			}
+		}
+	}
+	w.lock()
+	defer w.unlock()
+	w.pack(values, width)
+	return w.err
+}
+
+// PackAll writes the low width bits of each of values, one after another, the counterpart
+// of UnpackAll. Unlike PackUints it does not check that values fit; bits above width are
+// dropped, as WriteBits does. The fields are gathered into 64-bit words with shifts and
+// written a word at a time, which replaces one WriteBits call per value in bulk ingestion.
+//
+// Panics if width < 1 or width > 64.
+func (w *BitWriter[T]) PackAll(values []uint64, width int) {
+	if width < 1 || width > 64 {
+		panic("bitstream: pack width must be between 1 and 64")
+	}
+	w.lock()
+	defer w.unlock()
+	w.pack(values, width)
+}
+
+// pack appends the low width bits of each of values, 64 bits at a time.
+// Nothing is written if the fields would exceed the limit set by NewBitWriterLimit.
+func (w *BitWriter[T]) pack(values []uint64, width int) {
+	if !w.reserve(w.bits + len(values)*width) {
+		return
+	}
+	w.grow(len(values) * width)
+	var acc uint64 // pending bits, right-aligned; bits above have are stale
+	have := 0      // number of pending bits
+	for _, v := range values {
+		if width < 64 {
+			v &= 1<<width - 1
+		}
+		if have+width <= 64 {
+			acc = acc<<width | v
+			if have += width; have == 64 {
+				w.writeBits(acc, 64)
+				have = 0
+			}
+			continue
+		}
+		k := 64 - have // leading bits of v that complete the word
+		w.writeBits(acc<<k|v>>(width-k), 64)
+		acc = v
+		have = width - k
+	}
+	w.writeBits(acc, have)
+}
+
+// UnpackUints reads n width-bit values written by PackUints and advances the cursor.
+// Returns io.EOF if no valid bits remain and io.ErrUnexpectedEOF if fewer than n*width
+// bits remain; the cursor is not moved on error.
+//
+// Panics if width < 1 or width > 64, or if n is negative.
+func (r *BitReader[T]) UnpackUints(n, width int) ([]uint64, error) {
+	if width < 1 || width > 64 {
+		panic("bitstream: pack width must be between 1 and 64")
+	}
+	if n < 0 {
+		panic("bitstream: negative value count")
+	}
+	if n == 0 {
+		return nil, nil
+	}
+	if r.pos >= r.bits {
+		return nil, io.EOF
+	}
+	if n > (r.bits-r.pos)/width {
+		return nil, io.ErrUnexpectedEOF
+	}
+	values := make([]uint64, n)
+	r.unpack(values, width)
+	return values, nil
+}
+
+// UnpackAll reads every whole width-bit field from the cursor to the end of the valid bits
+// and advances the cursor past them; fewer than width trailing bits are left unread.
+// It is the columnar counterpart of calling ReadBits in a loop, but fetches the stream
+// 64 bits at a time and splits the fields out with shifts, so small widths cost a fraction
+// of a ReadBits call per field. Returns an empty slice if less than one field remains.
+//
+// Panics if width < 1 or width > 64.
+func (r *BitReader[T]) UnpackAll(width int) []uint64 {
+	if width < 1 || width > 64 {
+		panic("bitstream: pack width must be between 1 and 64")
+	}
+	values := make([]uint64, max(r.bits-r.pos, 0)/width)
+	r.unpack(values, width)
+	return values
+}
+
+// unpack fills values with consecutive width-bit fields at the cursor and advances it.
+// The caller must make sure the fields are within the valid bits.
+func (r *BitReader[T]) unpack(values []uint64, width int) {
+	end := r.pos + len(values)*width
+	pos := r.pos
+	var acc uint64 // bits fetched but not yet used, left-aligned
+	have := 0      // number of bits in acc
+	for i := range values {
+		if have >= width {
+			values[i] = acc >> (64 - width)
+			acc <<= width
+			have -= width
+			continue
+		}
+		k := min(64, end-pos)
+		word := r.bitsAt(pos, k) << (64 - k)
+		pos += k
+		need := width - have
+		values[i] = acc>>(64-width) | word>>(64-need)
+		acc = word << need
+		have = k - need
+	}
+	r.pos = end
+}
+
+// simple8b lists the value count and width of each Simple-8b selector.
+// Selectors 0 and 1 encode runs of 240 and 120 ones and carry no payload.
+var simple8b = [16]struct{ n, width int }{
+	{240, 0}, {120, 0}, {60, 1}, {30, 2}, {20, 3}, {15, 4}, {12, 5}, {10, 6},
+	{8, 7}, {7, 8}, {6, 10}, {5, 12}, {4, 15}, {3, 20}, {2, 30}, {1, 60},
+}
+
+// WriteSimple8b packs values with the Simple-8b codec: each 64-bit word holds a 4-bit
+// selector followed by as many values as fit at the smallest width that suits the next run,
+// so blocks of small numbers take few bits each. Values are stored MSB-first in stream order.
+// The words need not be aligned. Returns ErrOverflow, writing nothing, if a value is 2^60 or more,
+// and ErrBudgetExceeded, writing nothing, if the words would exceed the limit set by
+// NewBitWriterLimit.
+func (w *BitWriter[T]) WriteSimple8b(values []uint64) error {
+	for _, v := range values {
+		if v>>60 != 0 {
+			return ErrOverflow
+		}
+	}
+	w.lock()
+	defer w.unlock()
+	words := 0
+	for rest := values; len(rest) > 0; words++ {
+		rest = rest[simple8b[simple8bSelector(rest)].n:]
+	}
+	if !w.reserveBits(64 * words) {
+		return w.err
+	}
+	for len(values) > 0 {
+		sel := simple8bSelector(values)
+		w.writeBits(uint64(sel), 4)
+		n, width := simple8b[sel].n, simple8b[sel].width
+		if width > 0 {
+			for _, v := range values[:n] {
+				w.writeBits(v, width)
+			}
+			w.writeBits(0, 60-n*width)
+		} else {
+			w.writeBits(0, 60)
+		}
+		values = values[n:]
+	}
+	return nil
+}
+
+// simple8bSelector returns the first selector that packs a full word from the start of values.
+func simple8bSelector(values []uint64) int {
+	for sel, s := range simple8b {
+		if s.n > len(values) {
+			continue
+		}
+		fits := true
+		for _, v := range values[:s.n] {
+			if s.width == 0 && v != 1 || s.width > 0 && v>>s.width != 0 {
+				fits = false
+				break
+			}
+		}
+		if fits {
+			return sel
+		}
+	}
+	panic("unreachable")
+}
+
+// ReadSimple8b reads the Simple-8b words holding the next n values written by WriteSimple8b
+// and advances the cursor past them.
+// Returns io.EOF if no valid bits remain, io.ErrUnexpectedEOF if a word is truncated or too few
+// words remain to hold n values, and ErrInvalidFormat if the words do not end exactly after
+// n values. The cursor is not moved on error.
+//
+// Panics if n is negative.
+func (r *BitReader[T]) ReadSimple8b(n int) ([]uint64, error) {
+	if n < 0 {
+		panic("bitstream: negative value count")
+	}
+	if n == 0 {
+		return nil, nil
+	}
+	if r.pos >= r.bits {
+		return nil, io.EOF
+	}
+	// each word holds at most 240 values, so n bounds the input needed before allocating
+	if (n-1)/240 >= (r.bits-r.pos)/64 {
+		return nil, io.ErrUnexpectedEOF
+	}
+	start := r.pos
+	values := make([]uint64, 0, n)
+	for len(values) < n {
+		if r.pos+64 > r.bits {
+			r.pos = start
+			return nil, io.ErrUnexpectedEOF
+		}
+		word := r.bitsAt(r.pos, 64)
+		r.pos += 64
+		s := simple8b[word>>60]
+		if len(values)+s.n > n {
+			r.pos = start
+			return nil, ErrInvalidFormat
+		}
+		for i := range s.n {
+			if s.width == 0 {
+				values = append(values, 1)
+				continue
+			}
+			shift := 60 - (i+1)*s.width
+			values = append(values, word>>shift&(1<<s.width-1))
+		}
+	}
+	return values, nil
+}
@@ -0,0 +1,180 @@
+package bitstream
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"testing"
+)
+
+func TestPack(t *testing.T) {
+	t.Run("PackUints", func(t *testing.T) {
+		values := []uint64{5, 0, 31, 17, 8}
+		writer := NewBitWriter[uint16](1, 2)
+		writer.WriteBool(true)
+		if err := writer.PackUints(values, 5); err != nil {
+			t.Fatalf("PackUints() error = %v; want nil", err)
+		}
+		if got := writer.Bits(); got != 26 {
+			t.Errorf("Bits() = %d; want 26", got)
+		}
+		if err := writer.PackUints([]uint64{1, 32}, 5); err != ErrOverflow || writer.Bits() != 26 {
+			t.Errorf("PackUints() = %v with %d bits; want ErrOverflow with 26", err, writer.Bits())
+		}
+		reader := NewBitReader(writer.Data(), 1, 2)
+		reader.SetBits(writer.Bits())
+		reader.Skip(1)
+		if _, err := reader.UnpackUints(6, 5); err != io.ErrUnexpectedEOF || reader.Pos() != 1 {
+			t.Errorf("UnpackUints(6, 5) = %v at %d; want io.ErrUnexpectedEOF at 1", err, reader.Pos())
+		}
+		if got, err := reader.UnpackUints(5, 5); !slices.Equal(got, values) || err != nil {
+			t.Errorf("UnpackUints(5, 5) = %v, %v; want %v, nil", got, err, values)
+		}
+		if _, err := reader.UnpackUints(1, 5); err != io.EOF {
+			t.Errorf("UnpackUints(1, 5) at end error = %v; want io.EOF", err)
+		}
+	})
+
+	t.Run("PackUints64", func(t *testing.T) {
+		values := []uint64{1<<64 - 1, 0, 1 << 63}
+		writer := NewBitWriter[uint32](0, 0)
+		writer.PackUints(values, 64)
+		reader := NewBitReader(writer.Data(), 0, 0)
+		if got, _ := reader.UnpackUints(3, 64); !slices.Equal(got, values) {
+			t.Errorf("UnpackUints(3, 64) = %v; want %v", got, values)
+		}
+	})
+
+	t.Run("Simple8bSelectors", func(t *testing.T) {
+		ones := slices.Repeat([]uint64{1}, 240)
+		tests := []struct {
+			name   string
+			values []uint64
+			sels   []uint64
+		}{
+			{"Ones240", ones, []uint64{0}},
+			{"Ones120", ones[:120], []uint64{1}},
+			{"Bits1", slices.Repeat([]uint64{0, 1}, 30), []uint64{2}},
+			{"Bits8", []uint64{200, 1, 2, 3, 4, 5, 6}, []uint64{9}},
+			{"Tail", []uint64{1 << 29, 3, 1<<60 - 1}, []uint64{14, 15}},
+			{"Mixed", append(slices.Repeat([]uint64{7}, 20), 1000), []uint64{4, 15}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				writer := NewBitWriter[uint64](0, 0)
+				if err := writer.WriteSimple8b(tt.values); err != nil {
+					t.Fatalf("WriteSimple8b() error = %v; want nil", err)
+				}
+				data := writer.Data()
+				var sels []uint64
+				for _, word := range data {
+					sels = append(sels, word>>60)
+				}
+				if !slices.Equal(sels, tt.sels) {
+					t.Errorf("selectors = %v; want %v", sels, tt.sels)
+				}
+				reader := NewBitReader(data, 0, 0)
+				if got, err := reader.ReadSimple8b(len(tt.values)); !slices.Equal(got, tt.values) || err != nil {
+					t.Errorf("ReadSimple8b(%d) = %v, %v; want %v, nil", len(tt.values), got, err, tt.values)
+				}
+			})
+		}
+	})
+
+	t.Run("Simple8bUnaligned", func(t *testing.T) {
+		values := []uint64{3, 1, 4, 1, 5, 9, 2, 6, 5, 3, 5, 8, 9, 7, 9, 3, 2, 3, 8, 4, 6, 2, 6, 4, 3, 3, 8, 3, 2, 7, 9, 5}
+		writer := NewBitWriter[uint8](0, 0)
+		writer.WriteBits(0b101, 3)
+		writer.WriteSimple8b(values)
+		writer.WriteSimple8b([]uint64{42})
+		reader := NewBitReader(writer.Data(), 0, 0)
+		reader.SetBits(writer.Bits())
+		reader.Skip(3)
+		if _, err := reader.ReadSimple8b(10); err != ErrInvalidFormat || reader.Pos() != 3 {
+			t.Errorf("ReadSimple8b(10) = %v at %d; want ErrInvalidFormat at 3", err, reader.Pos())
+		}
+		if got, err := reader.ReadSimple8b(len(values)); !slices.Equal(got, values) || err != nil {
+			t.Errorf("ReadSimple8b() = %v, %v; want %v, nil", got, err, values)
+		}
+		reader.SetBits(reader.Bits() - 1)
+		if _, err := reader.ReadSimple8b(1); err != io.ErrUnexpectedEOF {
+			t.Errorf("ReadSimple8b(1) on truncated word error = %v; want io.ErrUnexpectedEOF", err)
+		}
+		reader = NewBitReader(make([]uint8, 8), 0, 0)
+		if _, err := reader.ReadSimple8b(1 << 60); err != io.ErrUnexpectedEOF || reader.Pos() != 0 {
+			t.Errorf("ReadSimple8b(1<<60) on one word = %v at %d; want io.ErrUnexpectedEOF at 0", err, reader.Pos())
+		}
+	})
+
+	t.Run("Simple8bOverflow", func(t *testing.T) {
+		writer := NewBitWriter[uint64](0, 0)
+		if err := writer.WriteSimple8b([]uint64{1, 1 << 60}); err != ErrOverflow || writer.Bits() != 0 {
+			t.Errorf("WriteSimple8b() = %v with %d bits; want ErrOverflow with 0", err, writer.Bits())
+		}
+	})
+}
+
+func TestUnpackAll(t *testing.T) {
+	for _, width := range []int{1, 3, 7, 8, 13, 31, 32, 33, 57, 63, 64} {
+		t.Run(fmt.Sprintf("width%d", width), func(t *testing.T) {
+			want := make([]uint64, 200)
+			x := uint64(0x243F6A8885A308D3)
+			for i := range want {
+				x = x*6364136223846793005 + 1442695040888963407
+				want[i] = x
+				if width < 64 {
+					want[i] &= 1<<width - 1
+				}
+			}
+			writer := NewBitWriter[uint16](3, 2)
+			writer.WriteBits(0b101, 3)
+			writer.PackUints(want, width)
+			writer.WriteBits(0, width-1)
+			reader := NewBitReader(writer.Data(), 3, 2)
+			reader.SetBits(writer.Bits())
+			reader.Skip(3)
+			got := reader.UnpackAll(width)
+			if !slices.Equal(got, want) {
+				t.Fatalf("UnpackAll(%d) = %v; want %v", width, got[:4], want[:4])
+			}
+			if reader.Pos() != 3+len(want)*width {
+				t.Errorf("Pos() = %d; want %d", reader.Pos(), 3+len(want)*width)
+			}
+			if rest := reader.UnpackAll(width); len(rest) != 0 {
+				t.Errorf("UnpackAll() at the end = %v; want empty", rest)
+			}
+		})
+	}
+}
+
+func TestPackAll(t *testing.T) {
+	for _, width := range []int{1, 5, 8, 13, 32, 47, 63, 64} {
+		t.Run(fmt.Sprintf("width%d", width), func(t *testing.T) {
+			values := make([]uint64, 150)
+			x := uint64(0x13198A2E03707344)
+			for i := range values {
+				x = x*6364136223846793005 + 1442695040888963407
+				values[i] = x
+			}
+			got := NewBitWriter[uint32](2, 1)
+			got.WriteBits(1, 1)
+			got.PackAll(values, width)
+			want := NewBitWriter[uint32](2, 1)
+			want.WriteBits(1, 1)
+			for _, v := range values {
+				want.WriteBits(v, width)
+			}
+			if got.Bits() != want.Bits() || !slices.Equal(got.Data(), want.Data()) {
+				t.Errorf("PackAll(%d) = %d bits %x; want %d bits %x", width, got.Bits(), got.Data()[:4], want.Bits(), want.Data()[:4])
+			}
+		})
+	}
+
+	t.Run("Budget", func(t *testing.T) {
+		writer := NewBitWriterLimit[uint8](0, 0, 100)
+		writer.PackAll(make([]uint64, 11), 10)
+		if writer.Bits() != 0 || writer.Err() == nil {
+			t.Errorf("PackAll() over the limit: Bits() = %d, Err() = %v; want 0, ErrBudgetExceeded", writer.Bits(), writer.Err())
+		}
+	})
+}
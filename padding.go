@@ -0,0 +1,84 @@
+package bitstream
+
+import "fmt"
+
+// PaddingError reports elements whose padding bits do not hold the expected fill.
+type PaddingError struct {
+	Elements []int // Indices of the offending elements, in increasing order
+}
+
+func (e *PaddingError) Error() string {
+	return fmt.Sprintf("bitstream: padding bits of %d elements do not match the fill, first at element %d",
+		len(e.Elements), e.Elements[0])
+}
+
+// LeftPadding returns the number of padding bits at the top of each element.
+func (r *BitReader[T]) LeftPadding() int {
+	return r.lp
+}
+
+// RightPadding returns the number of padding bits at the bottom of each element.
+func (r *BitReader[T]) RightPadding() int {
+	return r.rp
+}
+
+// ElementBits returns the number of valid bits in each element.
+func (r *BitReader[T]) ElementBits() int {
+	return r.s
+}
+
+// Validate checks that the padding bits of every element of Data() are 0, as written by
+// a BitWriter with the default fill, and returns a *PaddingError listing the elements
+// where they are not. Corrupted or misconfigured input often shows up here first.
+func (r *BitReader[T]) Validate() error {
+	return r.ValidateFill(FillZeros)
+}
+
+// ValidateFill is like Validate, but expects the padding bits to hold fill f.
+//
+// Panics if f is not one of the Fill constants.
+func (r *BitReader[T]) ValidateFill(f Fill) error {
+	if f < FillZeros || f > FillAlternating {
+		panic("bitstream: invalid fill")
+	}
+	pad := ^((r.msb<<1 - 1) >> r.rp << r.rp)
+	if pad == 0 {
+		return nil
+	}
+	want := fillElem[T](f) & pad
+	var bad []int
+	for i, v := range r.data {
+		if v&pad != want {
+			bad = append(bad, i)
+		}
+	}
+	if bad != nil {
+		return &PaddingError{Elements: bad}
+	}
+	return nil
+}
+
+// LeftPadding returns the number of padding bits at the top of each element.
+func (w *BitWriter[T]) LeftPadding() int {
+	return w.lp
+}
+
+// RightPadding returns the number of padding bits at the bottom of each element.
+func (w *BitWriter[T]) RightPadding() int {
+	return w.rp
+}
+
+// ElementBits returns the number of valid bits in each element.
+func (w *BitWriter[T]) ElementBits() int {
+	return w.s
+}
+
+// Validate checks that the padding bits of every element of Data() hold the fill set by
+// SetFill, which may not be the case after Data() has been modified directly, and returns
+// a *PaddingError listing the elements where they do not.
+func (w *BitWriter[T]) Validate() error {
+	w.lock()
+	defer w.unlock()
+	r := w.reader()
+	return r.ValidateFill(w.fill)
+}
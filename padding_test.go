@@ -0,0 +1,55 @@
+package bitstream
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestPadding(t *testing.T) {
+	t.Run("Accessors", func(t *testing.T) {
+		reader := NewBitReader([]uint16{}, 3, 2)
+		if reader.LeftPadding() != 3 || reader.RightPadding() != 2 || reader.ElementBits() != 11 {
+			t.Errorf("reader padding = %d, %d, %d; want 3, 2, 11", reader.LeftPadding(), reader.RightPadding(), reader.ElementBits())
+		}
+		writer := NewBitWriter[uint8](0, 1)
+		if writer.LeftPadding() != 0 || writer.RightPadding() != 1 || writer.ElementBits() != 7 {
+			t.Errorf("writer padding = %d, %d, %d; want 0, 1, 7", writer.LeftPadding(), writer.RightPadding(), writer.ElementBits())
+		}
+	})
+
+	t.Run("Reader", func(t *testing.T) {
+		reader := NewBitReader([]uint8{0b0111_1110, 0b1111_1110, 0b0000_0001, 0b0101_0100}, 1, 1)
+		err := reader.Validate()
+		var perr *PaddingError
+		if !errors.As(err, &perr) || !slices.Equal(perr.Elements, []int{1, 2}) {
+			t.Fatalf("Validate() = %v; want PaddingError for elements [1 2]", err)
+		}
+		if got := err.Error(); got != "bitstream: padding bits of 2 elements do not match the fill, first at element 1" {
+			t.Errorf("Error() = %q", got)
+		}
+		if err := NewBitReader([]uint8{0xFF}, 0, 0).Validate(); err != nil {
+			t.Errorf("Validate() without padding = %v; want nil", err)
+		}
+		err = NewBitReader([]uint8{0b1000_0001, 0b1111_1111, 0b1000_0000}, 1, 1).ValidateFill(FillOnes)
+		if !errors.As(err, &perr) || !slices.Equal(perr.Elements, []int{2}) {
+			t.Errorf("ValidateFill(FillOnes) = %v; want PaddingError for element 2", err)
+		}
+	})
+
+	t.Run("Writer", func(t *testing.T) {
+		writer := NewBitWriter[uint16](2, 3)
+		writer.SetFill(FillAlternating)
+		for range 5 {
+			writer.WriteBits(0x3FF, 10)
+		}
+		if err := writer.Validate(); err != nil {
+			t.Errorf("Validate() = %v; want nil", err)
+		}
+		writer.Data()[3] ^= 1
+		var perr *PaddingError
+		if err := writer.Validate(); !errors.As(err, &perr) || !slices.Equal(perr.Elements, []int{3}) {
+			t.Errorf("Validate() after corruption = %v; want PaddingError for element 3", err)
+		}
+	})
+}
@@ -0,0 +1,139 @@
+package bitstream
+
+import (
+	"io"
+	"math/bits"
+	"slices"
+)
+
+// MaxPFORBlock is the largest number of values in a block written by WritePFOR.
+// ReadPFOR rejects larger counts before allocating, since a block of equal values takes
+// only a few bytes whatever its count.
+const MaxPFORBlock = 1 << 20
+
+// WritePFOR compresses values with patched frame-of-reference coding, as used for integer
+// columns and posting lists: the minimum is stored once, every value is bit-packed as its
+// difference from the minimum in a common width, and the few differences too wide for it are
+// patched in afterwards as exceptions. The width is chosen to minimize the encoded size, so
+// without outliers this is plain frame-of-reference coding.
+//
+// The block is byte-aligned and self-describing: uvarint count, uvarint minimum, one byte of
+// width and a uvarint exception count, then the packed differences, then for each exception
+// the uvarint distance from the previous exception and the uvarint of its bits above the width.
+// Split longer columns into several blocks.
+//
+// Panics if len(values) > MaxPFORBlock.
+func (w *BitWriter[T]) WritePFOR(values []uint64) {
+	if len(values) > MaxPFORBlock {
+		panic("bitstream: PFOR block exceeds MaxPFORBlock values")
+	}
+	w.lock()
+	defer w.unlock()
+	w.writeUvarint(uint64(len(values)))
+	if len(values) == 0 {
+		return
+	}
+	base := slices.Min(values)
+	var hist [65]int
+	for _, v := range values {
+		hist[bits.Len64(v-base)]++
+	}
+	width, best := 64, len(values)*64
+	for b := range 64 {
+		cost := len(values) * b
+		for l := b + 1; l <= 64; l++ {
+			cost += hist[l] * 8 * (2 + (l-b-1)/7)
+		}
+		if cost < best {
+			width, best = b, cost
+		}
+	}
+	var exceptions int
+	for l := width + 1; l <= 64; l++ {
+		exceptions += hist[l]
+	}
+	w.writeUvarint(base)
+	w.writeBits(uint64(width), 8)
+	w.writeUvarint(uint64(exceptions))
+	w.grow(len(values) * width)
+	for _, v := range values {
+		w.writeBits(v-base, width)
+	}
+	prev := 0
+	for i, v := range values {
+		if width < 64 && (v-base)>>width != 0 {
+			w.writeUvarint(uint64(i - prev))
+			w.writeUvarint((v - base) >> width)
+			prev = i
+		}
+	}
+}
+
+// ReadPFOR reads a block written by WritePFOR, aligning the cursor to a byte boundary first,
+// and returns the decoded values.
+// Returns io.EOF if no valid bits remain after alignment, io.ErrUnexpectedEOF if the block
+// is truncated, and ErrInvalidFormat if it is malformed or holds more than MaxPFORBlock values.
+// The cursor is not moved on error.
+func (r *BitReader[T]) ReadPFOR() ([]uint64, error) {
+	start := r.pos
+	values, err := r.readPFOR()
+	if err != nil {
+		r.pos = start
+		return nil, err
+	}
+	return values, nil
+}
+
+func (r *BitReader[T]) readPFOR() ([]uint64, error) {
+	n, err := r.ReadUvarint()
+	if err != nil || n == 0 {
+		return nil, err
+	}
+	base, err := r.ReadUvarint()
+	if err != nil {
+		return nil, eofAsUnexpected(err)
+	}
+	width, err := r.ReadBits(8)
+	if err != nil {
+		return nil, eofAsUnexpected(err)
+	}
+	exceptions, err := r.ReadUvarint()
+	if err != nil {
+		return nil, eofAsUnexpected(err)
+	}
+	if n > MaxPFORBlock || width > 64 || exceptions > n || width == 64 && exceptions > 0 {
+		return nil, ErrInvalidFormat
+	}
+	// every exception takes at least two bytes after the packed values
+	if rest := uint64(r.bits - r.pos); n*width > rest || exceptions > (rest-n*width)/16 {
+		return nil, io.ErrUnexpectedEOF
+	}
+	values := make([]uint64, n)
+	for i := range values {
+		values[i] = r.bitsAt(r.pos, int(width))
+		r.pos += int(width)
+	}
+	var pos uint64
+	for i := range exceptions {
+		d, err := r.ReadUvarint()
+		if err != nil {
+			return nil, eofAsUnexpected(err)
+		}
+		high, err := r.ReadUvarint()
+		if err != nil {
+			return nil, eofAsUnexpected(err)
+		}
+		pos += d
+		if i > 0 && d == 0 || pos >= n || high == 0 || bits.Len64(high)+int(width) > 64 {
+			return nil, ErrInvalidFormat
+		}
+		values[pos] |= high << width
+	}
+	for i, v := range values {
+		if v+base < base {
+			return nil, ErrInvalidFormat
+		}
+		values[i] = v + base
+	}
+	return values, nil
+}
@@ -0,0 +1,101 @@
+package bitstream
+
+import (
+	"io"
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestPFOR(t *testing.T) {
+	t.Run("RoundTrip", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			values []uint64
+			width  uint64
+		}{
+			{"Empty", nil, 0},
+			{"Constant", slices.Repeat([]uint64{1 << 50}, 100), 0},
+			{"FrameOfReference", []uint64{1000, 1003, 1001, 1007, 1002}, 3},
+			{"Outliers", append(slices.Repeat([]uint64{10, 11, 12, 13}, 32), 1<<40, 10, 1<<33), 2},
+			{"FullRange", []uint64{0, math.MaxUint64, 1}, 1},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				writer := NewBitWriter[uint32](0, 0)
+				writer.WriteBits(1, 1)
+				writer.WritePFOR(tt.values)
+				writer.WritePFOR([]uint64{7})
+				reader := NewBitReader(writer.Data(), 0, 0)
+				reader.SetBits(writer.Bits())
+				reader.Skip(1)
+				if len(tt.values) > 0 {
+					// count and minimum are uvarints, then the width byte
+					probe := reader.Clone()
+					probe.ReadUvarint()
+					probe.ReadUvarint()
+					if got, _ := probe.ReadBits(8); got != tt.width {
+						t.Errorf("width = %d; want %d", got, tt.width)
+					}
+				}
+				if got, err := reader.ReadPFOR(); !slices.Equal(got, tt.values) || err != nil {
+					t.Errorf("ReadPFOR() = %v, %v; want %v, nil", got, err, tt.values)
+				}
+				if got, err := reader.ReadPFOR(); !slices.Equal(got, []uint64{7}) || err != nil {
+					t.Errorf("second ReadPFOR() = %v, %v; want [7], nil", got, err)
+				}
+				if _, err := reader.ReadPFOR(); err != io.EOF {
+					t.Errorf("ReadPFOR() at end error = %v; want io.EOF", err)
+				}
+			})
+		}
+	})
+
+	t.Run("Size", func(t *testing.T) {
+		values := make([]uint64, 128)
+		for i := range values {
+			values[i] = 1_000_000 + uint64(i%16)
+		}
+		values[64] = 1 << 45
+		writer := NewBitWriter[uint8](0, 0)
+		writer.WritePFOR(values)
+		if got := writer.Bits(); got > 128*4+16*8 {
+			t.Errorf("Bits() = %d; want at most %d", got, 128*4+16*8)
+		}
+	})
+
+	t.Run("Truncated", func(t *testing.T) {
+		writer := NewBitWriter[uint8](0, 0)
+		writer.WritePFOR([]uint64{5, 6, 1 << 20, 8})
+		for _, bits := range []int{8, 20, writer.Bits() - 8} {
+			reader := NewBitReader(writer.Data(), 0, 0)
+			reader.SetBits(bits)
+			if _, err := reader.ReadPFOR(); err != io.ErrUnexpectedEOF || reader.Pos() != 0 {
+				t.Errorf("ReadPFOR() with %d bits = %v at %d; want io.ErrUnexpectedEOF at 0", bits, err, reader.Pos())
+			}
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		// count 2, minimum 0, width 65
+		reader := NewBitReader([]uint8{2, 0, 65, 0}, 0, 0)
+		if _, err := reader.ReadPFOR(); err != ErrInvalidFormat {
+			t.Errorf("ReadPFOR() with width 65 error = %v; want ErrInvalidFormat", err)
+		}
+		// count 1, minimum 0, width 0, one exception at position 1
+		reader = NewBitReader([]uint8{1, 0, 0, 1, 1, 1}, 0, 0)
+		if _, err := reader.ReadPFOR(); err != ErrInvalidFormat || reader.Pos() != 0 {
+			t.Errorf("ReadPFOR() with bad exception = %v at %d; want ErrInvalidFormat at 0", err, reader.Pos())
+		}
+		// count near 1<<62, minimum 0, width 0, no exceptions: rejected before allocating
+		reader = NewBitReader([]uint8{0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x40, 0, 0, 0, 0}, 0, 0)
+		if _, err := reader.ReadPFOR(); err != ErrInvalidFormat || reader.Pos() != 0 {
+			t.Errorf("ReadPFOR() with huge count = %v at %d; want ErrInvalidFormat at 0", err, reader.Pos())
+		}
+		// count MaxPFORBlock, width 0, MaxPFORBlock exceptions without the bytes to hold them
+		reader = NewBitReader([]uint8{0x80, 0x80, 0x40, 0, 0, 0x80, 0x80, 0x40}, 0, 0)
+		if _, err := reader.ReadPFOR(); err != io.ErrUnexpectedEOF {
+			t.Errorf("ReadPFOR() with missing exceptions = %v; want io.ErrUnexpectedEOF", err)
+		}
+	})
+}
@@ -0,0 +1,298 @@
+package bitstream
+
+import (
+	"context"
+	"io"
+	"sync"
+)
+
+// bitQueue is a FIFO of bits stored MSB-first in 64-bit words.
+type bitQueue struct {
+	words []uint64
+	r, w  int // read and write positions in bits
+}
+
+func (q *bitQueue) len() int {
+	return q.w - q.r
+}
+
+// push appends the low bits bits of data, for 1 <= bits <= 64.
+func (q *bitQueue) push(data uint64, bits int) {
+	if q.r >= 64*len(q.words)/2 && q.r >= 64 {
+		// Drop the words already read once they make up half the buffer.
+		n := copy(q.words, q.words[q.r/64:])
+		q.words = q.words[:n]
+		q.w -= q.r / 64 * 64
+		q.r %= 64
+	}
+	data <<= 64 - bits
+	if q.w%64 == 0 {
+		q.words = append(q.words, data)
+	} else {
+		q.words[q.w/64] |= data >> (q.w % 64)
+		if k := 64 - q.w%64; k < bits {
+			q.words = append(q.words, data<<k)
+		}
+	}
+	q.w += bits
+}
+
+// pop removes and returns bits bits, right-aligned, for 1 <= bits <= min(64, len()).
+func (q *bitQueue) pop(bits int) uint64 {
+	v := q.peek(bits)
+	q.r += bits
+	if q.r == q.w {
+		q.words, q.r, q.w = q.words[:0], 0, 0
+	}
+	return v
+}
+
+// peek returns the next bits bits, right-aligned, for 1 <= bits <= min(64, len()).
+func (q *bitQueue) peek(bits int) uint64 {
+	i, o := q.r/64, q.r%64
+	v := q.words[i] << o
+	if o+bits > 64 {
+		v |= q.words[i+1] >> (64 - o)
+	}
+	return v >> (64 - bits)
+}
+
+// pipe is the state shared by a PipeBitReader and PipeBitWriter.
+type pipe struct {
+	mu   sync.Mutex
+	q    bitQueue
+	wait chan struct{} // closed and replaced whenever bits arrive or an end closes
+	rerr error         // set when the reader is closed
+	werr error         // set when the writer is closed
+}
+
+// wake releases the readers waiting for bits. p.mu must be held.
+func (p *pipe) wake() {
+	close(p.wait)
+	p.wait = make(chan struct{})
+}
+
+// BitPipe creates a synchronous in-memory pipe carrying bits, like io.Pipe at bit
+// granularity: bits written to the PipeBitWriter can be read from the PipeBitReader,
+// with reads blocking until enough bits have been written or the writer is closed.
+// Writes are buffered and never block, so the reader may ask for more bits than any single
+// write provides. Both ends are safe for concurrent use.
+func BitPipe() (*PipeBitReader, *PipeBitWriter) {
+	p := &pipe{wait: make(chan struct{})}
+	return &PipeBitReader{p: p}, &PipeBitWriter{p: p}
+}
+
+// PipeBitReader is the read half of a BitPipe.
+type PipeBitReader struct {
+	p *pipe
+}
+
+// read waits until bits bits are buffered, the pipe is closed or ctx is done, then calls fn
+// on the queue.
+func (r *PipeBitReader) read(ctx context.Context, bits int, fn func(q *bitQueue)) error {
+	p := r.p
+	for {
+		p.mu.Lock()
+		switch {
+		case p.rerr != nil:
+			p.mu.Unlock()
+			return io.ErrClosedPipe
+		case p.q.len() >= bits:
+			fn(&p.q)
+			p.mu.Unlock()
+			return nil
+		case p.werr != nil:
+			err := p.werr
+			if p.q.len() > 0 {
+				err = io.ErrUnexpectedEOF
+			}
+			p.mu.Unlock()
+			return err
+		}
+		wait := p.wait
+		p.mu.Unlock()
+		select {
+		case <-wait:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// ReadBits blocks until bits bits have been written and returns them right-aligned.
+// If the writer is closed first, returns io.EOF (or the error passed to CloseWithError)
+// when no bits remain, and io.ErrUnexpectedEOF when fewer than bits remain; the remaining
+// bits stay in the pipe. Returns io.ErrClosedPipe once the reader is closed.
+//
+// Panics if bits > 64.
+func (r *PipeBitReader) ReadBits(bits int) (uint64, error) {
+	return r.ReadBitsContext(context.Background(), bits)
+}
+
+// ReadBitsContext is like ReadBits but stops waiting when ctx is done, returning ctx.Err()
+// without consuming any bits.
+//
+// Panics if bits > 64.
+func (r *PipeBitReader) ReadBitsContext(ctx context.Context, bits int) (uint64, error) {
+	if bits > 64 {
+		panic(&WidthError{Op: "read", Width: bits, Max: 64})
+	}
+	if bits <= 0 {
+		return 0, nil
+	}
+	var v uint64
+	err := r.read(ctx, bits, func(q *bitQueue) {
+		v = q.pop(bits)
+	})
+	return v, err
+}
+
+// ReadBit reads one bit with the blocking and errors of ReadBits.
+func (r *PipeBitReader) ReadBit() (bool, error) {
+	v, err := r.ReadBits(1)
+	return v != 0, err
+}
+
+// ReadBitContext is like ReadBit but stops waiting when ctx is done, returning ctx.Err().
+func (r *PipeBitReader) ReadBitContext(ctx context.Context) (bool, error) {
+	v, err := r.ReadBitsContext(ctx, 1)
+	return v != 0, err
+}
+
+// Read implements io.Reader. It blocks until at least one whole byte has been written and
+// returns the buffered whole bytes, packed MSB-first. After the writer is closed, a final
+// partial byte is returned padded with zero bits.
+func (r *PipeBitReader) Read(p []byte) (int, error) {
+	return r.ReadContext(context.Background(), p)
+}
+
+// ReadContext is like Read but stops waiting when ctx is done, returning 0 and ctx.Err().
+func (r *PipeBitReader) ReadContext(ctx context.Context, p []byte) (n int, err error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	err = r.read(ctx, 8, func(q *bitQueue) {
+		for n < len(p) && q.len() >= 8 {
+			p[n] = byte(q.pop(8))
+			n++
+		}
+	})
+	if err == io.ErrUnexpectedEOF {
+		r.p.mu.Lock()
+		defer r.p.mu.Unlock()
+		if k := r.p.q.len(); k > 0 && k < 8 {
+			p[0] = byte(r.p.q.pop(k) << (8 - k))
+			return 1, nil
+		}
+		return 0, r.p.werr
+	}
+	return n, err
+}
+
+// Buffered returns the number of bits written but not yet read.
+func (r *PipeBitReader) Buffered() int {
+	r.p.mu.Lock()
+	defer r.p.mu.Unlock()
+	return r.p.q.len()
+}
+
+// Close closes the reader; subsequent writes fail with io.ErrClosedPipe.
+func (r *PipeBitReader) Close() error {
+	return r.CloseWithError(nil)
+}
+
+// CloseWithError closes the reader; subsequent writes fail with err, or io.ErrClosedPipe
+// if err is nil.
+func (r *PipeBitReader) CloseWithError(err error) error {
+	if err == nil {
+		err = io.ErrClosedPipe
+	}
+	p := r.p
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.rerr == nil {
+		p.rerr = err
+		p.wake()
+	}
+	return nil
+}
+
+// PipeBitWriter is the write half of a BitPipe.
+type PipeBitWriter struct {
+	p *pipe
+}
+
+// WriteBits appends the low bits bits of data to the pipe and wakes any waiting reader.
+// Returns io.ErrClosedPipe after the writer is closed, or the reader's close error.
+//
+// Panics if bits > 64.
+func (w *PipeBitWriter) WriteBits(data uint64, bits int) error {
+	if bits > 64 {
+		panic(&WidthError{Op: "write", Width: bits, Max: 64})
+	}
+	p := w.p
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.werr != nil {
+		return io.ErrClosedPipe
+	}
+	if p.rerr != nil {
+		return p.rerr
+	}
+	if bits <= 0 {
+		return nil
+	}
+	p.q.push(data, bits)
+	p.wake()
+	return nil
+}
+
+// WriteBit appends one bit, with the errors of WriteBits.
+func (w *PipeBitWriter) WriteBit(bit bool) error {
+	var v uint64
+	if bit {
+		v = 1
+	}
+	return w.WriteBits(v, 1)
+}
+
+// Write implements io.Writer, appending the bytes of p as 8-bit values.
+func (w *PipeBitWriter) Write(b []byte) (int, error) {
+	p := w.p
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.werr != nil {
+		return 0, io.ErrClosedPipe
+	}
+	if p.rerr != nil {
+		return 0, p.rerr
+	}
+	for _, c := range b {
+		p.q.push(uint64(c), 8)
+	}
+	if len(b) > 0 {
+		p.wake()
+	}
+	return len(b), nil
+}
+
+// Close closes the writer; once the buffered bits are read, reads return io.EOF.
+func (w *PipeBitWriter) Close() error {
+	return w.CloseWithError(nil)
+}
+
+// CloseWithError closes the writer; once the buffered bits are read, reads return err,
+// or io.EOF if err is nil.
+func (w *PipeBitWriter) CloseWithError(err error) error {
+	if err == nil {
+		err = io.EOF
+	}
+	p := w.p
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.werr == nil {
+		p.werr = err
+		p.wake()
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package bitstream
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestBitPipe(t *testing.T) {
+	t.Run("Concurrent", func(t *testing.T) {
+		pr, pw := BitPipe()
+		go func() {
+			for i := range 1000 {
+				pw.WriteBits(uint64(i), 3+i%7)
+			}
+			pw.Write([]byte{0xAB})
+			pw.WriteBit(true)
+			pw.Close()
+		}()
+		for i := range 1000 {
+			want := uint64(i) & (1<<(3+i%7) - 1)
+			if v, err := pr.ReadBits(3 + i%7); v != want || err != nil {
+				t.Fatalf("ReadBits() #%d = %d, %v; want %d, nil", i, v, err, want)
+			}
+		}
+		p := make([]byte, 4)
+		if n, err := pr.Read(p); n != 1 || err != nil || p[0] != 0xAB {
+			t.Errorf("Read() = %d, %v, %x; want 1, nil, ab", n, err, p[0])
+		}
+		if n, err := pr.Read(p); n != 1 || err != nil || p[0] != 0x80 {
+			t.Errorf("Read() of final bit = %d, %v, %x; want 1, nil, 80", n, err, p[0])
+		}
+		if _, err := pr.ReadBit(); err != io.EOF {
+			t.Errorf("ReadBit() after close error = %v; want io.EOF", err)
+		}
+	})
+
+	t.Run("WideReads", func(t *testing.T) {
+		pr, pw := BitPipe()
+		go func() {
+			for range 200 {
+				pw.WriteBits(0b1, 1)
+				pw.WriteBits(0, 63)
+			}
+			pw.Close()
+		}()
+		for i := range 200 {
+			if v, _ := pr.ReadBits(64); v != 1<<63 {
+				t.Fatalf("ReadBits(64) #%d = %#x; want %#x", i, v, uint64(1)<<63)
+			}
+		}
+	})
+
+	t.Run("Close", func(t *testing.T) {
+		pr, pw := BitPipe()
+		pw.WriteBits(0b101, 3)
+		if pr.Buffered() != 3 {
+			t.Errorf("Buffered() = %d; want 3", pr.Buffered())
+		}
+		errTest := errors.New("test")
+		pw.CloseWithError(errTest)
+		if _, err := pr.ReadBits(4); err != io.ErrUnexpectedEOF || pr.Buffered() != 3 {
+			t.Errorf("ReadBits(4) = %v with %d buffered; want io.ErrUnexpectedEOF with 3", err, pr.Buffered())
+		}
+		pr.ReadBits(3)
+		if _, err := pr.ReadBits(1); err != errTest {
+			t.Errorf("ReadBits(1) error = %v; want %v", err, errTest)
+		}
+		if err := pw.WriteBits(1, 1); err != io.ErrClosedPipe {
+			t.Errorf("WriteBits() after close error = %v; want io.ErrClosedPipe", err)
+		}
+
+		pr, pw = BitPipe()
+		done := make(chan error)
+		go func() {
+			_, err := pr.ReadBits(8)
+			done <- err
+		}()
+		pr.Close()
+		if err := <-done; err != io.ErrClosedPipe {
+			t.Errorf("blocked ReadBits() error after reader close = %v; want io.ErrClosedPipe", err)
+		}
+		if _, err := pw.Write([]byte{1}); err != io.ErrClosedPipe {
+			t.Errorf("Write() after reader close error = %v; want io.ErrClosedPipe", err)
+		}
+	})
+}
+
+func TestBitPipeContext(t *testing.T) {
+	pr, pw := BitPipe()
+	pw.WriteBits(0b11, 2)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := pr.ReadBitsContext(ctx, 3); err != context.DeadlineExceeded || pr.Buffered() != 2 {
+		t.Errorf("ReadBitsContext(3) = %v with %d buffered; want context.DeadlineExceeded with 2", err, pr.Buffered())
+	}
+	if _, err := pr.ReadContext(ctx, make([]byte, 1)); err != context.DeadlineExceeded {
+		t.Errorf("ReadContext() error = %v; want context.DeadlineExceeded", err)
+	}
+	// bits already buffered are returned even after the deadline
+	if bit, err := pr.ReadBitContext(ctx); !bit || err != nil {
+		t.Errorf("ReadBitContext() = %v, %v; want true, nil", bit, err)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	go pw.WriteBits(0b0, 1)
+	if v, err := pr.ReadBitsContext(ctx, 2); v != 0b10 || err != nil {
+		t.Errorf("ReadBitsContext(2) = %b, %v; want 10, nil", v, err)
+	}
+}
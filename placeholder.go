@@ -0,0 +1,48 @@
+package bitstream
+
+// Placeholder is a reserved field in a BitWriter that can be filled in later,
+// for example a header length that is only known once the payload has been written.
+type Placeholder[T Unsigned] struct {
+	w    *BitWriter[T]
+	pos  int
+	bits int
+}
+
+// Reserve appends bits zero bits to the stream and returns a Placeholder for them.
+// Call Fill on the Placeholder once the value is known.
+// A width of 0 or less reserves nothing and returns a Placeholder of width 0, as does a
+// reservation that would exceed the limit set by NewBitWriterLimit; Fill does nothing then.
+//
+// Panics if bits > 64.
+func (w *BitWriter[T]) Reserve(bits int) Placeholder[T] {
+	if bits > 64 {
+		panic("bitstream: cannot reserve more than 64 bits")
+	}
+	w.lock()
+	defer w.unlock()
+	p := Placeholder[T]{w: w, pos: w.bits}
+	if bits > 0 && w.reserveBits(bits) {
+		p.bits = bits
+		w.writeBits(0, bits)
+	}
+	return p
+}
+
+// Fill writes the low bits of v into the reserved field.
+// It may be called more than once; each call overwrites the previous value.
+func (p Placeholder[T]) Fill(v uint64) {
+	p.w.lock()
+	defer p.w.unlock()
+	p.w.traceWrite(p.pos, p.bits, v)
+	p.w.writeBitsAt(p.pos, p.bits, v)
+}
+
+// Pos returns the bit position of the reserved field.
+func (p Placeholder[T]) Pos() int {
+	return p.pos
+}
+
+// Bits returns the width of the reserved field in bits.
+func (p Placeholder[T]) Bits() int {
+	return p.bits
+}
@@ -0,0 +1,41 @@
+package bitstream
+
+import "testing"
+
+func TestPlaceholder(t *testing.T) {
+	writer := NewBitWriter[uint8](0, 2)
+	writer.WriteBits(0b11, 2)
+	length := writer.Reserve(12)
+	if length.Pos() != 2 || length.Bits() != 12 {
+		t.Errorf("Reserve(12) = pos %d, bits %d; want 2, 12", length.Pos(), length.Bits())
+	}
+	if writer.Bits() != 14 {
+		t.Errorf("expected bits to be 14 after Reserve, got %d", writer.Bits())
+	}
+	payload := writer.Bits()
+	for range 5 {
+		writer.WriteBits(0xFF, 8)
+	}
+	length.Fill(uint64(writer.Bits() - payload))
+
+	reader := NewBitReader(writer.Data(), 0, 2)
+	reader.SetBits(writer.Bits())
+	if got, _ := reader.ReadBits(2); got != 0b11 {
+		t.Errorf("prefix = %02b; want 11", got)
+	}
+	if got, _ := reader.ReadBits(12); got != 40 {
+		t.Errorf("filled length = %d; want 40", got)
+	}
+	for i := range 5 {
+		if got, _ := reader.ReadBits(8); got != 0xFF {
+			t.Errorf("payload byte %d = %08b; want 11111111", i, got)
+		}
+	}
+
+	// Fill overwrites a previous value and ignores bits above the field width
+	length.Fill(0xF001)
+	reader.Seek(2)
+	if got, _ := reader.ReadBits(12); got != 0x001 {
+		t.Errorf("refilled length = %03x; want 001", got)
+	}
+}
@@ -0,0 +1,58 @@
+package bitstream
+
+import (
+	"math/bits"
+	"unsafe"
+)
+
+// popcount returns the number of set bits in data, padding included.
+// The elements are counted through a byte view of their memory, 64 bits at a time once
+// aligned, which is independent of byte order and of the element type.
+func popcount[T Unsigned](data []T) int {
+	if len(data) == 0 {
+		return 0
+	}
+	b := unsafe.Slice((*byte)(unsafe.Pointer(unsafe.SliceData(data))), len(data)*int(unsafe.Sizeof(data[0])))
+	n := 0
+	for len(b) > 0 && uintptr(unsafe.Pointer(unsafe.SliceData(b)))%8 != 0 {
+		n += bits.OnesCount8(b[0])
+		b = b[1:]
+	}
+	words := unsafe.Slice((*uint64)(unsafe.Pointer(unsafe.SliceData(b))), len(b)/8)
+	n += popcountWords(words)
+	for _, c := range b[len(words)*8:] {
+		n += bits.OnesCount8(c)
+	}
+	return n
+}
+
+// popcountWords returns the number of set bits in words, eight words per iteration so
+// the independent population counts can execute in parallel.
+func popcountWords(words []uint64) int {
+	n := 0
+	for ; len(words) >= 8; words = words[8:] {
+		n += bits.OnesCount64(words[0]) + bits.OnesCount64(words[1]) +
+			bits.OnesCount64(words[2]) + bits.OnesCount64(words[3]) +
+			bits.OnesCount64(words[4]) + bits.OnesCount64(words[5]) +
+			bits.OnesCount64(words[6]) + bits.OnesCount64(words[7])
+	}
+	for _, w := range words {
+		n += bits.OnesCount64(w)
+	}
+	return n
+}
+
+// popcountMasked returns the number of set bits of data within mask, for padded elements.
+func popcountMasked[T Unsigned](data []T, mask T) int {
+	n := 0
+	for ; len(data) >= 8; data = data[8:] {
+		n += bits.OnesCount64(uint64(data[0]&mask)) + bits.OnesCount64(uint64(data[1]&mask)) +
+			bits.OnesCount64(uint64(data[2]&mask)) + bits.OnesCount64(uint64(data[3]&mask)) +
+			bits.OnesCount64(uint64(data[4]&mask)) + bits.OnesCount64(uint64(data[5]&mask)) +
+			bits.OnesCount64(uint64(data[6]&mask)) + bits.OnesCount64(uint64(data[7]&mask))
+	}
+	for _, e := range data {
+		n += bits.OnesCount64(uint64(e & mask))
+	}
+	return n
+}
@@ -0,0 +1,64 @@
+package bitstream
+
+import (
+	"fmt"
+	"testing"
+)
+
+// testCount checks Count over many ranges of a pseudo-random stream against a bit loop.
+func testCount[T Unsigned](t *testing.T, lp, rp int) {
+	writer := NewBitWriter[T](lp, rp)
+	x := uint64(0xA0761D6478BD642F)
+	for range 40 {
+		x = x*6364136223846793005 + 1442695040888963407
+		writer.WriteBits(x, 64)
+	}
+	// fill padding with ones so that masking is exercised
+	data := writer.Data()
+	for i := range data {
+		data[i] |= ^(^T(0) >> lp << lp >> rp << rp)
+	}
+	reader := NewBitReader(data, lp, rp)
+	reader.SetBits(writer.Bits())
+	prefix := make([]int, reader.Bits()+1)
+	for i := range reader.Bits() {
+		bit, _ := reader.ReadBitAt(i)
+		prefix[i+1] = prefix[i] + int(b2u(bit))
+	}
+	for from := 0; from < reader.Bits(); from += 37 {
+		for to := from; to <= reader.Bits(); to += 53 {
+			if got, want := reader.Count(from, to), prefix[to]-prefix[from]; got != want {
+				t.Fatalf("Count(%d, %d) = %d; want %d", from, to, got, want)
+			}
+		}
+	}
+	if got, want := reader.Slice(3, reader.Bits()).Count(0, reader.Bits()), prefix[reader.Bits()]-prefix[3]; got != want {
+		t.Errorf("Slice(3, ...).Count() = %d; want %d", got, want)
+	}
+}
+
+func TestPopcount(t *testing.T) {
+	for _, p := range []struct{ lp, rp int }{{0, 0}, {1, 0}, {0, 3}, {2, 1}} {
+		name := fmt.Sprintf("padding%d_%d", p.lp, p.rp)
+		t.Run("uint8/"+name, func(t *testing.T) { testCount[uint8](t, p.lp, p.rp) })
+		t.Run("uint16/"+name, func(t *testing.T) { testCount[uint16](t, p.lp, p.rp) })
+		t.Run("uint32/"+name, func(t *testing.T) { testCount[uint32](t, p.lp, p.rp) })
+		t.Run("uint64/"+name, func(t *testing.T) { testCount[uint64](t, p.lp, p.rp) })
+	}
+
+	t.Run("Unaligned", func(t *testing.T) {
+		buf := make([]uint8, 100)
+		for i := range buf {
+			buf[i] = uint8(i * 37)
+		}
+		want := 0
+		for _, b := range buf[5:] {
+			for ; b != 0; b &= b - 1 {
+				want++
+			}
+		}
+		if got := popcount(buf[5:]); got != want {
+			t.Errorf("popcount() = %d; want %d", got, want)
+		}
+	})
+}
@@ -0,0 +1,88 @@
+package bitstream
+
+import (
+	"io"
+	"math"
+)
+
+// The methods below are int64 variants of the position API, for code that indexes
+// huge streams with int64 offsets (file offsets, succinct structures) and must build on
+// 32-bit platforms too. Streams are still addressed internally with int bit positions,
+// so on 32-bit platforms a stream holds at most math.MaxInt bits (256 MiB of data);
+// positions beyond that are reported as ErrOverflow, or as io.EOF when reading.
+
+// Bits64 returns the total number of valid bits in the BitReader as an int64.
+func (r *BitReader[T]) Bits64() int64 {
+	return int64(r.bits)
+}
+
+// Pos64 returns the current read position (cursor) as an int64.
+func (r *BitReader[T]) Pos64() int64 {
+	return int64(r.pos)
+}
+
+// Seek64 sets the read position (cursor) like Seek, taking an int64 position.
+// Returns ErrNegativePosition for negative positions and ErrOverflow for positions
+// beyond the largest int; the cursor is not moved on error.
+func (r *BitReader[T]) Seek64(pos int64) error {
+	if pos > math.MaxInt {
+		return ErrOverflow
+	}
+	return r.Seek(int(pos))
+}
+
+// ReadBitsAt64 reads bits bits starting at pos without moving the cursor, like ReadBitsAt,
+// taking an int64 position. Positions beyond the largest int are past the valid bits and
+// return io.EOF.
+//
+// Panics if bits > 64.
+func (r *BitReader[T]) ReadBitsAt64(pos int64, bits int) (uint64, error) {
+	if pos > math.MaxInt {
+		if bits > 64 {
+			panic(&WidthError{Op: "read", Width: bits, Max: 64})
+		}
+		if bits <= 0 {
+			return 0, nil
+		}
+		return 0, io.EOF
+	}
+	return r.ReadBitsAt(int(pos), bits)
+}
+
+// Bits64 returns the total number of valid bits in the BitWriter as an int64.
+func (w *BitWriter[T]) Bits64() int64 {
+	w.lock()
+	defer w.unlock()
+	return int64(w.bits)
+}
+
+// Pos64 returns the current write position (cursor) as an int64.
+func (w *BitWriter[T]) Pos64() int64 {
+	w.lock()
+	defer w.unlock()
+	return int64(w.pos)
+}
+
+// Seek64 sets the write position (cursor) like Seek, taking an int64 position.
+// Returns ErrNegativePosition for negative positions and ErrOverflow for positions
+// beyond the largest int; the cursor is not moved on error.
+func (w *BitWriter[T]) Seek64(pos int64) error {
+	if pos > math.MaxInt {
+		return ErrOverflow
+	}
+	return w.Seek(int(pos))
+}
+
+// WriteBitsAt64 writes the low bits of data at pos without moving the cursor, like
+// WriteBitsAt, taking an int64 position. Errors are reported as for WriteBitsAt.
+//
+// Panics if bits > 64.
+func (w *BitWriter[T]) WriteBitsAt64(pos int64, bits int, data uint64) error {
+	if pos > math.MaxInt {
+		if bits > 64 {
+			panic(&WidthError{Op: "write", Width: bits, Max: 64})
+		}
+		return ErrOverflow
+	}
+	return w.WriteBitsAt(int(pos), bits, data)
+}
@@ -0,0 +1,56 @@
+package bitstream
+
+import (
+	"io"
+	"math"
+	"testing"
+)
+
+func TestPos64(t *testing.T) {
+	t.Run("Reader", func(t *testing.T) {
+		reader := NewBitReader([]uint16{0xABCD, 0x1234}, 0, 0)
+		if reader.Bits64() != 32 {
+			t.Errorf("Bits64() = %d; want 32", reader.Bits64())
+		}
+		if err := reader.Seek64(20); err != nil || reader.Pos64() != 20 || reader.Pos() != 20 {
+			t.Errorf("Seek64(20) = %v, Pos64() = %d; want nil, 20", err, reader.Pos64())
+		}
+		if got, err := reader.ReadBitsAt64(4, 12); got != 0xBCD || err != nil {
+			t.Errorf("ReadBitsAt64(4, 12) = %#x, %v; want 0xbcd, nil", got, err)
+		}
+		if _, err := reader.ReadBitsAt64(32, 1); err != io.EOF {
+			t.Errorf("ReadBitsAt64(32, 1) error = %v; want io.EOF", err)
+		}
+		if _, err := reader.ReadBitsAt64(math.MaxInt64, 1); err != io.EOF {
+			t.Errorf("ReadBitsAt64(MaxInt64, 1) error = %v; want io.EOF", err)
+		}
+		if err := reader.Seek64(-1); err != ErrNegativePosition || reader.Pos64() != 20 {
+			t.Errorf("Seek64(-1) = %v at %d; want ErrNegativePosition at 20", err, reader.Pos64())
+		}
+		if math.MaxInt < math.MaxInt64 {
+			if err := reader.Seek64(math.MaxInt64); err != ErrOverflow || reader.Pos64() != 20 {
+				t.Errorf("Seek64(MaxInt64) = %v at %d; want ErrOverflow at 20", err, reader.Pos64())
+			}
+		}
+	})
+
+	t.Run("Writer", func(t *testing.T) {
+		writer := NewBitWriter[uint8](0, 0)
+		writer.WriteBits(0xAB, 8)
+		if err := writer.WriteBitsAt64(12, 4, 0xF); err != nil || writer.Bits64() != 16 {
+			t.Errorf("WriteBitsAt64(12, 4) = %v, Bits64() = %d; want nil, 16", err, writer.Bits64())
+		}
+		if err := writer.Seek64(4); err != nil || writer.Pos64() != 4 {
+			t.Errorf("Seek64(4) = %v, Pos64() = %d; want nil, 4", err, writer.Pos64())
+		}
+		if got := writer.ToHex(); got != "ab0f" {
+			t.Errorf("ToHex() = %s; want ab0f", got)
+		}
+		if err := writer.WriteBitsAt64(-1, 4, 0); err != ErrNegativePosition {
+			t.Errorf("WriteBitsAt64(-1, 4) = %v; want ErrNegativePosition", err)
+		}
+		if err := writer.WriteBitsAt64(math.MaxInt64-3, 8, 0xFF); err != ErrOverflow || writer.Bits64() != 16 {
+			t.Errorf("WriteBitsAt64(MaxInt64-3, 8) = %v with %d bits; want ErrOverflow with 16", err, writer.Bits64())
+		}
+	})
+}
@@ -0,0 +1,256 @@
+package bitstream
+
+// MaxModelTotal is the largest total frequency a Model may report to RangeEncoder
+// and RangeDecoder, which keep 32 bits of precision.
+const MaxModelTotal = 1 << 16
+
+const (
+	rcTop     = 1<<32 - 1
+	rcHalf    = 1 << 31
+	rcQuarter = 1 << 30
+)
+
+// Model supplies symbol probabilities to RangeEncoder and RangeDecoder as cumulative
+// frequencies. Symbols are numbered from 0, and symbol s owns the frequencies [low, high)
+// returned by Range. The encoder and decoder call Update after every symbol, so an adaptive
+// model learns identically on both sides; a static model can ignore it.
+type Model interface {
+	// Range returns the cumulative frequency range of symbol, with low < high <= Total().
+	Range(symbol int) (low, high uint32)
+	// Total returns the sum of all frequencies, between 1 and MaxModelTotal.
+	Total() uint32
+	// Symbol returns the symbol whose range contains target, for target < Total().
+	Symbol(target uint32) int
+	// Update records that symbol was coded.
+	Update(symbol int)
+}
+
+// RangeEncoder is an arithmetic coder that narrows an interval by the probability of each
+// symbol and writes its leading bits to a BitWriter as soon as they are settled.
+// Different Models can be mixed from symbol to symbol, as in context-modelling compressors,
+// as long as the decoder uses the same sequence.
+//
+//	enc := bitstream.NewRangeEncoder(w)
+//	m := bitstream.NewFrequencyModel(256)
+//	for _, b := range data {
+//		enc.Encode(m, int(b))
+//	}
+//	enc.Flush()
+type RangeEncoder[T Unsigned] struct {
+	w         *BitWriter[T]
+	low, high uint64
+	pending   int // opposite bits owed after the next settled bit
+}
+
+// NewRangeEncoder returns a RangeEncoder appending to w.
+func NewRangeEncoder[T Unsigned](w *BitWriter[T]) *RangeEncoder[T] {
+	return &RangeEncoder[T]{w: w, high: rcTop}
+}
+
+// Encode codes symbol with the probabilities of m, then updates m.
+//
+// Panics if m reports a total above MaxModelTotal or an empty range for symbol.
+func (e *RangeEncoder[T]) Encode(m Model, symbol int) {
+	lo, hi := m.Range(symbol)
+	total := m.Total()
+	if total > MaxModelTotal || lo >= hi || hi > total {
+		panic("bitstream: invalid model frequencies")
+	}
+	e.narrow(uint64(lo), uint64(hi), uint64(total))
+	m.Update(symbol)
+}
+
+// narrow shrinks the interval to [lo, hi) out of total and writes the bits that become settled.
+func (e *RangeEncoder[T]) narrow(lo, hi, total uint64) {
+	rng := e.high - e.low + 1
+	e.high = e.low + rng*hi/total - 1
+	e.low += rng * lo / total
+	for {
+		switch {
+		case e.high < rcHalf:
+			e.emit(0)
+		case e.low >= rcHalf:
+			e.emit(1)
+			e.low -= rcHalf
+			e.high -= rcHalf
+		case e.low >= rcQuarter && e.high < 3*rcQuarter:
+			e.pending++
+			e.low -= rcQuarter
+			e.high -= rcQuarter
+		default:
+			return
+		}
+		e.low <<= 1
+		e.high = e.high<<1 | 1
+	}
+}
+
+// emit writes a settled bit followed by the pending opposite bits.
+func (e *RangeEncoder[T]) emit(bit uint64) {
+	e.w.WriteBits(bit, 1)
+	for ; e.pending > 0; e.pending -= min(e.pending, 64) {
+		n := min(e.pending, 64)
+		e.w.WriteBits((bit^1)*(^uint64(0)>>(64-n)), n)
+	}
+}
+
+// Flush writes the bits needed to identify the final interval, two plus any pending bits,
+// and resets the encoder so another independent block can follow.
+func (e *RangeEncoder[T]) Flush() {
+	e.pending++
+	if e.low < rcQuarter {
+		e.emit(0)
+	} else {
+		e.emit(1)
+	}
+	e.low, e.high, e.pending = 0, rcTop, 0
+}
+
+// RangeDecoder decodes symbols written by RangeEncoder.
+//
+// The decoder looks up to 32 bits ahead, reading bits past the end of the stream as 0,
+// so the cursor of the reader is left untouched until Finish. The stream does not record
+// how many symbols it holds; store the count separately or reserve an end-of-block symbol.
+type RangeDecoder[T Unsigned] struct {
+	r                *BitReader[T]
+	low, high, value uint64
+	start, pos       int
+	shifts           int // bits consumed beyond the first 32
+}
+
+// NewRangeDecoder returns a RangeDecoder for a block starting at the cursor of r.
+func NewRangeDecoder[T Unsigned](r *BitReader[T]) *RangeDecoder[T] {
+	d := &RangeDecoder[T]{r: r, high: rcTop, start: r.pos, pos: r.pos}
+	for range 32 {
+		d.value = d.value<<1 | d.next()
+	}
+	return d
+}
+
+func (d *RangeDecoder[T]) next() uint64 {
+	var b uint64
+	if d.pos < d.r.bits {
+		b = d.r.bitsAt(d.pos, 1)
+	}
+	d.pos++
+	return b
+}
+
+// Decode returns the next symbol using the probabilities of m, then updates m.
+//
+// Panics if m reports a total above MaxModelTotal or an empty range for the symbol.
+func (d *RangeDecoder[T]) Decode(m Model) int {
+	total := m.Total()
+	if total == 0 || total > MaxModelTotal {
+		panic("bitstream: invalid model frequencies")
+	}
+	symbol := m.Symbol(uint32(d.target(uint64(total))))
+	lo, hi := m.Range(symbol)
+	if lo >= hi || hi > total {
+		panic("bitstream: invalid model frequencies")
+	}
+	d.narrow(uint64(lo), uint64(hi), uint64(total))
+	m.Update(symbol)
+	return symbol
+}
+
+// target returns where the value falls within the current interval, scaled to total.
+func (d *RangeDecoder[T]) target(total uint64) uint64 {
+	return ((d.value-d.low+1)*total - 1) / (d.high - d.low + 1)
+}
+
+// narrow shrinks the interval to [lo, hi) out of total, mirroring RangeEncoder.narrow.
+func (d *RangeDecoder[T]) narrow(lo, hi, total uint64) {
+	rng := d.high - d.low + 1
+	d.high = d.low + rng*hi/total - 1
+	d.low += rng * lo / total
+	for {
+		switch {
+		case d.high < rcHalf:
+		case d.low >= rcHalf:
+			d.low -= rcHalf
+			d.high -= rcHalf
+			d.value -= rcHalf
+		case d.low >= rcQuarter && d.high < 3*rcQuarter:
+			d.low -= rcQuarter
+			d.high -= rcQuarter
+			d.value -= rcQuarter
+		default:
+			return
+		}
+		d.low <<= 1
+		d.high = d.high<<1 | 1
+		d.value = d.value<<1 | d.next()
+		d.shifts++
+	}
+}
+
+// Finish moves the cursor of the reader to just after the block, which ends where the
+// encoder's Flush ended it, and resets the decoder to decode another block from there.
+// The cursor is capped to the valid bits if the block was truncated.
+func (d *RangeDecoder[T]) Finish() {
+	d.r.pos = min(d.start+d.shifts+2, d.r.bits)
+	*d = *NewRangeDecoder(d.r)
+}
+
+// FrequencyModel is an adaptive Model over a fixed alphabet that starts with every symbol
+// equally likely and increases a symbol's frequency each time it is coded, halving all
+// frequencies when the total would exceed MaxModelTotal so recent data weighs more.
+type FrequencyModel struct {
+	freq  []uint32
+	total uint32
+}
+
+// frequencyStep is the amount added to a symbol's frequency when it is coded.
+const frequencyStep = 24
+
+// NewFrequencyModel returns a FrequencyModel over symbols 0 to n-1.
+//
+// Panics if n < 1 or n > MaxModelTotal/2.
+func NewFrequencyModel(n int) *FrequencyModel {
+	if n < 1 || n > MaxModelTotal/2 {
+		panic("bitstream: invalid alphabet size")
+	}
+	m := &FrequencyModel{freq: make([]uint32, n), total: uint32(n)}
+	for i := range m.freq {
+		m.freq[i] = 1
+	}
+	return m
+}
+
+// Range implements Model.
+func (m *FrequencyModel) Range(symbol int) (low, high uint32) {
+	for _, f := range m.freq[:symbol] {
+		low += f
+	}
+	return low, low + m.freq[symbol]
+}
+
+// Total implements Model.
+func (m *FrequencyModel) Total() uint32 {
+	return m.total
+}
+
+// Symbol implements Model.
+func (m *FrequencyModel) Symbol(target uint32) int {
+	for s, f := range m.freq {
+		if target < f {
+			return s
+		}
+		target -= f
+	}
+	return len(m.freq) - 1
+}
+
+// Update implements Model.
+func (m *FrequencyModel) Update(symbol int) {
+	m.freq[symbol] += frequencyStep
+	m.total += frequencyStep
+	if m.total > MaxModelTotal {
+		m.total = 0
+		for i, f := range m.freq {
+			m.freq[i] = (f + 1) / 2
+			m.total += m.freq[i]
+		}
+	}
+}
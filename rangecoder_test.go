@@ -0,0 +1,98 @@
+package bitstream
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+func TestRangeCoder(t *testing.T) {
+	t.Run("RoundTrip", func(t *testing.T) {
+		rnd := rand.New(rand.NewPCG(1, 2))
+		data := make([]int, 5000)
+		for i := range data {
+			// skewed towards small symbols
+			data[i] = min(int(rnd.ExpFloat64()*4), 255)
+		}
+		writer := NewBitWriter[uint8](0, 0)
+		writer.WriteBits(0x5, 3)
+		enc := NewRangeEncoder(writer)
+		m := NewFrequencyModel(256)
+		for _, s := range data {
+			enc.Encode(m, s)
+		}
+		enc.Flush()
+		end := writer.Bits()
+		writer.WriteBits(0xABC, 12)
+		if end-3 >= len(data)*8/2 {
+			t.Errorf("encoded %d symbols in %d bits; want fewer than %d", len(data), end-3, len(data)*8/2)
+		}
+
+		reader := NewBitReader(writer.Data(), 0, 0)
+		reader.SetBits(writer.Bits())
+		reader.Skip(3)
+		dec := NewRangeDecoder(reader)
+		m = NewFrequencyModel(256)
+		for i, want := range data {
+			if got := dec.Decode(m); got != want {
+				t.Fatalf("Decode() #%d = %d; want %d", i, got, want)
+			}
+		}
+		dec.Finish()
+		if reader.Pos() != end {
+			t.Errorf("Pos() after Finish = %d; want %d", reader.Pos(), end)
+		}
+		if v, _ := reader.ReadBits(12); v != 0xABC {
+			t.Errorf("ReadBits(12) after block = %#x; want 0xabc", v)
+		}
+	})
+
+	t.Run("MixedModels", func(t *testing.T) {
+		writer := NewBitWriter[uint64](0, 0)
+		enc := NewRangeEncoder(writer)
+		a, b := NewFrequencyModel(2), NewFrequencyModel(1000)
+		for i := range 300 {
+			enc.Encode(a, i%2)
+			enc.Encode(b, i*7%1000)
+		}
+		enc.Flush()
+		enc.Encode(a, 1)
+		enc.Flush()
+
+		reader := NewBitReader(writer.Data(), 0, 0)
+		reader.SetBits(writer.Bits())
+		dec := NewRangeDecoder(reader)
+		a, b = NewFrequencyModel(2), NewFrequencyModel(1000)
+		for i := range 300 {
+			if got := dec.Decode(a); got != i%2 {
+				t.Fatalf("Decode(a) #%d = %d; want %d", i, got, i%2)
+			}
+			if got := dec.Decode(b); got != i*7%1000 {
+				t.Fatalf("Decode(b) #%d = %d; want %d", i, got, i*7%1000)
+			}
+		}
+		dec.Finish()
+		if got := dec.Decode(a); got != 1 {
+			t.Errorf("Decode() of second block = %d; want 1", got)
+		}
+		dec.Finish()
+		if reader.Pos() != writer.Bits() {
+			t.Errorf("Pos() = %d; want %d", reader.Pos(), writer.Bits())
+		}
+	})
+
+	t.Run("Rescale", func(t *testing.T) {
+		m := NewFrequencyModel(3)
+		for range 10000 {
+			m.Update(1)
+		}
+		if m.Total() > MaxModelTotal {
+			t.Errorf("Total() = %d; want at most %d", m.Total(), MaxModelTotal)
+		}
+		if lo, hi := m.Range(0); lo != 0 || hi != 1 {
+			t.Errorf("Range(0) = %d, %d; want 0, 1", lo, hi)
+		}
+		if got := m.Symbol(m.Total() - 1); got != 2 {
+			t.Errorf("Symbol(Total()-1) = %d; want 2", got)
+		}
+	})
+}
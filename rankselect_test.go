@@ -0,0 +1,86 @@
+package bitstream
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+func TestRankSelect(t *testing.T) {
+	t.Run("Small", func(t *testing.T) {
+		r := NewBitReader([]uint16{0b1011_0000_0000_0001, 0b1100_0000_0000_0000}, 1, 0)
+		r.SetBits(17)
+		rs := NewRankSelect(r)
+		// valid bits: 011 0000 0000 0000 1 | 10
+		if rs.Bits() != 17 || rs.Ones() != 4 {
+			t.Errorf("Bits(), Ones() = %d, %d; want 17, 4", rs.Bits(), rs.Ones())
+		}
+		ranks := map[int]int{0: 0, 1: 0, 2: 1, 3: 2, 14: 2, 15: 3, 16: 4, 17: 4}
+		for pos, want := range ranks {
+			if got := rs.Rank1(pos); got != want {
+				t.Errorf("Rank1(%d) = %d; want %d", pos, got, want)
+			}
+			if got := rs.Rank0(pos); got != pos-want {
+				t.Errorf("Rank0(%d) = %d; want %d", pos, got, pos-want)
+			}
+		}
+		for k, want := range []int{1, 2, 14, 15, -1} {
+			if got := rs.Select1(k); got != want {
+				t.Errorf("Select1(%d) = %d; want %d", k, got, want)
+			}
+		}
+		for k, want := range map[int]int{0: 0, 1: 3, 11: 13, 12: 16, 13: -1, -1: -1} {
+			if got := rs.Select0(k); got != want {
+				t.Errorf("Select0(%d) = %d; want %d", k, got, want)
+			}
+		}
+		if !rs.Bit(15) || rs.Bit(16) {
+			t.Errorf("Bit(15), Bit(16) = %v, %v; want true, false", rs.Bit(15), rs.Bit(16))
+		}
+	})
+
+	t.Run("Random", func(t *testing.T) {
+		rnd := rand.New(rand.NewPCG(5, 6))
+		for _, n := range []int{0, 1, 63, 64, 512, 513, 1024, 5000} {
+			w := NewBitWriter[uint32](0, 3)
+			bitsSet := make([]bool, n)
+			for i := range bitsSet {
+				bitsSet[i] = rnd.IntN(3) == 0
+				w.WriteBool(bitsSet[i])
+			}
+			r := NewBitReader(w.Data(), 0, 3)
+			r.SetBits(n)
+			rs := NewRankSelect(r)
+			ones, zeros := 0, 0
+			for pos, b := range bitsSet {
+				if got := rs.Rank1(pos); got != ones {
+					t.Fatalf("n=%d: Rank1(%d) = %d; want %d", n, pos, got, ones)
+				}
+				if b {
+					if got := rs.Select1(ones); got != pos {
+						t.Fatalf("n=%d: Select1(%d) = %d; want %d", n, ones, got, pos)
+					}
+					ones++
+				} else {
+					if got := rs.Select0(zeros); got != pos {
+						t.Fatalf("n=%d: Select0(%d) = %d; want %d", n, zeros, got, pos)
+					}
+					zeros++
+				}
+			}
+			if rs.Rank1(n) != ones || rs.Select1(ones) != -1 || rs.Select0(zeros) != -1 {
+				t.Errorf("n=%d: Rank1(n), Select1(%d), Select0(%d) = %d, %d, %d; want %d, -1, -1",
+					n, ones, zeros, rs.Rank1(n), rs.Select1(ones), rs.Select0(zeros), ones)
+			}
+		}
+	})
+
+	t.Run("OutOfRange", func(t *testing.T) {
+		rs := NewRankSelect(NewBitReader([]uint8{0xFF}, 0, 0))
+		defer func() {
+			if recover() == nil {
+				t.Error("Rank1(9) did not panic")
+			}
+		}()
+		rs.Rank1(9)
+	})
+}
@@ -0,0 +1,85 @@
+package bitstream
+
+import "math/bits"
+
+// H.264 and H.265 NAL units protect start codes by inserting an emulation prevention byte
+// 0x03 after every two zero bytes that would otherwise be followed by 0x00 to 0x03, turning
+// the raw byte sequence payload (RBSP) into the encapsulated form (EBSP).
+
+// NewBitReaderRBSP creates a reader over the RBSP of a NAL unit payload, dropping each
+// emulation prevention byte so syntax elements can be read directly with ReadBits and ReadUE.
+// data should not include the start code; the NAL header can be read as ordinary bits.
+func NewBitReaderRBSP(data []byte) *BitReader[uint8] {
+	rbsp := make([]uint8, 0, len(data))
+	zeros := 0
+	for _, b := range data {
+		if zeros >= 2 && b == 0x03 {
+			zeros = 0
+			continue
+		}
+		if b == 0 {
+			zeros++
+		} else {
+			zeros = 0
+		}
+		rbsp = append(rbsp, b)
+	}
+	return NewBitReader(rbsp, 0, 0)
+}
+
+// BytesEBSP returns the written bits packed into bytes with emulation prevention bytes
+// inserted, ready to follow a start code. A final partial byte is padded with zero bits;
+// write the RBSP trailing bits with WriteRBSPTrailingBits first to end the payload properly.
+func (w *BitWriter[T]) BytesEBSP() []byte {
+	w.lock()
+	defer w.unlock()
+	r := w.reader()
+	p := make([]byte, 0, (r.bits+7)/8+r.bits/128)
+	zeros := 0
+	for pos := 0; pos < r.bits; pos += 8 {
+		k := min(8, r.bits-pos)
+		b := byte(r.bitsAt(pos, k) << (8 - k))
+		if zeros >= 2 && b <= 0x03 {
+			p = append(p, 0x03)
+			zeros = 0
+		}
+		if b == 0 {
+			zeros++
+		} else {
+			zeros = 0
+		}
+		p = append(p, b)
+	}
+	if zeros >= 2 {
+		// A payload may not end in 0x0000, as with cabac_zero_words.
+		p = append(p, 0x03)
+	}
+	return p
+}
+
+// WriteRBSPTrailingBits appends rbsp_trailing_bits: a 1 bit, then 0 bits up to the next byte
+// boundary.
+func (w *BitWriter[T]) WriteRBSPTrailingBits() {
+	w.lock()
+	defer w.unlock()
+	if !w.reserveBits(1 + padLen(w.bits+1, 8)) {
+		return
+	}
+	w.writeBits(1, 1)
+	w.alignTo(8)
+}
+
+// MoreRBSPData reports whether syntax elements remain before the rbsp_trailing_bits,
+// the more_rbsp_data() function of H.264 and H.265: that is, whether there is a 1 bit
+// after the cursor other than the last 1 bit of the stream.
+func (r *BitReader[T]) MoreRBSPData() bool {
+	for end := r.bits; end > r.pos; {
+		k := min(64, end-r.pos)
+		if v := r.bitsAt(end-k, k); v != 0 {
+			last := end - 1 - bits.TrailingZeros64(v)
+			return last > r.pos
+		}
+		end -= k
+	}
+	return false
+}
@@ -0,0 +1,61 @@
+package bitstream
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestRBSP(t *testing.T) {
+	t.Run("Reader", func(t *testing.T) {
+		reader := NewBitReaderRBSP([]byte{0x67, 0x00, 0x00, 0x03, 0x01, 0x00, 0x00, 0x03, 0x03, 0x80})
+		if reader.Bits() != 64 {
+			t.Errorf("Bits() = %d; want 64", reader.Bits())
+		}
+		if v, _ := reader.ReadBits(64); v != 0x67000001_00000380 {
+			t.Errorf("ReadBits(64) = %#x; want 0x6700000100000380", v)
+		}
+	})
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		writer := NewBitWriter[uint32](0, 0)
+		writer.WriteBits(0x65, 8)
+		writer.WriteBits(0, 16)
+		writer.WriteBits(0x02, 8)
+		writer.WriteUE(0)
+		writer.WriteUE(7)
+		writer.WriteBits(0, 24)
+		writer.WriteRBSPTrailingBits()
+		ebsp := writer.BytesEBSP()
+		want := []byte{0x65, 0x00, 0x00, 0x03, 0x02, 0x88, 0x00, 0x00, 0x03, 0x00, 0x80}
+		if !bytes.Equal(ebsp, want) {
+			t.Errorf("BytesEBSP() = %x; want %x", ebsp, want)
+		}
+
+		reader := NewBitReaderRBSP(ebsp)
+		reader.Skip(32)
+		if v, _ := reader.ReadUE(); v != 0 {
+			t.Errorf("ReadUE() = %d; want 0", v)
+		}
+		if !reader.MoreRBSPData() {
+			t.Errorf("MoreRBSPData() = false; want true")
+		}
+		if v, _ := reader.ReadUE(); v != 7 {
+			t.Errorf("ReadUE() = %d; want 7", v)
+		}
+		reader.Skip(24)
+		if reader.MoreRBSPData() {
+			t.Errorf("MoreRBSPData() at trailing bits = true; want false")
+		}
+	})
+
+	t.Run("TrailingZeros", func(t *testing.T) {
+		writer := NewBitWriter[uint8](0, 0)
+		writer.WriteBits(0x80_0000, 24)
+		if got := writer.BytesEBSP(); !bytes.Equal(got, []byte{0x80, 0x00, 0x00, 0x03}) {
+			t.Errorf("BytesEBSP() = %x; want 80000003", got)
+		}
+		if NewBitReader([]uint8{0}, 0, 0).MoreRBSPData() {
+			t.Errorf("MoreRBSPData() without trailing bits = true; want false")
+		}
+	})
+}
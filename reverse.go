@@ -0,0 +1,77 @@
+package bitstream
+
+import "math/bits"
+
+// ReadBitsLE reads bits bits at the current position like ReadBits, but treats the first bit
+// read as the least significant one, for fields stored LSB-first inside an MSB-first stream.
+// Errors are reported as for ReadBits.
+//
+// Panics if bits > 64.
+func (r *BitReader[T]) ReadBitsLE(bits int) (uint64, error) {
+	v, err := r.ReadBits(bits)
+	if err != nil || bits <= 0 {
+		return v, err
+	}
+	return reverseBits(v, bits), nil
+}
+
+// Reversed returns a reader over a copy of the valid bits of r in reverse order,
+// with the same element type and padding. Its cursor starts at 0.
+func (r *BitReader[T]) Reversed() *BitReader[T] {
+	w := NewUnsyncBitWriter[T](r.lp, r.rp)
+	w.grow(r.bits)
+	for end := r.bits; end > 0; {
+		k := min(64, end)
+		w.writeBits(reverseBits(r.bitsAt(end-k, k), k), k)
+		end -= k
+	}
+	c := NewBitReader(w.data, r.lp, r.rp)
+	c.bits = r.bits
+	return c
+}
+
+// WriteBitsLE appends the low bits bits of data least significant bit first,
+// the inverse of ReadBitsLE.
+//
+// Panics if bits > 64.
+func (w *BitWriter[T]) WriteBitsLE(data uint64, bits int) {
+	if bits > 64 {
+		panic(&WidthError{Op: "write", Width: bits, Max: 64})
+	}
+	if bits <= 0 {
+		return
+	}
+	w.lock()
+	defer w.unlock()
+	w.writeBits(reverseBits(data, bits), bits)
+}
+
+// ReverseBits reverses the order of the written bits in [from, to) in place.
+// The cursor and Bits() are not changed.
+//
+// Panics if the range is not within the written bits.
+func (w *BitWriter[T]) ReverseBits(from, to int) {
+	w.lock()
+	defer w.unlock()
+	if from < 0 || from > to || to > w.bits {
+		panic("bitstream: slice bounds out of range")
+	}
+	r := w.reader()
+	// Swap chunks from both ends of the range, reversing each, until they meet.
+	for lo, hi := from, to; hi-lo > 1; {
+		k := min(64, (hi-lo)/2)
+		a, b := r.bitsAt(lo, k), r.bitsAt(hi-k, k)
+		a, b = reverseBits(b, k), reverseBits(a, k)
+		w.traceWrite(lo, k, a)
+		w.writeBitsAt(lo, k, a)
+		w.traceWrite(hi-k, k, b)
+		w.writeBitsAt(hi-k, k, b)
+		lo += k
+		hi -= k
+	}
+}
+
+// reverseBits reverses the order of the low n bits of v, for 1 <= n <= 64.
+func reverseBits(v uint64, n int) uint64 {
+	return bits.Reverse64(v) >> (64 - n)
+}
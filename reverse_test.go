@@ -0,0 +1,90 @@
+package bitstream
+
+import (
+	"io"
+	"testing"
+)
+
+func TestReverse(t *testing.T) {
+	t.Run("ReadBitsLE", func(t *testing.T) {
+		reader := NewBitReader([]uint8{0b0_1110100, 0b0_1110000}, 1, 0)
+		reader.Skip(1)
+		if v, err := reader.ReadBitsLE(3); v != 0b011 || err != nil {
+			t.Errorf("ReadBitsLE(3) = %03b, %v; want 011, nil", v, err)
+		}
+		if v, err := reader.ReadBitsLE(7); v != 0b0111001 || err != nil {
+			t.Errorf("ReadBitsLE(7) = %07b, %v; want 0111001, nil", v, err)
+		}
+		if v, err := reader.ReadBitsLE(4); v != 0 || err != io.ErrUnexpectedEOF || reader.Pos() != 11 {
+			t.Errorf("ReadBitsLE(4) = %b, %v at %d; want 0, io.ErrUnexpectedEOF at 11", v, err, reader.Pos())
+		}
+	})
+
+	t.Run("WriteBitsLE", func(t *testing.T) {
+		writer := NewBitWriter[uint16](0, 0)
+		writer.WriteBitsLE(0b110, 3)
+		writer.WriteBitsLE(0xDEADBEEFCAFEF00D, 64)
+		reader := NewBitReader(writer.Data(), 0, 0)
+		if v, _ := reader.ReadBits(3); v != 0b011 {
+			t.Errorf("ReadBits(3) = %03b; want 011", v)
+		}
+		if v, _ := reader.ReadBitsLE(64); v != 0xDEADBEEFCAFEF00D {
+			t.Errorf("ReadBitsLE(64) = %#x; want 0xdeadbeefcafef00d", v)
+		}
+	})
+
+	t.Run("Reversed", func(t *testing.T) {
+		writer := NewBitWriter[uint32](3, 0)
+		for i := range 20 {
+			writer.WriteBits(uint64(i), 7)
+		}
+		reader := NewBitReader(writer.Data(), 3, 0)
+		reader.SetBits(writer.Bits())
+		rev := reader.Reversed()
+		if rev.Bits() != 140 {
+			t.Fatalf("Bits() = %d; want 140", rev.Bits())
+		}
+		for i := range 20 {
+			if v, _ := rev.ReadBitsLE(7); v != uint64(19-i) {
+				t.Errorf("ReadBitsLE(7) = %d; want %d", v, 19-i)
+			}
+		}
+	})
+
+	t.Run("ReverseBits", func(t *testing.T) {
+		for _, n := range []int{0, 1, 2, 7, 64, 129, 200} {
+			writer := NewBitWriter[uint8](1, 1)
+			writer.WriteBits(0b101, 3)
+			for i := range n {
+				writer.WriteBool(i%3 == 0)
+			}
+			writer.WriteBits(0b011, 3)
+			writer.ReverseBits(3, 3+n)
+
+			reader := NewBitReader(writer.Data(), 1, 1)
+			reader.SetBits(writer.Bits())
+			if v, _ := reader.ReadBits(3); v != 0b101 {
+				t.Errorf("n=%d: prefix = %03b; want 101", n, v)
+			}
+			for i := n - 1; i >= 0; i-- {
+				if bit, _ := reader.ReadBit(); bit != (i%3 == 0) {
+					t.Errorf("n=%d: bit %d = %v; want %v", n, n-1-i, bit, i%3 == 0)
+				}
+			}
+			if v, _ := reader.ReadBits(3); v != 0b011 {
+				t.Errorf("n=%d: suffix = %03b; want 011", n, v)
+			}
+		}
+	})
+
+	t.Run("ReverseBits_panic", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("expected panic for range past the end")
+			}
+		}()
+		writer := NewBitWriter[uint8](0, 0)
+		writer.WriteBits(0, 4)
+		writer.ReverseBits(0, 5)
+	})
+}
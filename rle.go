@@ -0,0 +1,70 @@
+package bitstream
+
+// RunLengthEncode reads the remaining bits of src and appends them to dst as a run-length code.
+// The code is the value of the first bit, followed by the length of each run as a countWidth-bit
+// field; runs alternate in value, so only their lengths are stored. A run longer than
+// 2^countWidth-1 bits is split by a zero-length run of the other value.
+// The cursor of src is advanced to the end of its valid bits. Nothing is written for an empty source.
+//
+// Panics if countWidth < 1 or countWidth > 64.
+func RunLengthEncode[T, U Unsigned](src *BitReader[T], dst *BitWriter[U], countWidth int) {
+	if countWidth < 1 || countWidth > 64 {
+		panic("bitstream: run-length count width must be between 1 and 64")
+	}
+	limit := ^uint64(0) >> (64 - countWidth)
+	dst.lock()
+	defer dst.unlock()
+	first := true
+	for bit, n := range src.Runs() {
+		if first {
+			var b uint64
+			if bit {
+				b = 1
+			}
+			dst.writeBits(b, 1)
+			first = false
+		}
+		count := uint64(n)
+		for count > limit {
+			dst.writeBits(limit, countWidth)
+			dst.writeBits(0, countWidth)
+			count -= limit
+		}
+		dst.writeBits(count, countWidth)
+	}
+}
+
+// RunLengthDecode reads a run-length code written by RunLengthEncode from src and appends
+// the decoded bits to dst. Decoding stops when fewer than countWidth bits remain in src,
+// so zero padding after the code (e.g. from AlignToByte) is ignored.
+// The cursor of src is advanced past the consumed bits.
+//
+// Panics if countWidth < 1 or countWidth > 64.
+func RunLengthDecode[T, U Unsigned](src *BitReader[T], dst *BitWriter[U], countWidth int) {
+	if countWidth < 1 || countWidth > 64 {
+		panic("bitstream: run-length count width must be between 1 and 64")
+	}
+	bit, err := src.ReadBit()
+	if err != nil {
+		return
+	}
+	dst.lock()
+	defer dst.unlock()
+	for src.bits-src.pos >= countWidth {
+		n, _ := src.ReadBits(countWidth)
+		dst.writeRun(bit, n)
+		bit = !bit
+	}
+}
+
+// writeRun appends n copies of bit.
+func (w *BitWriter[T]) writeRun(bit bool, n uint64) {
+	var fill uint64
+	if bit {
+		fill = ^uint64(0)
+	}
+	for ; n > 64; n -= 64 {
+		w.writeBits(fill, 64)
+	}
+	w.writeBits(fill, int(n))
+}
@@ -0,0 +1,76 @@
+package bitstream
+
+import "testing"
+
+func TestRunLength(t *testing.T) {
+	t.Run("Encode", func(t *testing.T) {
+		// 1110 0000 0001 -> first bit 1, runs 3, 8, 1
+		src := NewBitReader([]uint16{0b1110000000010000}, 0, 4)
+		dst := NewBitWriter[uint8](0, 0)
+		RunLengthEncode(src, dst, 4)
+		if dst.Bits() != 13 {
+			t.Fatalf("Bits() = %d; want 13", dst.Bits())
+		}
+		got := NewBitReader(dst.Data(), 0, 0)
+		if v, _ := got.ReadBits(13); v != 0b1_0011_1000_0001 {
+			t.Errorf("encoded = %013b; want %013b", v, 0b1_0011_1000_0001)
+		}
+		if src.Pos() != 12 {
+			t.Errorf("src.Pos() = %d; want 12", src.Pos())
+		}
+	})
+
+	t.Run("Encode_split", func(t *testing.T) {
+		// a run of 7 zeros does not fit in 2 bits: 3, 0, 3, 0, 1
+		src := NewBitReader([]uint8{0b00000001}, 0, 0)
+		dst := NewBitWriter[uint8](0, 0)
+		RunLengthEncode(src, dst, 2)
+		got := NewBitReader(dst.Data(), 0, 0)
+		if v, _ := got.ReadBits(dst.Bits()); dst.Bits() != 13 || v != 0b0_11_00_11_00_01_01 {
+			t.Errorf("encoded = %0*b; want %013b", dst.Bits(), v, 0b0_11_00_11_00_01_01)
+		}
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		dst := NewBitWriter[uint8](0, 0)
+		RunLengthEncode(NewBitReader([]uint8{}, 0, 0), dst, 8)
+		if dst.Bits() != 0 {
+			t.Errorf("Bits() = %d; want 0", dst.Bits())
+		}
+		out := NewBitWriter[uint8](0, 0)
+		RunLengthDecode(NewBitReader(dst.Data(), 0, 0), out, 8)
+		if out.Bits() != 0 {
+			t.Errorf("decoded Bits() = %d; want 0", out.Bits())
+		}
+	})
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		data := []uint32{0xFFFFFFFF, 0xFFFFFFFF, 0x0000F00F, 0x00000000, 0x80000001, 0xAAAAAAAA}
+		for _, width := range []int{1, 3, 8, 64} {
+			src := NewBitReader(data, 2, 3)
+			enc := NewBitWriter[uint8](0, 0)
+			RunLengthEncode(src, enc, width)
+			enc.AlignToByte()
+
+			dec := NewBitWriter[uint32](2, 3)
+			RunLengthDecode(NewBitReader(enc.Data(), 0, 0), dec, width)
+			if dec.Bits() != src.Bits() {
+				t.Fatalf("width %d: decoded Bits() = %d; want %d", width, dec.Bits(), src.Bits())
+			}
+			for i, v := range dec.Data() {
+				if want := data[i] >> 3 << 5 >> 2; v != want {
+					t.Errorf("width %d: data[%d] = %032b; want %032b", width, i, v, want)
+				}
+			}
+		}
+	})
+
+	t.Run("Panic", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("expected panic for count width 0")
+			}
+		}()
+		RunLengthEncode(NewBitReader([]uint8{0}, 0, 0), NewBitWriter[uint8](0, 0), 0)
+	})
+}
@@ -0,0 +1,193 @@
+package bitstream
+
+import (
+	"iter"
+	"math/bits"
+)
+
+// BitmapWords returns the valid bits of r, regardless of its cursor, as bitmap words in
+// the layout used by roaring bitmaps, java.util.BitSet and most Go bitset packages:
+// bit i is bit i%64 (counted from the least significant) of word i/64.
+// Bits past the end of the stream in the last word are zero.
+func (r *BitReader[T]) BitmapWords() []uint64 {
+	return r.bitmapWords(0, r.bits)
+}
+
+// bitmapWords returns the bits in [from, to) as LSB-first bitmap words.
+func (r *BitReader[T]) bitmapWords(from, to int) []uint64 {
+	words := make([]uint64, (to-from+63)/64)
+	for i := range words {
+		pos := from + i*64
+		k := min(64, to-pos)
+		words[i] = bits.Reverse64(r.bitsAt(pos, k) << (64 - k))
+	}
+	return words
+}
+
+// NewBitReaderBitmap creates a reader over the first n bits of bitmap words in the layout
+// returned by BitmapWords. The words are converted into a new buffer, so words can be reused.
+// n is capped to 64*len(words).
+func NewBitReaderBitmap(words []uint64, n int) *BitReader[uint64] {
+	data := make([]uint64, len(words))
+	for i, w := range words {
+		data[i] = bits.Reverse64(w)
+	}
+	r := NewBitReader(data, 0, 0)
+	r.bits = max(min(n, r.bits), 0)
+	return r
+}
+
+// BitmapStats describes how the set bits of a stream are distributed, to help choose
+// between sparse and dense representations.
+type BitmapStats struct {
+	Bits int // Number of valid bits
+	Ones int // Number of set bits (the cardinality)
+	Runs int // Number of maximal runs of set bits
+}
+
+// Density returns the fraction of valid bits that are set, or 0 for an empty stream.
+func (s BitmapStats) Density() float64 {
+	if s.Bits == 0 {
+		return 0
+	}
+	return float64(s.Ones) / float64(s.Bits)
+}
+
+// BitmapStats counts the set bits and runs of set bits in the valid bits of r,
+// regardless of its cursor.
+func (r *BitReader[T]) BitmapStats() BitmapStats {
+	s := BitmapStats{Bits: r.bits}
+	var prev uint64 // the last bit of the previous word
+	for _, w := range r.BitmapWords() {
+		s.Ones += bits.OnesCount64(w)
+		s.Runs += bits.OnesCount64(w &^ (w<<1 | prev))
+		prev = w >> 63
+	}
+	return s
+}
+
+// ContainerKind is the representation roaring bitmaps use for one 65536-bit chunk.
+type ContainerKind int
+
+const (
+	// ArrayContainer lists the positions of the set bits.
+	ArrayContainer ContainerKind = iota
+	// BitmapContainer stores all 65536 bits as 1024 words.
+	BitmapContainer
+	// RunContainer lists the runs of set bits.
+	RunContainer
+)
+
+// String returns the name used by roaring for the container kind.
+func (k ContainerKind) String() string {
+	switch k {
+	case ArrayContainer:
+		return "array"
+	case BitmapContainer:
+		return "bitmap"
+	case RunContainer:
+		return "run"
+	}
+	return "unknown"
+}
+
+// Interval16 is a run of set bits within a container, stored as in the roaring format:
+// the run covers Start to Start+Length inclusive.
+type Interval16 struct {
+	Start  uint16
+	Length uint16
+}
+
+// Container is one 65536-bit chunk of a stream in roaring form. Only the field matching
+// Kind is filled in.
+type Container struct {
+	Key         int // Chunk index; the chunk covers positions [Key<<16, (Key+1)<<16)
+	Kind        ContainerKind
+	Cardinality int
+	Array       []uint16     // Sorted low 16 bits of each set position, for ArrayContainer
+	Bitmap      []uint64     // 1024 bitmap words as returned by BitmapWords, for BitmapContainer
+	Runs        []Interval16 // Runs of set bits in order, for RunContainer
+}
+
+// Containers returns an iterator over the valid bits of r, regardless of its cursor, as
+// roaring containers of 65536 bits. Chunks without set bits are skipped, and each chunk
+// uses the kind roaring would serialize it as: a run container if that is smallest,
+// otherwise an array container for at most 4096 set bits and a bitmap container above.
+// The containers can be added to a roaring bitmap with its AddRange, AddMany or
+// FromBitSet style constructors without going through individual bits.
+func (r *BitReader[T]) Containers() iter.Seq[Container] {
+	return func(yield func(Container) bool) {
+		for key := 0; key<<16 < r.bits; key++ {
+			from := key << 16
+			words := r.bitmapWords(from, min(from+1<<16, r.bits))
+			c := Container{Key: key}
+			runs := 0
+			var prev uint64
+			for _, w := range words {
+				c.Cardinality += bits.OnesCount64(w)
+				runs += bits.OnesCount64(w &^ (w<<1 | prev))
+				prev = w >> 63
+			}
+			if c.Cardinality == 0 {
+				continue
+			}
+			switch size := 2 * c.Cardinality; {
+			case 2+4*runs < min(size, 8192):
+				c.Kind = RunContainer
+				c.Runs = containerRuns(words, runs)
+			case c.Cardinality <= 4096:
+				c.Kind = ArrayContainer
+				c.Array = containerArray(words, c.Cardinality)
+			default:
+				c.Kind = BitmapContainer
+				c.Bitmap = make([]uint64, 1024)
+				copy(c.Bitmap, words)
+			}
+			if !yield(c) {
+				return
+			}
+		}
+	}
+}
+
+// containerArray lists the set bits of LSB-first words.
+func containerArray(words []uint64, n int) []uint16 {
+	a := make([]uint16, 0, n)
+	for i, w := range words {
+		for w != 0 {
+			a = append(a, uint16(i*64+bits.TrailingZeros64(w)))
+			w &= w - 1
+		}
+	}
+	return a
+}
+
+// containerRuns lists the runs of set bits of LSB-first words.
+func containerRuns(words []uint64, n int) []Interval16 {
+	runs := make([]Interval16, 0, n)
+	start := -1
+	for i, w := range words {
+		for b := 0; b < 64; {
+			if start < 0 {
+				z := bits.TrailingZeros64(w >> b)
+				if b+z >= 64 {
+					break
+				}
+				b += z
+				start = i*64 + b
+			} else {
+				o := bits.TrailingZeros64(^w >> b)
+				if b+o >= 64 {
+					break
+				}
+				b += o
+				runs = append(runs, Interval16{Start: uint16(start), Length: uint16(i*64 + b - 1 - start)})
+				start = -1
+			}
+		}
+	}
+	if start >= 0 {
+		runs = append(runs, Interval16{Start: uint16(start), Length: uint16(len(words)*64 - 1 - start)})
+	}
+	return runs
+}
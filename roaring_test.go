@@ -0,0 +1,90 @@
+package bitstream
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestBitmapWords(t *testing.T) {
+	t.Run("RoundTrip", func(t *testing.T) {
+		reader := NewBitReader([]uint8{0x80, 0x01, 0xF0}, 0, 0)
+		reader.SetBits(20)
+		words := reader.BitmapWords()
+		// bits 0, 15, 16..19
+		if want := []uint64{1 | 1<<15 | 0xF<<16}; !slices.Equal(words, want) {
+			t.Errorf("BitmapWords() = %#x; want %#x", words, want)
+		}
+		back := NewBitReaderBitmap(words, 20)
+		if back.Bits() != 20 {
+			t.Errorf("Bits() = %d; want 20", back.Bits())
+		}
+		if got := back.Read64R(20, 0); got != 0x8001F {
+			t.Errorf("Read64R() = %#x; want 0x8001f", got)
+		}
+	})
+
+	t.Run("Stats", func(t *testing.T) {
+		words := []uint64{1<<63 | 1<<62, 1 | 1<<5}
+		s := NewBitReaderBitmap(words, 128).BitmapStats()
+		if s != (BitmapStats{Bits: 128, Ones: 4, Runs: 2}) {
+			t.Errorf("BitmapStats() = %+v; want {128 4 2}", s)
+		}
+		if got := s.Density(); got != 4.0/128 {
+			t.Errorf("Density() = %v; want %v", got, 4.0/128)
+		}
+		if got := (BitmapStats{}).Density(); got != 0 {
+			t.Errorf("Density() of empty = %v; want 0", got)
+		}
+	})
+}
+
+func TestContainers(t *testing.T) {
+	const n = 4 << 16
+	bs := NewBitSet[uint64](0, 0)
+	// chunk 0: sparse, chunk 1: empty, chunk 2: long runs, chunk 3: dense and scattered
+	for _, i := range []int{3, 100, 65535} {
+		bs.Set(i)
+	}
+	for i := 2<<16 + 10; i < 2<<16+20000; i++ {
+		bs.Set(i)
+	}
+	for i := 3 << 16; i < n; i += 3 {
+		bs.Set(i)
+	}
+	reader := NewBitReader(bs.Data(), 0, 0)
+	reader.SetBits(n)
+
+	var got []Container
+	for c := range reader.Containers() {
+		got = append(got, c)
+	}
+	if len(got) != 3 {
+		t.Fatalf("Containers() yielded %d containers; want 3", len(got))
+	}
+	if c := got[0]; c.Key != 0 || c.Kind != ArrayContainer || !slices.Equal(c.Array, []uint16{3, 100, 65535}) {
+		t.Errorf("container 0 = %d %v %v; want 0 array [3 100 65535]", c.Key, c.Kind, c.Array)
+	}
+	if c := got[1]; c.Key != 2 || c.Kind != RunContainer || c.Cardinality != 19990 ||
+		!slices.Equal(c.Runs, []Interval16{{Start: 10, Length: 19989}}) {
+		t.Errorf("container 1 = %d %v %d %v; want 2 run 19990 [{10 19989}]", c.Key, c.Kind, c.Cardinality, c.Runs)
+	}
+	c := got[2]
+	if c.Key != 3 || c.Kind != BitmapContainer || len(c.Bitmap) != 1024 || c.Cardinality != 21846 {
+		t.Errorf("container 2 = %d %v %d words %d ones; want 3 bitmap 1024 words 21846 ones", c.Key, c.Kind, len(c.Bitmap), c.Cardinality)
+	}
+	if c.Bitmap[0] != 0x9249249249249249 || c.Bitmap[1023]>>63 != 1 {
+		t.Errorf("container 2 words = %#x ... %#x", c.Bitmap[0], c.Bitmap[1023])
+	}
+	if ContainerKind(9).String() != "unknown" || RunContainer.String() != "run" {
+		t.Errorf("ContainerKind.String() returned unexpected names")
+	}
+
+	t.Run("TrailingRun", func(t *testing.T) {
+		r := NewBitReader([]uint8{0x0F, 0xFF}, 0, 0)
+		for c := range r.Containers() {
+			if c.Kind != RunContainer || !slices.Equal(c.Runs, []Interval16{{Start: 4, Length: 11}}) {
+				t.Errorf("container = %v %v; want run [{4 11}]", c.Kind, c.Runs)
+			}
+		}
+	})
+}
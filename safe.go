@@ -0,0 +1,41 @@
+package bitstream
+
+import "strings"
+
+// Try calls fn and returns its error, turning a panic raised by this package for an invalid
+// argument, such as the padding checks of the constructors or the width checks of the Read
+// and Write methods, into an error wrapping ErrInvalidArgument. It lets code that takes
+// widths, paddings or parameters from untrusted input be embedded in a server or fuzzed
+// without crashing:
+//
+//	err := bitstream.Try(func() error {
+//		r := bitstream.NewBitReader(data, lp, rp)
+//		v, err := r.ReadBits(width)
+//		...
+//	})
+//
+// Panics that do not come from this package, including runtime errors, are not recovered,
+// so fuzzing still reports genuine bugs.
+func Try(fn func() error) (err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			if e, ok := v.(*WidthError); ok {
+				err = e
+				return
+			}
+			msg, ok := v.(string)
+			if !ok || !strings.HasPrefix(msg, "bitstream: ") {
+				panic(v)
+			}
+			err = argumentError(msg)
+		}
+	}()
+	return fn()
+}
+
+// argumentError is the error Try returns for a recovered panic, carrying its message.
+type argumentError string
+
+func (e argumentError) Error() string { return string(e) }
+
+func (e argumentError) Unwrap() error { return ErrInvalidArgument }
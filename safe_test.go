@@ -0,0 +1,85 @@
+package bitstream
+
+import (
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestTry(t *testing.T) {
+	t.Run("Panics", func(t *testing.T) {
+		tests := map[string]func() error{
+			"padding": func() error {
+				NewBitReader([]uint8{0}, 4, 4)
+				return nil
+			},
+			"width": func() error {
+				_, err := NewBitReader([]uint64{0, 0}, 0, 0).ReadBits(65)
+				return err
+			},
+			"writer": func() error {
+				NewBitWriter[uint16](0, 0).WriteRice(-1, 0)
+				return nil
+			},
+		}
+		for name, fn := range tests {
+			err := Try(fn)
+			if !errors.Is(err, ErrInvalidArgument) {
+				t.Errorf("%s: Try() = %v; want ErrInvalidArgument", name, err)
+			}
+		}
+		err := Try(tests["padding"])
+		if got, want := err.Error(), "bitstream: padding sum must be less than element bit size"; got != want {
+			t.Errorf("Error() = %q; want %q", got, want)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		if err := Try(func() error { return nil }); err != nil {
+			t.Errorf("Try() = %v; want nil", err)
+		}
+		err := Try(func() error {
+			_, err := NewBitReader([]uint8{0}, 0, 0).ReadBits(9)
+			return err
+		})
+		if err != io.ErrUnexpectedEOF {
+			t.Errorf("Try() = %v; want io.ErrUnexpectedEOF", err)
+		}
+	})
+
+	t.Run("Foreign", func(t *testing.T) {
+		defer func() {
+			if v := recover(); v != "boom" {
+				t.Errorf("recover() = %v; want boom", v)
+			}
+		}()
+		Try(func() error { panic("boom") })
+		t.Error("Try() recovered a foreign panic")
+	})
+}
+
+func FuzzTry(f *testing.F) {
+	f.Add([]byte{0xAB, 0xCD}, uint8(0), uint8(0), uint8(8), uint8(3))
+	f.Add([]byte{0xFF}, uint8(4), uint8(4), uint8(65), uint8(0))
+	f.Add([]byte{}, uint8(1), uint8(2), uint8(0), uint8(200))
+	f.Fuzz(func(t *testing.T, data []byte, lp, rp, width, k uint8) {
+		err := Try(func() error {
+			r := NewBitReader(data, int(lp), int(rp))
+			for {
+				if _, err := r.ReadBits(int(width)); err != nil {
+					return err
+				}
+				if _, err := r.ReadRice(int(k)); err != nil {
+					return err
+				}
+				r.AlignTo(int(k))
+				if width == 0 {
+					return nil
+				}
+			}
+		})
+		if err != nil && !errors.Is(err, ErrInvalidArgument) && err != io.EOF && err != io.ErrUnexpectedEOF && err != ErrOverflow {
+			t.Errorf("unexpected error %v", err)
+		}
+	})
+}
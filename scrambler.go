@@ -0,0 +1,112 @@
+package bitstream
+
+import "math/bits"
+
+// Scrambler is a linear-feedback shift register scrambler.
+//
+// The feedback polynomial is given without its constant term: bit k-1 of poly is set for
+// every x^k term, and degree is the highest such k. For example the 802.11 scrambler
+// x^7 + x^4 + 1 is poly 0x48 with degree 7, and the DVB randomizer 1 + x^14 + x^15 is
+// poly 0x6000 with degree 15. The register value seed holds the most recent bit in bit 0.
+//
+// An additive (synchronous) scrambler XORs the data with the register's own output,
+// so scrambling and descrambling are the same operation and both ends must start from
+// the same seed, as in 802.11, DVB and SONET. A multiplicative (self-synchronizing)
+// scrambler feeds the scrambled bits back into the register, so a descrambler recovers
+// after degree bits regardless of its seed.
+//
+// A Scrambler is not safe for concurrent use.
+type Scrambler struct {
+	poly           uint64
+	mask           uint64
+	seed           uint64
+	state          uint64
+	multiplicative bool
+}
+
+// NewAdditiveScrambler returns an additive scrambler with the given polynomial and seed.
+//
+// Panics if degree is not between 1 and 64 or poly has bits at or above degree.
+func NewAdditiveScrambler(poly uint64, degree int, seed uint64) *Scrambler {
+	return newScrambler(poly, degree, seed, false)
+}
+
+// NewMultiplicativeScrambler returns a self-synchronizing scrambler with the given polynomial and seed.
+//
+// Panics if degree is not between 1 and 64 or poly has bits at or above degree.
+func NewMultiplicativeScrambler(poly uint64, degree int, seed uint64) *Scrambler {
+	return newScrambler(poly, degree, seed, true)
+}
+
+func newScrambler(poly uint64, degree int, seed uint64, multiplicative bool) *Scrambler {
+	if degree < 1 || degree > 64 {
+		panic("bitstream: scrambler degree must be between 1 and 64")
+	}
+	mask := ^uint64(0) >> (64 - degree)
+	if poly&^mask != 0 || poly>>(degree-1) == 0 {
+		panic("bitstream: scrambler polynomial does not match its degree")
+	}
+	return &Scrambler{poly: poly, mask: mask, seed: seed & mask, state: seed & mask, multiplicative: multiplicative}
+}
+
+// Reset loads the seed back into the register.
+func (s *Scrambler) Reset() {
+	s.state = s.seed
+}
+
+// ScrambleBit scrambles one bit, given in the lowest bit of b, and returns the result.
+func (s *Scrambler) ScrambleBit(b uint64) uint64 {
+	key := uint64(bits.OnesCount64(s.state&s.poly) & 1)
+	out := (b ^ key) & 1
+	if s.multiplicative {
+		s.shift(out)
+	} else {
+		s.shift(key)
+	}
+	return out
+}
+
+// DescrambleBit reverses ScrambleBit for one bit, given in the lowest bit of b.
+func (s *Scrambler) DescrambleBit(b uint64) uint64 {
+	key := uint64(bits.OnesCount64(s.state&s.poly) & 1)
+	b &= 1
+	if s.multiplicative {
+		s.shift(b)
+	} else {
+		s.shift(key)
+	}
+	return b ^ key
+}
+
+func (s *Scrambler) shift(b uint64) {
+	s.state = (s.state<<1 | b) & s.mask
+}
+
+// Scramble scrambles the remaining bits of src, starting at its cursor, and appends them to dst.
+// The cursor of src is advanced to the end of its valid bits, and the register state carries
+// over, so a stream can be scrambled in several calls.
+func Scramble[T, U Unsigned](s *Scrambler, src *BitReader[T], dst *BitWriter[U]) {
+	transform(src, dst, s.ScrambleBit)
+}
+
+// Descramble reverses Scramble, reading from the cursor of src and appending to dst.
+func Descramble[T, U Unsigned](s *Scrambler, src *BitReader[T], dst *BitWriter[U]) {
+	transform(src, dst, s.DescrambleBit)
+}
+
+// transform passes the remaining bits of src through fn and appends the results to dst.
+func transform[T, U Unsigned](src *BitReader[T], dst *BitWriter[U], fn func(uint64) uint64) {
+	dst.lock()
+	defer dst.unlock()
+	dst.grow(max(src.bits-src.pos, 0))
+	for src.pos < src.bits {
+		k := min(64, src.bits-src.pos)
+		in := src.bitsAt(src.pos, k)
+		var out uint64
+		for i := k - 1; i >= 0; i-- {
+			out = out<<1 | fn(in>>i)
+		}
+		dst.writeBits(out, k)
+		src.pos += k
+	}
+}
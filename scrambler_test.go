@@ -0,0 +1,86 @@
+package bitstream
+
+import "testing"
+
+func TestScrambler(t *testing.T) {
+	t.Run("802.11", func(t *testing.T) {
+		// IEEE 802.11 (17.3.5.5): with an all-ones state, the scrambler x^7 + x^4 + 1
+		// produces this 127-bit sequence, which repeats.
+		const seq = "00001110111100101100100100000010001001100010111010110110000011001101010011100111101101000010101011111010010100011011100011111111"
+		s := NewAdditiveScrambler(0x48, 7, 0x7F)
+		zeros := NewBitReader(make([]uint8, 32), 0, 0)
+		out := NewBitWriter[uint8](0, 0)
+		Scramble(s, zeros, out)
+		r := NewBitReader(out.Data(), 0, 0)
+		for i := range 256 {
+			bit, _ := r.ReadBit()
+			if want := seq[i%127] == '1'; bit != want {
+				t.Fatalf("keystream bit %d = %v; want %v", i, bit, want)
+			}
+		}
+	})
+
+	payload := NewBitWriter[uint16](1, 1)
+	for i := range 50 {
+		payload.WriteBits(uint64(i*i), 11)
+	}
+
+	t.Run("Additive", func(t *testing.T) {
+		src := NewBitReader(payload.Data(), 1, 1)
+		src.SetBits(payload.Bits())
+		scrambled := NewBitWriter[uint32](0, 0)
+		Scramble(NewAdditiveScrambler(0x6000, 15, 0b100101010000000), src, scrambled)
+		if scrambled.Bits() != 50*11 {
+			t.Fatalf("Bits() = %d; want %d", scrambled.Bits(), 50*11)
+		}
+		if src.Pos() != src.Bits() {
+			t.Errorf("src.Pos() = %d; want %d", src.Pos(), src.Bits())
+		}
+		plain := NewBitWriter[uint16](1, 1)
+		s := NewAdditiveScrambler(0x6000, 15, 0b100101010000000)
+		sr := NewBitReader(scrambled.Data(), 0, 0)
+		sr.SetBits(scrambled.Bits())
+		Descramble(s, sr, plain)
+		if got, want := plain.ToHex(), payload.ToHex(); got != want {
+			t.Errorf("Descramble() = %s; want %s", got, want)
+		}
+	})
+
+	t.Run("Multiplicative", func(t *testing.T) {
+		src := NewBitReader(payload.Data(), 1, 1)
+		src.SetBits(payload.Bits())
+		scrambled := NewBitWriter[uint8](0, 0)
+		Scramble(NewMultiplicativeScrambler(0x60, 7, 0x12), src, scrambled)
+		sr := NewBitReader(scrambled.Data(), 0, 0)
+		sr.SetBits(scrambled.Bits())
+
+		// A descrambler with the wrong seed resynchronizes after 7 bits.
+		plain := NewBitWriter[uint16](1, 1)
+		Descramble(NewMultiplicativeScrambler(0x60, 7, 0), sr, plain)
+		pr := NewBitReader(plain.Data(), 1, 1)
+		pr.SetBits(plain.Bits())
+		orig := NewBitReader(payload.Data(), 1, 1)
+		orig.SetBits(payload.Bits())
+		if !EqualRange(pr, orig, 7, 7, orig.Bits()-7) {
+			t.Error("Descramble() did not resynchronize after 7 bits")
+		}
+	})
+
+	t.Run("Reset", func(t *testing.T) {
+		s := NewAdditiveScrambler(0x48, 7, 0x7F)
+		first := s.ScrambleBit(1)<<1 | s.ScrambleBit(1)
+		s.Reset()
+		if again := s.ScrambleBit(1)<<1 | s.ScrambleBit(1); again != first {
+			t.Errorf("after Reset() = %02b; want %02b", again, first)
+		}
+	})
+
+	t.Run("Panic", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("expected panic for polynomial above its degree")
+			}
+		}()
+		NewAdditiveScrambler(0x80, 7, 0)
+	})
+}
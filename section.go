@@ -0,0 +1,94 @@
+package bitstream
+
+import "io"
+
+// SectionReader reads a fixed range of bits of a BitReader with its own offset, like
+// io.SectionReader. The random-access methods ReadBitAt, ReadBitsAt and ReadAt do not use
+// a cursor and may be called from several goroutines at once, so disjoint (or overlapping)
+// sections of one buffer can be decoded in parallel. The cursor methods are not safe for
+// concurrent use; call Reader to give each goroutine its own cursor instead.
+type SectionReader[T Unsigned] struct {
+	s   *BitReader[T]
+	r   *BitReader[T]
+	off int
+}
+
+// SectionBitReader returns a SectionReader over the n bits of r starting at off, sharing
+// its data. The cursor of r is neither used nor moved.
+//
+// Panics if off < 0, n < 0, or off+n > r.Bits().
+func SectionBitReader[T Unsigned](r *BitReader[T], off, n int) *SectionReader[T] {
+	if off < 0 || n < 0 || n > r.bits-off {
+		panic("bitstream: slice bounds out of range")
+	}
+	return &SectionReader[T]{s: r.Slice(off, off+n), r: r, off: off}
+}
+
+// Size returns the length of the section in bits.
+func (s *SectionReader[T]) Size() int {
+	return s.s.bits
+}
+
+// Outer returns the underlying reader, offset and length passed to SectionBitReader.
+func (s *SectionReader[T]) Outer() (r *BitReader[T], off, n int) {
+	return s.r, s.off, s.s.bits
+}
+
+// Reader returns a new BitReader over the section with its cursor at 0, for parsing it
+// with the full set of BitReader methods independently of other goroutines.
+func (s *SectionReader[T]) Reader() *BitReader[T] {
+	return s.s.Slice(0, s.s.bits)
+}
+
+// ReadBitAt reads the bit at pos within the section, with the errors of BitReader.ReadBitAt.
+func (s *SectionReader[T]) ReadBitAt(pos int) (bool, error) {
+	return s.s.ReadBitAt(pos)
+}
+
+// ReadBitsAt reads bits bits at pos within the section, with the errors of BitReader.ReadBitsAt.
+//
+// Panics if bits > 64.
+func (s *SectionReader[T]) ReadBitsAt(pos, bits int) (uint64, error) {
+	return s.s.ReadBitsAt(pos, bits)
+}
+
+// ReadAt implements io.ReaderAt: it reads len(p) bytes packed MSB-first from the bit at
+// 8*off within the section. If the section ends first it returns the bytes read, with the
+// last padded with zero bits if the section length is not a multiple of 8, and io.EOF.
+func (s *SectionReader[T]) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, ErrNegativePosition
+	}
+	if off >= int64(s.s.bits+7)/8 {
+		return 0, io.EOF
+	}
+	r := *s.s
+	r.pos = int(off) * 8
+	n, _ := r.Read(p)
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// Pos returns the cursor within the section.
+func (s *SectionReader[T]) Pos() int {
+	return s.s.pos
+}
+
+// SeekBit sets the cursor within the section as BitReader.SeekBit does.
+func (s *SectionReader[T]) SeekBit(offset int64, whence int) (int64, error) {
+	return s.s.SeekBit(offset, whence)
+}
+
+// ReadBits reads bits bits at the cursor with the errors of BitReader.ReadBits.
+//
+// Panics if bits > 64.
+func (s *SectionReader[T]) ReadBits(bits int) (uint64, error) {
+	return s.s.ReadBits(bits)
+}
+
+// Read implements io.Reader like BitReader.Read, within the section.
+func (s *SectionReader[T]) Read(p []byte) (int, error) {
+	return s.s.Read(p)
+}
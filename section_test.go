@@ -0,0 +1,81 @@
+package bitstream
+
+import (
+	"io"
+	"sync"
+	"testing"
+)
+
+func TestSectionBitReader(t *testing.T) {
+	data := []uint8{0xDE, 0xAD, 0xBE, 0xEF, 0x01}
+	reader := NewBitReader(data, 0, 0)
+	s := SectionBitReader(reader, 4, 20) // E AD BE
+	if s.Size() != 20 {
+		t.Errorf("Size() = %d; want 20", s.Size())
+	}
+	if r, off, n := s.Outer(); r != reader || off != 4 || n != 20 {
+		t.Errorf("Outer() = %p, %d, %d; want %p, 4, 20", r, off, n, reader)
+	}
+	if v, _ := s.ReadBitsAt(0, 12); v != 0xEAD {
+		t.Errorf("ReadBitsAt(0, 12) = %#x; want 0xead", v)
+	}
+	if _, err := s.ReadBitsAt(16, 8); err != io.ErrUnexpectedEOF {
+		t.Errorf("ReadBitsAt(16, 8) error = %v; want io.ErrUnexpectedEOF", err)
+	}
+	if bit, _ := s.ReadBitAt(19); bit != false {
+		t.Errorf("ReadBitAt(19) = true; want false")
+	}
+
+	p := make([]byte, 3)
+	if n, err := s.ReadAt(p, 1); n != 2 || err != io.EOF || p[0] != 0xDB || p[1] != 0xE0 {
+		t.Errorf("ReadAt(p, 1) = %d, %v, %x; want 2, io.EOF, dbe0", n, err, p[:n])
+	}
+	if n, err := s.ReadAt(p, 3); n != 0 || err != io.EOF {
+		t.Errorf("ReadAt(p, 3) = %d, %v; want 0, io.EOF", n, err)
+	}
+	if _, err := s.ReadAt(p, -1); err != ErrNegativePosition {
+		t.Errorf("ReadAt(p, -1) error = %v; want ErrNegativePosition", err)
+	}
+	if s.Pos() != 0 {
+		t.Errorf("Pos() after ReadAt = %d; want 0", s.Pos())
+	}
+
+	if v, _ := s.ReadBits(8); v != 0xEA {
+		t.Errorf("ReadBits(8) = %#x; want 0xea", v)
+	}
+	s.SeekBit(-4, io.SeekEnd)
+	q := make([]byte, 2)
+	if n, _ := s.Read(q); n != 1 || q[0] != 0xE0 {
+		t.Errorf("Read() = %d, %x; want 1, e0", n, q[0])
+	}
+	if reader.Pos() != 0 {
+		t.Errorf("underlying Pos() = %d; want 0", reader.Pos())
+	}
+
+	t.Run("Concurrent", func(t *testing.T) {
+		big := make([]uint64, 64)
+		for i := range big {
+			big[i] = uint64(i) * 0x0101010101010101
+		}
+		r := NewBitReader(big, 0, 0)
+		var wg sync.WaitGroup
+		for i := range 8 {
+			sec := SectionBitReader(r, i*512, 512)
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := range 8 {
+					if v, _ := sec.ReadBitsAt(j*64, 64); v != big[i*8+j] {
+						t.Errorf("section %d ReadBitsAt(%d, 64) = %#x; want %#x", i, j*64, v, big[i*8+j])
+					}
+				}
+				sr := sec.Reader()
+				sr.Skip(64)
+				if v, _ := sr.ReadBits(64); v != big[i*8+1] {
+					t.Errorf("section %d Reader().ReadBits(64) = %#x; want %#x", i, v, big[i*8+1])
+				}
+			}()
+		}
+		wg.Wait()
+	})
+}
@@ -0,0 +1,25 @@
+package bitstream
+
+// Slice returns a reader over the valid bits in [from, to) that shares the data of r.
+// Bit 0 of the new reader is bit from of r, its Bits() is to-from, and its cursor starts at 0,
+// so a nested structure such as a payload inside a header can be parsed with its own cursor
+// and bounds. Neither the cursor nor the limit of r is affected.
+//
+// Panics if from < 0, from > to, or to > Bits().
+func (r *BitReader[T]) Slice(from, to int) *BitReader[T] {
+	if from < 0 || from > to || to > r.bits {
+		panic("bitstream: slice bounds out of range")
+	}
+	start, end := r.off+from, r.off+to
+	return &BitReader[T]{
+		data: r.data[start/r.s : (end+r.s-1)/r.s],
+		bits: to - from,
+		s:    r.s,
+		msb:  r.msb,
+		lp:   r.lp,
+		rp:   r.rp,
+		off:  start % r.s,
+
+		detailed: r.detailed,
+	}
+}
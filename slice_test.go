@@ -0,0 +1,80 @@
+package bitstream
+
+import (
+	"fmt"
+	"io"
+	"testing"
+)
+
+func TestSlice(t *testing.T) {
+	data := []uint16{0x0123, 0x4567, 0x89AB, 0xCDEF}
+	for _, tt := range []struct{ lp, rp int }{{0, 0}, {3, 2}} {
+		t.Run(fmt.Sprintf("lp%d_rp%d", tt.lp, tt.rp), func(t *testing.T) {
+			reader := NewBitReader(data, tt.lp, tt.rp)
+			reader.Seek(7)
+			from, to := 5, reader.Bits()-3
+			sub := reader.Slice(from, to)
+
+			if sub.Bits() != to-from || sub.Pos() != 0 {
+				t.Fatalf("Bits(), Pos() = %d, %d; want %d, 0", sub.Bits(), sub.Pos(), to-from)
+			}
+			if reader.Pos() != 7 {
+				t.Errorf("parent Pos() = %d; want 7", reader.Pos())
+			}
+			for i := range sub.Bits() {
+				want, _ := reader.ReadBitAt(from + i)
+				if got, _ := sub.ReadBitAt(i); got != want {
+					t.Errorf("ReadBitAt(%d) = %v; want %v", i, got, want)
+				}
+			}
+			for i := 0; i+13 <= sub.Bits(); i++ {
+				want, _ := reader.ReadBitsAt(from+i, 13)
+				if got, _ := sub.ReadBitsAt(i, 13); got != want {
+					t.Errorf("ReadBitsAt(%d, 13) = %b; want %b", i, got, want)
+				}
+			}
+			if got, want := sub.Count(0, sub.Bits()), reader.Count(from, to); got != want {
+				t.Errorf("Count() = %d; want %d", got, want)
+			}
+			if got, want := sub.FindNextSet(0), reader.FindNextSet(from)-from; got != want {
+				t.Errorf("FindNextSet(0) = %d; want %d", got, want)
+			}
+			if got, want := sub.FindNextClear(3), reader.FindNextClear(from+3)-from; got != want {
+				t.Errorf("FindNextClear(3) = %d; want %d", got, want)
+			}
+			if got, want := sub.String(), reader.Slice(from, to).String(); got != want {
+				t.Errorf("String() = %q; want %q", got, want)
+			}
+
+			nested := sub.Slice(2, 10)
+			want, _ := reader.ReadBitsAt(from+2, 8)
+			if got, err := nested.ReadBits(8); got != want || err != nil {
+				t.Errorf("nested ReadBits(8) = %b, %v; want %b, nil", got, err, want)
+			}
+			if _, err := nested.ReadBit(); err != io.EOF {
+				t.Errorf("nested ReadBit() past end error = %v; want io.EOF", err)
+			}
+			nested.SetBits(100)
+			if nested.Bits() != 8 {
+				t.Errorf("SetBits(100) on slice changed Bits() to %d; want 8", nested.Bits())
+			}
+		})
+	}
+
+	t.Run("Empty", func(t *testing.T) {
+		reader := NewBitReader([]uint8{0xFF}, 0, 0)
+		sub := reader.Slice(8, 8)
+		if _, err := sub.ReadBit(); err != io.EOF {
+			t.Errorf("ReadBit() error = %v; want io.EOF", err)
+		}
+	})
+
+	t.Run("Panic", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("expected panic for to > Bits()")
+			}
+		}()
+		NewBitReader([]uint8{0xFF}, 0, 0).Slice(0, 9)
+	})
+}
@@ -0,0 +1,72 @@
+package bitstream
+
+import "math"
+
+// WriterStats summarizes the contents of a BitWriter, to help analyze the entropy
+// and storage overhead of a format.
+type WriterStats struct {
+	Bits         int // Number of written bits
+	StorageBytes int // Size in bytes of the elements holding them, including padding
+	Ones         int // Number of written bits that are 1
+	Zeros        int // Number of written bits that are 0
+
+	// Widths[n] counts the fields of n bits appended since TrackWidths was enabled,
+	// up to the widest field written. It is nil if width tracking is off.
+	Widths []int
+}
+
+// Entropy returns the Shannon entropy per bit of the written bits, between 0 and 1,
+// treating each bit as an independent sample. It is 0 for an empty stream.
+func (s WriterStats) Entropy() float64 {
+	if s.Bits == 0 || s.Ones == 0 || s.Zeros == 0 {
+		return 0
+	}
+	p := float64(s.Ones) / float64(s.Bits)
+	return -p*math.Log2(p) - (1-p)*math.Log2(1-p)
+}
+
+// Overhead returns the fraction of storage not holding written bits: element padding
+// and the unused tail of the last element. It is 0 for an empty stream.
+func (s WriterStats) Overhead() float64 {
+	if s.StorageBytes == 0 {
+		return 0
+	}
+	return 1 - float64(s.Bits)/float64(8*s.StorageBytes)
+}
+
+// Stats returns statistics about the bits written so far.
+func (w *BitWriter[T]) Stats() WriterStats {
+	w.lock()
+	defer w.unlock()
+	r := w.reader()
+	s := WriterStats{
+		Bits:         w.bits,
+		StorageBytes: len(w.data) * w.size() / 8,
+		Ones:         r.Count(0, w.bits),
+	}
+	s.Zeros = s.Bits - s.Ones
+	if w.widths != nil {
+		s.Widths = w.widths[:]
+		for len(s.Widths) > 0 && s.Widths[len(s.Widths)-1] == 0 {
+			s.Widths = s.Widths[:len(s.Widths)-1]
+		}
+		s.Widths = append([]int{}, s.Widths...)
+	}
+	return s
+}
+
+// TrackWidths turns recording of field widths for Stats on or off; it is off by default.
+// While on, every field appended at the end of the stream adds one to the histogram entry
+// for its width. Codes written in several parts, such as WriteUE, add one entry per part,
+// and bulk methods such as Write, PackAll and Append add 64-bit fields.
+// Turning it off discards the histogram.
+func (w *BitWriter[T]) TrackWidths(on bool) {
+	w.lock()
+	defer w.unlock()
+	switch {
+	case !on:
+		w.widths = nil
+	case w.widths == nil:
+		w.widths = new([65]int)
+	}
+}
@@ -0,0 +1,61 @@
+package bitstream
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestWriterStats(t *testing.T) {
+	t.Run("Stats", func(t *testing.T) {
+		writer := NewBitWriter[uint16](2, 2)
+		writer.WriteBits(0xFF, 8)
+		writer.WriteBits(0, 8)
+		s := writer.Stats()
+		if s.Bits != 16 || s.StorageBytes != 4 || s.Ones != 8 || s.Zeros != 8 || s.Widths != nil {
+			t.Errorf("Stats() = %+v; want 16 bits, 4 bytes, 8 ones, 8 zeros, no widths", s)
+		}
+		if got := s.Entropy(); got != 1 {
+			t.Errorf("Entropy() = %v; want 1", got)
+		}
+		if got := s.Overhead(); got != 0.5 {
+			t.Errorf("Overhead() = %v; want 0.5", got)
+		}
+	})
+
+	t.Run("Entropy", func(t *testing.T) {
+		s := WriterStats{Bits: 4, Ones: 1, Zeros: 3}
+		want := -0.25*math.Log2(0.25) - 0.75*math.Log2(0.75)
+		if got := s.Entropy(); math.Abs(got-want) > 1e-12 {
+			t.Errorf("Entropy() = %v; want %v", got, want)
+		}
+		if got := (WriterStats{Bits: 8, Zeros: 8}).Entropy(); got != 0 {
+			t.Errorf("Entropy() of constant bits = %v; want 0", got)
+		}
+		if got := (WriterStats{}).Overhead(); got != 0 {
+			t.Errorf("Overhead() of empty stream = %v; want 0", got)
+		}
+	})
+
+	t.Run("TrackWidths", func(t *testing.T) {
+		writer := NewBitWriter[uint8](0, 0)
+		writer.WriteBits(1, 3)
+		writer.TrackWidths(true)
+		writer.WriteBits(1, 3)
+		writer.WriteBits(2, 5)
+		writer.WriteBool(true)
+		writer.WriteUE(2) // 0 then 11
+		s := writer.Stats()
+		if want := []int{0, 2, 1, 1, 0, 1}; !slices.Equal(s.Widths, want) {
+			t.Errorf("Widths = %v; want %v", s.Widths, want)
+		}
+		s.Widths[1] = 100
+		if writer.Stats().Widths[1] != 2 {
+			t.Error("Stats().Widths shares storage with the writer")
+		}
+		writer.TrackWidths(false)
+		if s := writer.Stats(); s.Widths != nil {
+			t.Errorf("Widths after TrackWidths(false) = %v; want nil", s.Widths)
+		}
+	})
+}
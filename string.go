@@ -0,0 +1,88 @@
+package bitstream
+
+import (
+	"io"
+	"strings"
+)
+
+// ReadString reads a text field of 8-bit bytes at the cursor, at any bit alignment.
+// If lengthBits is positive, the field starts with a lengthBits-wide byte count;
+// if it is 0, the bytes run up to a terminating NUL byte, which is consumed but not returned.
+// The bytes are returned as is; use utf8.ValidString to check for UTF-8.
+// Returns io.EOF if no valid bits remain and io.ErrUnexpectedEOF if the field is truncated
+// or the terminator is missing. The cursor is not moved on error.
+// Panics if lengthBits is negative or greater than 64.
+func (r *BitReader[T]) ReadString(lengthBits int) (string, error) {
+	if lengthBits < 0 || lengthBits > 64 {
+		panic("bitstream: invalid string length width")
+	}
+	if r.pos >= r.bits {
+		return "", io.EOF
+	}
+	start := r.pos
+	var b strings.Builder
+	if lengthBits > 0 {
+		n, err := r.ReadBits(lengthBits)
+		if err != nil || n > uint64(r.bits-r.pos)/8 {
+			r.pos = start
+			return "", io.ErrUnexpectedEOF
+		}
+		b.Grow(int(n))
+		for range n {
+			c, _ := r.ReadBits(8)
+			b.WriteByte(byte(c))
+		}
+		return b.String(), nil
+	}
+	for {
+		c, err := r.ReadBits(8)
+		if err != nil {
+			r.pos = start
+			return "", io.ErrUnexpectedEOF
+		}
+		if c == 0 {
+			return b.String(), nil
+		}
+		b.WriteByte(byte(c))
+	}
+}
+
+// WriteString writes s as a text field of 8-bit bytes, at any bit alignment.
+// If lengthBits is positive, the bytes are preceded by a lengthBits-wide byte count;
+// if it is 0, they are followed by a NUL byte.
+// Returns ErrOverflow if len(s) does not fit in lengthBits, or ErrInvalidFormat if s
+// contains a NUL byte in null-terminated mode, and ErrBudgetExceeded if the field would
+// exceed the limit set by NewBitWriterLimit; nothing is written on error.
+// Panics if lengthBits is negative or greater than 64.
+func (w *BitWriter[T]) WriteString(s string, lengthBits int) error {
+	if lengthBits < 0 || lengthBits > 64 {
+		panic("bitstream: invalid string length width")
+	}
+	if lengthBits == 0 && strings.IndexByte(s, 0) >= 0 {
+		return ErrInvalidFormat
+	}
+	if lengthBits > 0 && lengthBits < 64 && uint64(len(s)) >= 1<<lengthBits {
+		return ErrOverflow
+	}
+	w.lock()
+	defer w.unlock()
+	n := 8 * len(s)
+	if lengthBits > 0 {
+		n += lengthBits
+	} else {
+		n += 8
+	}
+	if !w.reserveBits(n) {
+		return w.err
+	}
+	if lengthBits > 0 {
+		w.writeBits(uint64(len(s)), lengthBits)
+	}
+	for i := 0; i < len(s); i++ {
+		w.writeBits(uint64(s[i]), 8)
+	}
+	if lengthBits == 0 {
+		w.writeBits(0, 8)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package bitstream
+
+import (
+	"io"
+	"testing"
+)
+
+func TestString(t *testing.T) {
+	t.Run("LengthPrefixed", func(t *testing.T) {
+		writer := NewBitWriter[uint32](0, 0)
+		writer.WriteBits(0b101, 3)
+		if err := writer.WriteString("héllo", 5); err != nil {
+			t.Fatalf("WriteString() error = %v; want nil", err)
+		}
+		writer.WriteString("", 5)
+		if got, want := writer.Bits(), 3+5+6*8+5; got != want {
+			t.Errorf("Bits() = %d; want %d", got, want)
+		}
+		reader := NewBitReader(writer.Data(), 0, 0)
+		reader.SetBits(writer.Bits())
+		reader.Skip(3)
+		if s, err := reader.ReadString(5); s != "héllo" || err != nil {
+			t.Errorf("ReadString(5) = %q, %v; want \"héllo\", nil", s, err)
+		}
+		if s, err := reader.ReadString(5); s != "" || err != nil {
+			t.Errorf("ReadString(5) = %q, %v; want \"\", nil", s, err)
+		}
+		if _, err := reader.ReadString(5); err != io.EOF {
+			t.Errorf("ReadString(5) at end error = %v; want io.EOF", err)
+		}
+	})
+
+	t.Run("NullTerminated", func(t *testing.T) {
+		writer := NewBitWriter[uint8](1, 0)
+		writer.WriteBool(true)
+		writer.WriteString("ab", 0)
+		writer.WriteString("c", 0)
+		reader := NewBitReader(writer.Data(), 1, 0)
+		reader.SetBits(writer.Bits())
+		reader.Skip(1)
+		if s, err := reader.ReadString(0); s != "ab" || err != nil {
+			t.Errorf("ReadString(0) = %q, %v; want \"ab\", nil", s, err)
+		}
+		if s, err := reader.ReadString(0); s != "c" || err != nil {
+			t.Errorf("ReadString(0) = %q, %v; want \"c\", nil", s, err)
+		}
+	})
+
+	t.Run("Truncated", func(t *testing.T) {
+		writer := NewBitWriter[uint8](0, 0)
+		writer.WriteString("abc", 8)
+		reader := NewBitReader(writer.Data(), 0, 0)
+		reader.SetBits(writer.Bits() - 1)
+		if _, err := reader.ReadString(8); err != io.ErrUnexpectedEOF || reader.Pos() != 0 {
+			t.Errorf("ReadString(8) = %v at %d; want io.ErrUnexpectedEOF at 0", err, reader.Pos())
+		}
+		reader = NewBitReader([]uint8{'a', 'b'}, 0, 0)
+		if _, err := reader.ReadString(0); err != io.ErrUnexpectedEOF || reader.Pos() != 0 {
+			t.Errorf("ReadString(0) = %v at %d; want io.ErrUnexpectedEOF at 0", err, reader.Pos())
+		}
+	})
+
+	t.Run("WriteErrors", func(t *testing.T) {
+		writer := NewBitWriter[uint8](0, 0)
+		if err := writer.WriteString("abcd", 2); err != ErrOverflow {
+			t.Errorf("WriteString(\"abcd\", 2) error = %v; want ErrOverflow", err)
+		}
+		if err := writer.WriteString("a\x00b", 0); err != ErrInvalidFormat {
+			t.Errorf("WriteString(\"a\\x00b\", 0) error = %v; want ErrInvalidFormat", err)
+		}
+		if writer.Bits() != 0 {
+			t.Errorf("Bits() = %d; want 0", writer.Bits())
+		}
+	})
+}
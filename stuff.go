@@ -0,0 +1,86 @@
+package bitstream
+
+// StuffPolarity selects which runs of identical bits trigger a stuff bit.
+type StuffPolarity int
+
+const (
+	StuffOnes  StuffPolarity = iota // runs of 1s get a 0 inserted, as in HDLC and USB
+	StuffZeros                      // runs of 0s get a 1 inserted
+	StuffBoth                       // runs of either value get the complement inserted, as in CAN
+)
+
+// Stuff copies the remaining bits of src to dst, inserting the complementary bit after every
+// run of run identical bits of the given polarity, so that the output never holds longer runs.
+// HDLC uses (5, StuffOnes), USB (6, StuffOnes) and CAN (5, StuffBoth). Stuff bits count
+// towards the next run, and a run that ends the stream is still followed by its stuff bit.
+// src is consumed to its end.
+//
+// Panics if run < 1.
+func Stuff[T, U Unsigned](src *BitReader[T], dst *BitWriter[U], run int, p StuffPolarity) {
+	if run < 1 {
+		panic("bitstream: stuffing run must be positive")
+	}
+	dst.lock()
+	defer dst.unlock()
+	dst.grow(max(src.bits-src.pos, 0))
+	var st stuffState
+	for src.pos < src.bits {
+		b := src.bitsAt(src.pos, 1)
+		src.pos++
+		dst.writeBits(b, 1)
+		if st.next(b, run, p) {
+			dst.writeBits(b^1, 1)
+			st.next(b^1, run, p)
+		}
+	}
+}
+
+// Unstuff copies the remaining bits of src to dst, removing the stuff bits inserted by Stuff
+// with the same run and polarity. A stuffed stream that ends right after a run is accepted.
+// Returns ErrInvalidFormat if the bit after a run is not its complement, as with an HDLC flag
+// or a CAN stuff error; the bits before it have been written to dst and the cursor of src is
+// left on the offending bit.
+//
+// Panics if run < 1.
+func Unstuff[T, U Unsigned](src *BitReader[T], dst *BitWriter[U], run int, p StuffPolarity) error {
+	if run < 1 {
+		panic("bitstream: stuffing run must be positive")
+	}
+	dst.lock()
+	defer dst.unlock()
+	dst.grow(max(src.bits-src.pos, 0))
+	var st stuffState
+	for src.pos < src.bits {
+		b := src.bitsAt(src.pos, 1)
+		if st.stuffed {
+			if b == st.last {
+				return ErrInvalidFormat
+			}
+			src.pos++
+			st.next(b, run, p)
+			continue
+		}
+		src.pos++
+		dst.writeBits(b, 1)
+		st.next(b, run, p)
+	}
+	return nil
+}
+
+// stuffState tracks the current run of identical bits.
+type stuffState struct {
+	last    uint64
+	count   int
+	stuffed bool // the next bit is a stuff bit
+}
+
+// next records bit b and reports whether a stuff bit must follow it.
+func (s *stuffState) next(b uint64, run int, p StuffPolarity) bool {
+	if s.count > 0 && b == s.last {
+		s.count++
+	} else {
+		s.last, s.count = b, 1
+	}
+	s.stuffed = s.count == run && (p == StuffBoth || p == StuffOnes && b == 1 || p == StuffZeros && b == 0)
+	return s.stuffed
+}
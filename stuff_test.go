@@ -0,0 +1,98 @@
+package bitstream
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+func TestStuff(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		run     int
+		p       StuffPolarity
+		stuffed string
+	}{
+		{"HDLC", "0111111011111100", 5, StuffOnes, "011111010111110100"},
+		{"HDLCTrailingRun", "11111", 5, StuffOnes, "111110"},
+		{"HDLCZerosIgnored", "00000000", 5, StuffOnes, "00000000"},
+		{"Zeros", "000001", 5, StuffZeros, "0000011"},
+		{"USB", "1111111", 6, StuffOnes, "11111101"},
+		// CAN: the stuff bit starts the next run
+		{"CAN", "1111100001", 5, StuffBoth, "111110000011"},
+		{"CANCascade", "0000011110", 5, StuffBoth, "000001111100"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := bitsReader(tt.in)
+			writer := NewBitWriter[uint8](0, 0)
+			Stuff(src, writer, tt.run, tt.p)
+			if got := bitsString(writer); got != tt.stuffed {
+				t.Errorf("Stuff(%s) = %s; want %s", tt.in, got, tt.stuffed)
+			}
+			out := NewBitWriter[uint16](0, 0)
+			if err := Unstuff(bitsReader(tt.stuffed), out, tt.run, tt.p); err != nil {
+				t.Fatalf("Unstuff() error = %v; want nil", err)
+			}
+			if got := bitsString(out); got != tt.in {
+				t.Errorf("Unstuff(%s) = %s; want %s", tt.stuffed, got, tt.in)
+			}
+		})
+	}
+
+	t.Run("Violation", func(t *testing.T) {
+		src := bitsReader("0111111001")
+		out := NewBitWriter[uint8](0, 0)
+		if err := Unstuff(src, out, 5, StuffOnes); err != ErrInvalidFormat {
+			t.Errorf("Unstuff() of HDLC flag error = %v; want ErrInvalidFormat", err)
+		}
+		if src.Pos() != 6 || bitsString(out) != "011111" {
+			t.Errorf("Unstuff() stopped at %d with %s; want 6 with 011111", src.Pos(), bitsString(out))
+		}
+	})
+
+	t.Run("Random", func(t *testing.T) {
+		for _, p := range []StuffPolarity{StuffOnes, StuffZeros, StuffBoth} {
+			writer := NewBitWriter[uint64](0, 0)
+			for range 20 {
+				writer.WriteBits(rand.Uint64()&rand.Uint64(), 64)
+			}
+			stuffed := NewBitWriter[uint32](1, 2)
+			Stuff(NewBitReader(writer.Data(), 0, 0), stuffed, 3, p)
+			src := NewBitReader(stuffed.Data(), 1, 2)
+			src.SetBits(stuffed.Bits())
+			out := NewBitWriter[uint64](0, 0)
+			if err := Unstuff(src, out, 3, p); err != nil {
+				t.Fatalf("Unstuff() error = %v; want nil", err)
+			}
+			if Compare(NewBitReader(out.Data(), 0, 0), NewBitReader(writer.Data(), 0, 0)) != 0 || out.Bits() != writer.Bits() {
+				t.Errorf("Unstuff(Stuff(x)) != x for polarity %d", p)
+			}
+		}
+	})
+}
+
+// bitsReader returns a reader over a string of binary digits.
+func bitsReader(s string) *BitReader[uint8] {
+	w := NewBitWriter[uint8](0, 0)
+	for _, c := range s {
+		w.WriteBool(c == '1')
+	}
+	r := NewBitReader(w.Data(), 0, 0)
+	r.SetBits(len(s))
+	return r
+}
+
+// bitsString returns the written bits as binary digits without grouping.
+func bitsString[T Unsigned](w *BitWriter[T]) string {
+	b := make([]byte, w.Bits())
+	r := NewBitReader(w.Data(), w.lp, w.rp)
+	for i := range b {
+		bit, _ := r.ReadBitAt(i)
+		b[i] = '0'
+		if bit {
+			b[i] = '1'
+		}
+	}
+	return string(b)
+}
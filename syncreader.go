@@ -0,0 +1,119 @@
+package bitstream
+
+import "sync"
+
+// SyncBitReader is a BitReader guarded by a mutex, so one reader can be shared by
+// several goroutines. Each method call is atomic with respect to the others.
+// Sequences of cursor operations that must not interleave, such as a read followed by
+// a seek, should be run together with Do.
+type SyncBitReader[T Unsigned] struct {
+	mu sync.Mutex
+	r  *BitReader[T]
+}
+
+// NewSyncBitReader creates a SyncBitReader over data with the given padding,
+// with the same semantics as NewBitReader.
+//
+// Panics if leftPadd + rightPadd >= element bit size.
+func NewSyncBitReader[T Unsigned](data []T, leftPadd, rightPadd int) *SyncBitReader[T] {
+	return &SyncBitReader[T]{r: NewBitReader(data, leftPadd, rightPadd)}
+}
+
+// Do calls fn with the underlying reader while holding the lock and returns its error.
+// fn must not retain the reader or call methods of s.
+func (s *SyncBitReader[T]) Do(fn func(r *BitReader[T]) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return fn(s.r)
+}
+
+// ReadBit is the locked equivalent of BitReader.ReadBit.
+func (s *SyncBitReader[T]) ReadBit() (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.r.ReadBit()
+}
+
+// ReadBits is the locked equivalent of BitReader.ReadBits.
+func (s *SyncBitReader[T]) ReadBits(bits int) (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.r.ReadBits(bits)
+}
+
+// PeekBits is the locked equivalent of BitReader.PeekBits.
+func (s *SyncBitReader[T]) PeekBits(bits int) (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.r.PeekBits(bits)
+}
+
+// ReadBitAt is the locked equivalent of BitReader.ReadBitAt.
+func (s *SyncBitReader[T]) ReadBitAt(pos int) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.r.ReadBitAt(pos)
+}
+
+// ReadBitsAt is the locked equivalent of BitReader.ReadBitsAt.
+func (s *SyncBitReader[T]) ReadBitsAt(pos, bits int) (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.r.ReadBitsAt(pos, bits)
+}
+
+// Read is the locked equivalent of BitReader.Read.
+func (s *SyncBitReader[T]) Read(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.r.Read(p)
+}
+
+// Pos is the locked equivalent of BitReader.Pos.
+func (s *SyncBitReader[T]) Pos() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.r.Pos()
+}
+
+// Seek is the locked equivalent of BitReader.Seek.
+func (s *SyncBitReader[T]) Seek(pos int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.r.Seek(pos)
+}
+
+// SeekBit is the locked equivalent of BitReader.SeekBit.
+func (s *SyncBitReader[T]) SeekBit(offset int64, whence int) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.r.SeekBit(offset, whence)
+}
+
+// Skip is the locked equivalent of BitReader.Skip.
+func (s *SyncBitReader[T]) Skip(n int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.r.Skip(n)
+}
+
+// Bits is the locked equivalent of BitReader.Bits.
+func (s *SyncBitReader[T]) Bits() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.r.Bits()
+}
+
+// SetBits is the locked equivalent of BitReader.SetBits.
+func (s *SyncBitReader[T]) SetBits(bits int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.r.SetBits(bits)
+}
+
+// Count is the locked equivalent of BitReader.Count.
+func (s *SyncBitReader[T]) Count(from, to int) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.r.Count(from, to)
+}
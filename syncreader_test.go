@@ -0,0 +1,72 @@
+package bitstream
+
+import (
+	"io"
+	"sync"
+	"testing"
+)
+
+func TestSyncBitReader(t *testing.T) {
+	data := make([]uint16, 64)
+	for i := range data {
+		data[i] = uint16(i)
+	}
+
+	t.Run("Concurrent", func(t *testing.T) {
+		s := NewSyncBitReader(data, 0, 0)
+		var mu sync.Mutex
+		seen := make(map[uint64]bool)
+		var wg sync.WaitGroup
+		for range 8 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for {
+					v, err := s.ReadBits(16)
+					if err == io.EOF {
+						return
+					}
+					if err != nil {
+						t.Errorf("ReadBits(16) error = %v", err)
+						return
+					}
+					mu.Lock()
+					seen[v] = true
+					mu.Unlock()
+					if got, err := s.ReadBitsAt(int(v)*16, 16); got != v || err != nil {
+						t.Errorf("ReadBitsAt(%d, 16) = %d, %v; want %d, nil", v*16, got, err, v)
+					}
+				}
+			}()
+		}
+		wg.Wait()
+		if len(seen) != len(data) {
+			t.Errorf("read %d distinct values; want %d", len(seen), len(data))
+		}
+		if s.Pos() != s.Bits() {
+			t.Errorf("Pos() = %d; want %d", s.Pos(), s.Bits())
+		}
+	})
+
+	t.Run("Do", func(t *testing.T) {
+		s := NewSyncBitReader(data, 0, 0)
+		var wg sync.WaitGroup
+		for range 8 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for range 100 {
+					s.Do(func(r *BitReader[uint16]) error {
+						start := r.Pos()
+						r.Skip(16)
+						return r.Seek(start)
+					})
+				}
+			}()
+		}
+		wg.Wait()
+		if s.Pos() != 0 {
+			t.Errorf("Pos() = %d; want 0", s.Pos())
+		}
+	})
+}
@@ -0,0 +1,95 @@
+package bitstream
+
+// TeeReader reads from a BitReader and copies every bit it consumes to a BitWriter,
+// like io.TeeReader, so a parser can keep the exact raw bits of what it parsed, for
+// example to repackage a header unchanged or to log the bits behind a decoding error.
+// Bits skipped over are copied too. Each bit is copied once: peeking and random access copy
+// nothing, and after a backward seek the bits are not copied again until the cursor passes
+// the furthest position already copied.
+// A TeeReader is not safe for concurrent use.
+type TeeReader[T, U Unsigned] struct {
+	r    *BitReader[T]
+	w    *BitWriter[U]
+	next int // position of the first bit not yet copied
+}
+
+// TeeBitReader returns a TeeReader that reads from r at its cursor and appends the consumed
+// bits to w.
+func TeeBitReader[T, U Unsigned](r *BitReader[T], w *BitWriter[U]) *TeeReader[T, U] {
+	return &TeeReader[T, U]{r: r, w: w, next: r.pos}
+}
+
+// Do calls fn with the underlying reader and, if fn moved the cursor forward, copies the
+// bits between the old and new positions that were not copied before to the writer,
+// so any BitReader method such as ReadUE or ReadString can be teed.
+// Returns the error of fn.
+func (t *TeeReader[T, U]) Do(fn func(r *BitReader[T]) error) error {
+	start := t.r.pos
+	err := fn(t.r)
+	t.copy(start)
+	return err
+}
+
+// copy appends the valid bits in [start, cursor) that were not copied before to the writer.
+// A cursor beyond the valid bits copies only up to their end.
+func (t *TeeReader[T, U]) copy(start int) {
+	start = max(start, t.next)
+	if t.r.pos <= start {
+		return
+	}
+	t.next = t.r.pos
+	end := min(t.r.pos, t.r.bits)
+	if end <= start {
+		return
+	}
+	t.w.lock()
+	defer t.w.unlock()
+	t.w.grow(end - start)
+	for pos := start; pos < end; {
+		k := min(64, end-pos)
+		t.w.writeBits(t.r.bitsAt(pos, k), k)
+		pos += k
+	}
+}
+
+// ReadBit is the teeing equivalent of BitReader.ReadBit.
+func (t *TeeReader[T, U]) ReadBit() (bool, error) {
+	start := t.r.pos
+	bit, err := t.r.ReadBit()
+	t.copy(start)
+	return bit, err
+}
+
+// ReadBits is the teeing equivalent of BitReader.ReadBits.
+func (t *TeeReader[T, U]) ReadBits(bits int) (uint64, error) {
+	start := t.r.pos
+	v, err := t.r.ReadBits(bits)
+	t.copy(start)
+	return v, err
+}
+
+// Read is the teeing equivalent of BitReader.Read.
+func (t *TeeReader[T, U]) Read(p []byte) (int, error) {
+	start := t.r.pos
+	n, err := t.r.Read(p)
+	t.copy(start)
+	return n, err
+}
+
+// Skip is the teeing equivalent of BitReader.Skip.
+func (t *TeeReader[T, U]) Skip(n int) error {
+	start := t.r.pos
+	err := t.r.Skip(n)
+	t.copy(start)
+	return err
+}
+
+// Pos returns the cursor of the underlying reader.
+func (t *TeeReader[T, U]) Pos() int {
+	return t.r.Pos()
+}
+
+// Bits returns the number of valid bits of the underlying reader.
+func (t *TeeReader[T, U]) Bits() int {
+	return t.r.Bits()
+}
@@ -0,0 +1,78 @@
+package bitstream
+
+import "testing"
+
+func TestTeeBitReader(t *testing.T) {
+	src := NewBitWriter[uint8](0, 0)
+	src.WriteBits(0b101, 3)
+	src.WriteUE(5)
+	src.WriteBits(0xABCD, 16)
+	src.WriteBits(0x3, 2)
+	reader := NewBitReader(src.Data(), 0, 0)
+	reader.SetBits(src.Bits())
+
+	out := NewBitWriter[uint64](0, 0)
+	tee := TeeBitReader(reader, out)
+	if v, _ := tee.ReadBits(3); v != 0b101 {
+		t.Errorf("ReadBits(3) = %b; want 101", v)
+	}
+	var ue uint64
+	tee.Do(func(r *BitReader[uint8]) (err error) {
+		ue, err = r.ReadUE()
+		return err
+	})
+	if ue != 5 {
+		t.Errorf("ReadUE() = %d; want 5", ue)
+	}
+	// peeking and seeking back copy nothing, and bits are not copied twice
+	tee.Do(func(r *BitReader[uint8]) error {
+		r.PeekBits(8)
+		return r.Seek(0)
+	})
+	tee.Do(func(r *BitReader[uint8]) error {
+		return r.Seek(8)
+	})
+	if out.Bits() != 8 {
+		t.Errorf("Bits() after peek and seek = %d; want 8", out.Bits())
+	}
+	if tee.Pos() != 8 {
+		t.Errorf("Pos() = %d; want 8", tee.Pos())
+	}
+	tee.Skip(16)
+	bit, _ := tee.ReadBit()
+	if bit != true {
+		t.Errorf("ReadBit() = %v; want true", bit)
+	}
+	p := make([]byte, 1)
+	tee.Read(p)
+	if _, err := tee.ReadBits(8); err == nil {
+		t.Errorf("ReadBits(8) past the end succeeded")
+	}
+	if out.Bits() != tee.Pos() || tee.Pos() != tee.Bits() {
+		t.Errorf("Bits() = %d, Pos() = %d; want both %d", out.Bits(), tee.Pos(), tee.Bits())
+	}
+	outReader := NewBitReader(out.Data(), 0, 0)
+	if !EqualRange(reader, outReader, 0, 0, reader.Bits()) {
+		t.Errorf("teed bits = %s; want %s", out.ToHex(), src.ToHex())
+	}
+}
+
+func TestTeeBitReaderPastEnd(t *testing.T) {
+	reader := NewBitReader([]uint8{0xAB, 0xC0}, 0, 0)
+	reader.SetBits(12)
+	out := NewBitWriter[uint8](0, 0)
+	tee := TeeBitReader(reader, out)
+	tee.ReadBits(4)
+	tee.Do(func(r *BitReader[uint8]) error {
+		return r.Seek(r.Bits() + 20)
+	})
+	if out.Bits() != 12 || out.ToHex() != "abc" {
+		t.Errorf("after seeking past the end: Bits() = %d, ToHex() = %s; want 12, abc", out.Bits(), out.ToHex())
+	}
+	tee.Do(func(r *BitReader[uint8]) error {
+		return r.Seek(r.Bits() + 30)
+	})
+	if out.Bits() != 12 {
+		t.Errorf("after a second seek: Bits() = %d; want 12", out.Bits())
+	}
+}
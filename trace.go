@@ -0,0 +1,95 @@
+package bitstream
+
+import (
+	"fmt"
+	"io"
+)
+
+// Tracer receives the fields read from a BitReader or written to a BitWriter, so that an
+// annotated bit layout of a parse or encode session can be produced for debugging or
+// documentation. pos is the bit position of the field, bits its width, and value its bits,
+// right-aligned.
+//
+// Readers report fields consumed by ReadBit, ReadBits and Read, including those read by
+// higher-level methods built on them; bits passed over without being read, such as the
+// zero prefix of a unary or Exp-Golomb code, skipped bits and alignment, are not reported.
+// Writers report every field appended or overwritten, including alignment padding, placeholders
+// filled in and bits rewritten by ReverseBits; codes written in several parts, such as WriteUE,
+// are reported one part at a time, and appended streams 64 bits at a time. Changes that are
+// not writes, such as Truncate, Reset, UnmarshalBinary and the fill of unused bits, are not
+// reported.
+type Tracer interface {
+	OnRead(pos, bits int, value uint64)
+	OnWrite(pos, bits int, value uint64)
+}
+
+// SetTracer sets the Tracer notified of every field read from r, or removes it if t is nil.
+// Readers returned by Clone share the tracer; readers returned by Slice do not.
+func (r *BitReader[T]) SetTracer(t Tracer) {
+	r.tracer = t
+}
+
+// SetTracer sets the Tracer notified of every field written to w, or removes it if t is nil.
+// The tracer is called with w locked, so it must not call methods of w.
+func (w *BitWriter[T]) SetTracer(t Tracer) {
+	w.lock()
+	defer w.unlock()
+	w.tracer = t
+}
+
+// traceWrite reports a written field to the tracer, if any.
+func (w *BitWriter[T]) traceWrite(pos, bits int, data uint64) {
+	if w.tracer == nil || bits <= 0 {
+		return
+	}
+	if bits < 64 {
+		data &= 1<<bits - 1
+	}
+	w.tracer.OnWrite(pos, bits, data)
+}
+
+// traceAppend reports the nbits bits at start, appended without writeBits, to the tracer
+// and the width histogram, in 64-bit fields as writeBits would have received them.
+func (w *BitWriter[T]) traceAppend(start, nbits int) {
+	if w.tracer == nil && w.widths == nil {
+		return
+	}
+	r := w.reader()
+	for pos := start; pos < start+nbits; pos += 64 {
+		k := min(64, start+nbits-pos)
+		if w.widths != nil {
+			w.widths[k]++
+		}
+		w.traceWrite(pos, k, r.bitsAt(pos, k))
+	}
+}
+
+// TextTracer returns a Tracer that writes one line per field to w, giving the direction,
+// bit position, width, and value in binary and decimal:
+//
+//	write      0  3 101 (5)
+//	read      12  1 1 (1)
+//
+// Errors from w are ignored.
+func TextTracer(w io.Writer) Tracer {
+	return textTracer{w}
+}
+
+type textTracer struct {
+	w io.Writer
+}
+
+func (t textTracer) OnRead(pos, bits int, value uint64) {
+	fmt.Fprintf(t.w, "read  %6d %2d %0*b (%d)\n", pos, bits, bits, value, value)
+}
+
+func (t textTracer) OnWrite(pos, bits int, value uint64) {
+	fmt.Fprintf(t.w, "write %6d %2d %0*b (%d)\n", pos, bits, bits, value, value)
+}
+
+func b2u(b bool) uint64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,123 @@
+package bitstream
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+type traceEvent struct {
+	write     bool
+	pos, bits int
+	value     uint64
+}
+
+type recordTracer struct {
+	events []traceEvent
+}
+
+func (t *recordTracer) OnRead(pos, bits int, value uint64) {
+	t.events = append(t.events, traceEvent{false, pos, bits, value})
+}
+
+func (t *recordTracer) OnWrite(pos, bits int, value uint64) {
+	t.events = append(t.events, traceEvent{true, pos, bits, value})
+}
+
+func TestTracer(t *testing.T) {
+	t.Run("Writer", func(t *testing.T) {
+		var tr recordTracer
+		writer := NewBitWriter[uint8](0, 0)
+		writer.SetTracer(&tr)
+		writer.WriteBits(0xFD, 3)
+		writer.WriteUE(2)
+		writer.AlignToByte()
+		writer.WriteBitAt(1, true)
+		writer.WriteBitsAt(4, 2, 0)
+		writer.SetTracer(nil)
+		writer.WriteBits(0, 8)
+		want := []traceEvent{
+			{true, 0, 3, 0b101},
+			{true, 3, 1, 0},
+			{true, 4, 2, 0b11},
+			{true, 6, 2, 0},
+			{true, 1, 1, 1},
+			{true, 4, 2, 0},
+		}
+		if !slices.Equal(tr.events, want) {
+			t.Errorf("events = %v; want %v", tr.events, want)
+		}
+	})
+
+	t.Run("FillAndAppend", func(t *testing.T) {
+		var tr recordTracer
+		writer := NewBitWriter[uint8](0, 0)
+		writer.TrackWidths(true)
+		writer.SetTracer(&tr)
+		p := writer.Reserve(4)
+		writer.WriteBits(0xF, 4)
+		p.Fill(0b1010)
+		other := NewBitWriter[uint8](0, 0)
+		other.WriteBits(0xC3, 8)
+		writer.Append(other) // whole elements are copied
+		writer.WriteBits(1, 1)
+		writer.Append(other) // copied bit by bit
+		writer.ReverseBits(0, 4)
+		want := []traceEvent{
+			{true, 0, 4, 0},
+			{true, 4, 4, 0xF},
+			{true, 0, 4, 0b1010},
+			{true, 8, 8, 0xC3},
+			{true, 16, 1, 1},
+			{true, 17, 8, 0xC3},
+			{true, 0, 2, 0b01},
+			{true, 2, 2, 0b01},
+		}
+		if !slices.Equal(tr.events, want) {
+			t.Errorf("events = %v; want %v", tr.events, want)
+		}
+		if got := writer.Stats().Widths; !slices.Equal(got, []int{0, 1, 0, 0, 2, 0, 0, 0, 2}) {
+			t.Errorf("Stats().Widths = %v; want [0 1 0 0 2 0 0 0 2]", got)
+		}
+	})
+
+	t.Run("Reader", func(t *testing.T) {
+		var tr recordTracer
+		reader := NewBitReader([]uint8{0b10101001, 0b00111111, 0x12}, 0, 0)
+		reader.SetTracer(&tr)
+		reader.ReadBit()
+		reader.ReadBits(3)
+		reader.ReadUE() // 0: a lone 1 bit, which is skipped
+		reader.ReadUE() // 3 from 00100: prefix skipped, 2 suffix bits read
+		reader.ReadBits(0)
+		buf := make([]byte, 2)
+		reader.Read(buf)
+		want := []traceEvent{
+			{false, 0, 1, 1},
+			{false, 1, 3, 0b010},
+			{false, 8, 2, 0b00},
+			{false, 10, 8, 0b11111100},
+			{false, 18, 6, 0b010010},
+		}
+		if !slices.Equal(tr.events, want) {
+			t.Errorf("events = %v; want %v", tr.events, want)
+		}
+		if clone := reader.Clone(); clone.tracer == nil {
+			t.Error("Clone() dropped the tracer")
+		}
+	})
+
+	t.Run("TextTracer", func(t *testing.T) {
+		var sb strings.Builder
+		writer := NewBitWriter[uint8](0, 0)
+		writer.SetTracer(TextTracer(&sb))
+		writer.WriteBits(5, 3)
+		reader := NewBitReader(writer.Data(), 0, 0)
+		reader.SetTracer(TextTracer(&sb))
+		reader.ReadBits(3)
+		want := "write      0  3 101 (5)\nread       0  3 101 (5)\n"
+		if sb.String() != want {
+			t.Errorf("output = %q; want %q", sb.String(), want)
+		}
+	})
+}
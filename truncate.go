@@ -0,0 +1,47 @@
+package bitstream
+
+// Truncate discards all bits from position n on, so that Bits() becomes n, releasing the
+// elements past the new end and resetting the unused bits of the last element to the fill.
+// The cursor is moved back to n if it was beyond it, and an ErrBudgetExceeded recorded
+// by a writer with a bit limit is cleared. It is typically used to roll back a field that
+// turned out not to fit or to be unnecessary.
+//
+// Panics if n < 0 or n > Bits().
+func (w *BitWriter[T]) Truncate(n int) {
+	w.lock()
+	defer w.unlock()
+	if n < 0 || n > w.bits {
+		panic("bitstream: truncation out of range")
+	}
+	w.truncate(n)
+}
+
+func (w *BitWriter[T]) truncate(n int) {
+	w.data = w.data[:(n+w.s-1)/w.s]
+	w.bits = n
+	w.pos = min(w.pos, n)
+	w.err = nil
+	w.fillTail()
+}
+
+// SetBits sets the total number of valid bits, mirroring BitReader.SetBits: a smaller
+// value truncates the stream as Truncate does, and a larger one extends it with fill bits
+// (zeros by default) without moving the cursor.
+//
+// Panics if bits < 0.
+func (w *BitWriter[T]) SetBits(bits int) {
+	if bits < 0 {
+		panic("bitstream: negative bit count")
+	}
+	w.lock()
+	defer w.unlock()
+	if bits <= w.bits {
+		w.truncate(bits)
+		return
+	}
+	w.grow(bits - w.bits)
+	for w.bits < bits {
+		k := min(64, bits-w.bits)
+		w.writeBits(w.fillBits(w.bits, k), k)
+	}
+}
@@ -0,0 +1,71 @@
+package bitstream
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestTruncate(t *testing.T) {
+	t.Run("Truncate", func(t *testing.T) {
+		writer := NewBitWriter[uint8](0, 0)
+		writer.WriteBits(0xABCD, 16)
+		writer.WriteBits(0x7, 3)
+		writer.Truncate(12)
+		if writer.Bits() != 12 || writer.Pos() != 0 {
+			t.Errorf("Bits(), Pos() = %d, %d; want 12, 0", writer.Bits(), writer.Pos())
+		}
+		if got, want := writer.Data(), []uint8{0xAB, 0xC0}; !slices.Equal(got, want) {
+			t.Errorf("Data() = %x; want %x", got, want)
+		}
+		writer.WriteBits(0x5, 4)
+		if got := writer.ToHex(); got != "abc5" {
+			t.Errorf("ToHex() after rewrite = %s; want abc5", got)
+		}
+		writer.Seek(14)
+		writer.Truncate(0)
+		if writer.Bits() != 0 || writer.Pos() != 0 || len(writer.Data()) != 0 {
+			t.Errorf("after Truncate(0): Bits() %d, Pos() %d, %d elements; want all 0", writer.Bits(), writer.Pos(), len(writer.Data()))
+		}
+	})
+
+	t.Run("Fill", func(t *testing.T) {
+		writer := NewBitWriter[uint16](0, 4)
+		writer.SetFill(FillOnes)
+		writer.WriteBits(0, 20)
+		writer.Truncate(3)
+		if got, want := writer.Data(), []uint16{0x1FFF}; !slices.Equal(got, want) {
+			t.Errorf("Data() = %04x; want %04x", got, want)
+		}
+		if err := writer.Validate(); err != nil {
+			t.Errorf("Validate() = %v; want nil", err)
+		}
+	})
+
+	t.Run("SetBits", func(t *testing.T) {
+		writer := NewBitWriter[uint32](0, 0)
+		writer.WriteBits(0xFF, 8)
+		writer.SetBits(100)
+		if writer.Bits() != 100 || writer.Pos() != 0 || len(writer.Data()) != 4 {
+			t.Errorf("after SetBits(100): Bits() %d, Pos() %d, %d elements; want 100, 0, 4", writer.Bits(), writer.Pos(), len(writer.Data()))
+		}
+		writer.SetBits(4)
+		if got := writer.ToHex(); got != "f" {
+			t.Errorf("ToHex() after SetBits(4) = %s; want f", got)
+		}
+	})
+
+	t.Run("Panics", func(t *testing.T) {
+		for _, n := range []int{-1, 9} {
+			func() {
+				defer func() {
+					if recover() == nil {
+						t.Errorf("Truncate(%d) did not panic", n)
+					}
+				}()
+				writer := NewBitWriter[uint8](0, 0)
+				writer.WriteBits(0, 8)
+				writer.Truncate(n)
+			}()
+		}
+	})
+}
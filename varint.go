@@ -0,0 +1,69 @@
+package bitstream
+
+import "io"
+
+// ReadUvarint aligns the cursor to the next byte boundary and reads an unsigned
+// LEB128 varint (as used by protocol buffers, WebAssembly and encoding/binary).
+// Returns io.EOF if no valid bits remain after alignment, io.ErrUnexpectedEOF if the
+// varint is truncated, and ErrOverflow if it does not fit in 64 bits.
+// The cursor is not moved on error.
+func (r *BitReader[T]) ReadUvarint() (uint64, error) {
+	start := r.pos
+	r.AlignToByte()
+	if r.pos >= r.bits {
+		r.pos = start
+		return 0, io.EOF
+	}
+	var x uint64
+	var s uint
+	for i := 0; ; i++ {
+		b, err := r.ReadBits(8)
+		if err != nil {
+			r.pos = start
+			return 0, io.ErrUnexpectedEOF
+		}
+		if i == 9 && b > 1 {
+			r.pos = start
+			return 0, ErrOverflow
+		}
+		if b < 0x80 {
+			return x | b<<s, nil
+		}
+		x |= (b & 0x7f) << s
+		s += 7
+	}
+}
+
+// ReadVarint aligns the cursor to the next byte boundary and reads a signed
+// LEB128 varint using zig-zag encoding, as written by encoding/binary.PutVarint.
+// Errors are reported as for ReadUvarint.
+func (r *BitReader[T]) ReadVarint() (int64, error) {
+	ux, err := r.ReadUvarint()
+	return unzigzag(ux), err
+}
+
+// WriteUvarint pads the stream to the next byte boundary and writes v as an unsigned
+// LEB128 varint (as used by protocol buffers, WebAssembly and encoding/binary).
+func (w *BitWriter[T]) WriteUvarint(v uint64) {
+	w.lock()
+	defer w.unlock()
+	w.writeUvarint(v)
+}
+
+func (w *BitWriter[T]) writeUvarint(v uint64) {
+	if !w.reserveBits(padLen(w.bits, 8) + uvarintLen(v)) {
+		return
+	}
+	w.alignTo(8)
+	for v >= 0x80 {
+		w.writeBits(v|0x80, 8)
+		v >>= 7
+	}
+	w.writeBits(v, 8)
+}
+
+// WriteVarint pads the stream to the next byte boundary and writes v as a signed
+// LEB128 varint using zig-zag encoding, as encoding/binary.PutVarint does.
+func (w *BitWriter[T]) WriteVarint(v int64) {
+	w.WriteUvarint(zigzag(v))
+}
@@ -0,0 +1,82 @@
+package bitstream
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+	"testing"
+)
+
+func TestVarint(t *testing.T) {
+	t.Run("WriteUvarint", func(t *testing.T) {
+		values := []uint64{0, 1, 127, 128, 300, 1 << 35, math.MaxUint64}
+		for _, v := range values {
+			writer := NewBitWriter[uint8](0, 0)
+			writer.WriteBits(0b101, 3)
+			writer.WriteUvarint(v)
+			want := append([]byte{0b10100000}, binary.AppendUvarint(nil, v)...)
+			if !bytes.Equal(writer.Data(), want) {
+				t.Errorf("WriteUvarint(%d) = %x; want %x", v, writer.Data(), want)
+			}
+		}
+	})
+
+	t.Run("WriteVarint", func(t *testing.T) {
+		values := []int64{0, 1, -1, 63, -64, 64, math.MaxInt64, math.MinInt64}
+		for _, v := range values {
+			writer := NewBitWriter[uint8](0, 0)
+			writer.WriteVarint(v)
+			want := binary.AppendVarint(nil, v)
+			if !bytes.Equal(writer.Data(), want) {
+				t.Errorf("WriteVarint(%d) = %x; want %x", v, writer.Data(), want)
+			}
+		}
+	})
+
+	t.Run("roundTrip", func(t *testing.T) {
+		uvalues := []uint64{0, 1, 127, 128, 300, 1 << 35, math.MaxUint64}
+		svalues := []int64{0, 1, -1, 63, -64, 64, math.MaxInt64, math.MinInt64}
+		writer := NewBitWriter[uint16](1, 2)
+		for i, v := range uvalues {
+			writer.WriteBits(0, i)
+			writer.WriteUvarint(v)
+		}
+		for i, v := range svalues {
+			writer.WriteBits(0, i)
+			writer.WriteVarint(v)
+		}
+		reader := NewBitReader(writer.Data(), 1, 2)
+		reader.SetBits(writer.Bits())
+		for i, want := range uvalues {
+			reader.Skip(i)
+			if got, err := reader.ReadUvarint(); err != nil || got != want {
+				t.Errorf("ReadUvarint() = %d, %v; want %d, nil", got, err, want)
+			}
+		}
+		for i, want := range svalues {
+			reader.Skip(i)
+			if got, err := reader.ReadVarint(); err != nil || got != want {
+				t.Errorf("ReadVarint() = %d, %v; want %d, nil", got, err, want)
+			}
+		}
+		if _, err := reader.ReadUvarint(); err != io.EOF {
+			t.Errorf("ReadUvarint() at end should return io.EOF, got %v", err)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		reader := NewBitReader([]uint8{0xFF, 0x80}, 0, 0)
+		reader.Seek(3)
+		if _, err := reader.ReadUvarint(); err != io.ErrUnexpectedEOF {
+			t.Errorf("ReadUvarint() on truncated varint should return io.ErrUnexpectedEOF, got %v", err)
+		}
+		if reader.Pos() != 3 {
+			t.Errorf("Pos() after failed ReadUvarint() = %d; want 3", reader.Pos())
+		}
+		reader = NewBitReader([]uint8{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0x02}, 0, 0)
+		if _, err := reader.ReadUvarint(); err != ErrOverflow {
+			t.Errorf("ReadUvarint() on 65-bit varint should return ErrOverflow, got %v", err)
+		}
+	})
+}
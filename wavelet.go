@@ -0,0 +1,134 @@
+package bitstream
+
+import (
+	"math/bits"
+	"slices"
+)
+
+// WaveletTree is a succinct index over a sequence of integers that answers, in time
+// proportional to the number of bits per value, which value sits at a position, how often
+// a value occurs in a prefix, and the k-th smallest value in a range.
+//
+// It uses the level-wise (wavelet matrix) layout: level l holds bit l of every value, counted
+// from the most significant, with the values stably reordered by their higher bits, and each
+// level is a bit array indexed by RankSelect.
+// A WaveletTree is safe for concurrent use.
+type WaveletTree struct {
+	levels []*RankSelect
+	zeros  []int // number of 0s in each level
+	n      int
+	width  int
+}
+
+// NewWaveletTree builds a wavelet tree over values, using as many levels as the largest
+// value has bits.
+func NewWaveletTree(values []uint64) *WaveletTree {
+	width := 0
+	if len(values) > 0 {
+		width = bits.Len64(slices.Max(values))
+	}
+	wt := &WaveletTree{n: len(values), width: width}
+	cur := slices.Clone(values)
+	next := make([]uint64, len(values))
+	for l := range width {
+		shift := width - 1 - l
+		w := NewUnsyncBitWriter[uint64](0, 0)
+		w.grow(len(cur))
+		zeros := 0
+		for _, v := range cur {
+			b := v >> shift & 1
+			w.writeBits(b, 1)
+			if b == 0 {
+				zeros++
+			}
+		}
+		z, o := 0, zeros
+		for _, v := range cur {
+			if v>>shift&1 == 0 {
+				next[z] = v
+				z++
+			} else {
+				next[o] = v
+				o++
+			}
+		}
+		cur, next = next, cur
+		r := NewBitReader(w.data, 0, 0)
+		r.bits = w.bits
+		wt.levels = append(wt.levels, NewRankSelect(r))
+		wt.zeros = append(wt.zeros, zeros)
+	}
+	return wt
+}
+
+// Len returns the number of values.
+func (wt *WaveletTree) Len() int {
+	return wt.n
+}
+
+// Access returns the value at position i.
+//
+// Panics if i is not within [0, Len()).
+func (wt *WaveletTree) Access(i int) uint64 {
+	if i < 0 || i >= wt.n {
+		panic("bitstream: index out of range")
+	}
+	var v uint64
+	for l, rs := range wt.levels {
+		if rs.Bit(i) {
+			i = wt.zeros[l] + rs.Rank1(i)
+			v = v<<1 | 1
+		} else {
+			i = rs.Rank0(i)
+			v <<= 1
+		}
+	}
+	return v
+}
+
+// Rank returns the number of occurrences of v in positions [0, i).
+//
+// Panics if i is not within [0, Len()].
+func (wt *WaveletTree) Rank(v uint64, i int) int {
+	if i < 0 || i > wt.n {
+		panic("bitstream: index out of range")
+	}
+	if wt.width < 64 && v>>wt.width != 0 {
+		return 0
+	}
+	s, e := 0, i
+	for l, rs := range wt.levels {
+		if v>>(wt.width-1-l)&1 != 0 {
+			s, e = wt.zeros[l]+rs.Rank1(s), wt.zeros[l]+rs.Rank1(e)
+		} else {
+			s, e = rs.Rank0(s), rs.Rank0(e)
+		}
+	}
+	return e - s
+}
+
+// Quantile returns the k-th smallest value (counting from 0) among positions [from, to),
+// so k = (to-from)/2 gives the median.
+//
+// Panics if the range is not within [0, Len()] or k is not within [0, to-from).
+func (wt *WaveletTree) Quantile(from, to, k int) uint64 {
+	if from < 0 || from > to || to > wt.n {
+		panic("bitstream: slice bounds out of range")
+	}
+	if k < 0 || k >= to-from {
+		panic("bitstream: index out of range")
+	}
+	var v uint64
+	for l, rs := range wt.levels {
+		z := rs.Rank0(to) - rs.Rank0(from)
+		if k < z {
+			from, to = rs.Rank0(from), rs.Rank0(to)
+			v <<= 1
+		} else {
+			k -= z
+			from, to = wt.zeros[l]+rs.Rank1(from), wt.zeros[l]+rs.Rank1(to)
+			v = v<<1 | 1
+		}
+	}
+	return v
+}
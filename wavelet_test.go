@@ -0,0 +1,80 @@
+package bitstream
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestWaveletTree(t *testing.T) {
+	t.Run("Small", func(t *testing.T) {
+		values := []uint64{5, 4, 5, 5, 2, 1, 5, 6, 1, 3, 5, 0}
+		wt := NewWaveletTree(values)
+		if wt.Len() != len(values) {
+			t.Errorf("Len() = %d; want %d", wt.Len(), len(values))
+		}
+		for i, want := range values {
+			if got := wt.Access(i); got != want {
+				t.Errorf("Access(%d) = %d; want %d", i, got, want)
+			}
+		}
+		if got := wt.Rank(5, 9); got != 4 {
+			t.Errorf("Rank(5, 9) = %d; want 4", got)
+		}
+		if got := wt.Rank(7, 12); got != 0 {
+			t.Errorf("Rank(7, 12) = %d; want 0", got)
+		}
+		if got := wt.Rank(100, 12); got != 0 {
+			t.Errorf("Rank(100, 12) = %d; want 0", got)
+		}
+		// positions 2..8: 5 5 2 1 5 6 1 -> sorted 1 1 2 5 5 5 6
+		for k, want := range []uint64{1, 1, 2, 5, 5, 5, 6} {
+			if got := wt.Quantile(2, 9, k); got != want {
+				t.Errorf("Quantile(2, 9, %d) = %d; want %d", k, got, want)
+			}
+		}
+	})
+
+	t.Run("Random", func(t *testing.T) {
+		rnd := rand.New(rand.NewPCG(7, 8))
+		values := make([]uint64, 700)
+		for i := range values {
+			values[i] = rnd.Uint64N(40)
+		}
+		values[100] = 1<<64 - 1
+		wt := NewWaveletTree(values)
+		for range 200 {
+			i := rnd.IntN(len(values))
+			if got := wt.Access(i); got != values[i] {
+				t.Fatalf("Access(%d) = %d; want %d", i, got, values[i])
+			}
+			v := values[rnd.IntN(len(values))]
+			want := 0
+			for _, x := range values[:i] {
+				if x == v {
+					want++
+				}
+			}
+			if got := wt.Rank(v, i); got != want {
+				t.Fatalf("Rank(%d, %d) = %d; want %d", v, i, got, want)
+			}
+			from := rnd.IntN(len(values))
+			to := from + 1 + rnd.IntN(len(values)-from)
+			sorted := slices.Sorted(slices.Values(values[from:to]))
+			k := rnd.IntN(to - from)
+			if got := wt.Quantile(from, to, k); got != sorted[k] {
+				t.Fatalf("Quantile(%d, %d, %d) = %d; want %d", from, to, k, got, sorted[k])
+			}
+		}
+	})
+
+	t.Run("Zeros", func(t *testing.T) {
+		wt := NewWaveletTree([]uint64{0, 0, 0})
+		if wt.Access(1) != 0 || wt.Rank(0, 2) != 2 || wt.Rank(1, 3) != 0 || wt.Quantile(0, 3, 2) != 0 {
+			t.Errorf("queries on all-zero sequence returned wrong results")
+		}
+		if NewWaveletTree(nil).Len() != 0 {
+			t.Errorf("Len() of empty tree != 0")
+		}
+	})
+}
@@ -0,0 +1,23 @@
+package bitstream
+
+// ReadZigZag reads a bits-wide zig-zag code at the cursor and returns the signed value
+// it stands for, undoing WriteZigZag. Errors are reported as for ReadBits.
+func (r *BitReader[T]) ReadZigZag(bits int) (int64, error) {
+	u, err := r.ReadBits(bits)
+	return unzigzag(u), err
+}
+
+// WriteZigZag writes v as a bits-wide zig-zag code, mapping 0, -1, 1, -2, 2, ... to
+// 0, 1, 2, 3, 4, ... as protocol buffers do, so small magnitudes get small codes.
+// Only the low bits of the code are written; a bits-wide field holds -2^(bits-1) to 2^(bits-1)-1.
+func (w *BitWriter[T]) WriteZigZag(v int64, bits int) {
+	w.WriteBits(zigzag(v), bits)
+}
+
+func zigzag(v int64) uint64 {
+	return uint64(v<<1) ^ uint64(v>>63)
+}
+
+func unzigzag(u uint64) int64 {
+	return int64(u>>1) ^ -int64(u&1)
+}
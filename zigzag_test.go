@@ -0,0 +1,46 @@
+package bitstream
+
+import (
+	"math"
+	"testing"
+)
+
+func TestZigZag(t *testing.T) {
+	t.Run("Codes", func(t *testing.T) {
+		tests := []struct {
+			v    int64
+			code uint64
+		}{
+			{0, 0}, {-1, 1}, {1, 2}, {-2, 3}, {2, 4}, {-64, 127}, {63, 126},
+		}
+		writer := NewBitWriter[uint8](0, 0)
+		for _, tt := range tests {
+			writer.WriteZigZag(tt.v, 7)
+		}
+		reader := NewBitReader(writer.Data(), 0, 0)
+		for _, tt := range tests {
+			if got, _ := reader.PeekBits(7); got != tt.code {
+				t.Errorf("WriteZigZag(%d, 7) = %d; want %d", tt.v, got, tt.code)
+			}
+			if got, err := reader.ReadZigZag(7); got != tt.v || err != nil {
+				t.Errorf("ReadZigZag(7) = %d, %v; want %d, nil", got, err, tt.v)
+			}
+		}
+	})
+
+	t.Run("Extremes", func(t *testing.T) {
+		writer := NewBitWriter[uint32](0, 0)
+		for _, v := range []int64{math.MinInt64, math.MaxInt64} {
+			writer.WriteZigZag(v, 64)
+		}
+		reader := NewBitReader(writer.Data(), 0, 0)
+		for _, want := range []int64{math.MinInt64, math.MaxInt64} {
+			if got, _ := reader.ReadZigZag(64); got != want {
+				t.Errorf("ReadZigZag(64) = %d; want %d", got, want)
+			}
+		}
+		if _, err := reader.ReadZigZag(1); err == nil {
+			t.Error("ReadZigZag(1) at end error = nil; want non-nil")
+		}
+	})
+}